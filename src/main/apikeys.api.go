@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"main/utils"
+	"net/http"
+	"strconv"
+)
+
+// HandleCreateAPIKey handles the creation of an API key
+func HandleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read new API key from request body
+	var newAPIKey NewAPIKey
+
+	err = utils.ReadJSONFromRequest(r, &newAPIKey)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Create the API key and write it to the client
+	apiKey, err := CreateAPIKey(session.UserID, newAPIKey)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, apiKey)
+}
+
+// HandleListAPIKeys returns a list of all API keys to the client
+func HandleListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	apiKeys, err := ListAPIKeys()
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, apiKeys)
+}
+
+// HandleReadAPIKey returns a single API key to the client
+func HandleReadAPIKey(w http.ResponseWriter, r *http.Request) {
+	// Read API key ID from URL
+	vars := mux.Vars(r)
+
+	apiKeyID, err := strconv.Atoi(vars["apiKeyId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'apiKeyId' isn't a valid integer.", vars["apiKeyId"])))
+		return
+	}
+
+	// Read the API key from the database and write to the client
+	apiKey, err := ReadAPIKey(apiKeyID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, apiKey)
+}
+
+// HandleListAPIKeyUsage returns the daily usage of an API key to the client
+func HandleListAPIKeyUsage(w http.ResponseWriter, r *http.Request) {
+	// Read API key ID from URL
+	vars := mux.Vars(r)
+
+	apiKeyID, err := strconv.Atoi(vars["apiKeyId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'apiKeyId' isn't a valid integer.", vars["apiKeyId"])))
+		return
+	}
+
+	// Read the usage from the database and write to the client
+	usage, err := ListAPIKeyUsage(apiKeyID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, usage)
+}
+
+// HandleRevokeAPIKey handles the revocation of an API key
+func HandleRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	// Read API key ID from URL
+	vars := mux.Vars(r)
+
+	apiKeyID, err := strconv.Atoi(vars["apiKeyId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'apiKeyId' isn't a valid integer.", vars["apiKeyId"])))
+		return
+	}
+
+	// Revoke the API key and respond
+	err = RevokeAPIKey(apiKeyID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
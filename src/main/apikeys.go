@@ -0,0 +1,287 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"main/utils"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type (
+	// APIKey contains information on an API key, the key itself is only returned once when it is created
+	APIKey struct {
+		ID         int      `json:"id"`
+		Name       string   `json:"name"`
+		Prefix     string   `json:"prefix"`
+		Scopes     []string `json:"scopes"`
+		CreatedBy  int      `json:"createdBy"`
+		CreatedOn  string   `json:"createdOn"`
+		ExpiresOn  string   `json:"expiresOn"`
+		RevokedOn  string   `json:"revokedOn"`
+		LastUsedOn string   `json:"lastUsedOn"`
+		LastUsedIP string   `json:"lastUsedIp"`
+		UsageCount int      `json:"usageCount"`
+	}
+
+	// NewAPIKey contains all information on a to-be created API key
+	NewAPIKey struct {
+		Name      string   `json:"name"`
+		Scopes    []string `json:"scopes"`
+		ExpiresOn string   `json:"expiresOn"`
+	}
+
+	// CreatedAPIKey contains a newly created API key and its secret
+	CreatedAPIKey struct {
+		APIKey APIKey `json:"apiKey"`
+		Key    string `json:"key"`
+	}
+
+	// APIKeyUsage contains the number of requests made with an API key on a single day
+	APIKeyUsage struct {
+		Date     string `json:"date"`
+		Requests int    `json:"requests"`
+	}
+)
+
+const (
+	APIKeyHeader          = "X-API-Key"
+	apiKeyPrefix          = "smds_"
+	apiKeyDisplayLength   = 13
+	DefaultAPIKeyLifetime = 365 * 24 * time.Hour
+	APIKeyUsageDays       = 30
+)
+
+// CreateAPIKey creates a new API key with the given scopes, only the hash of the key is stored
+func CreateAPIKey(adminID int, newAPIKey NewAPIKey) (CreatedAPIKey, error) {
+	// Validate the key
+	if len(strings.TrimSpace(newAPIKey.Name)) == 0 {
+		return CreatedAPIKey{}, utils.BadRequestErrorMessage("API keys must have a name")
+	}
+
+	if len(newAPIKey.Scopes) == 0 {
+		return CreatedAPIKey{}, utils.BadRequestErrorMessage("API keys must have at least one scope")
+	}
+
+	for _, scope := range newAPIKey.Scopes {
+		if !isKnownPermission(scope) {
+			return CreatedAPIKey{}, utils.BadRequestErrorMessage(fmt.Sprintf("Unknown scope '%s'", scope))
+		}
+	}
+
+	expiresOn := time.Now().Add(DefaultAPIKeyLifetime)
+	if len(newAPIKey.ExpiresOn) > 0 {
+		var err error
+		expiresOn, err = time.Parse(time.RFC3339, newAPIKey.ExpiresOn)
+		if err != nil {
+			return CreatedAPIKey{}, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of field 'expiresOn' isn't a valid RFC 3339 time.", newAPIKey.ExpiresOn))
+		}
+	}
+
+	if !expiresOn.After(time.Now()) {
+		return CreatedAPIKey{}, utils.BadRequestErrorMessage("API keys must expire in the future")
+	}
+
+	// Generate and store the key
+	secret, err := utils.RandomToken(24)
+	if err != nil {
+		return CreatedAPIKey{}, utils.InternalServerError(err)
+	}
+
+	key := apiKeyPrefix + secret
+
+	var apiKeyID int
+
+	err = db.QueryRow(`INSERT INTO APIKeys (Name, KeyHash, Prefix, Scopes, CreatedBy, ExpiresOn)
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING ID`, newAPIKey.Name, utils.HashSHA256(key), key[:apiKeyDisplayLength],
+		strings.Join(newAPIKey.Scopes, ","), adminID, expiresOn).Scan(&apiKeyID)
+
+	if err != nil {
+		return CreatedAPIKey{}, utils.InternalServerError(err)
+	}
+
+	apiKey, err := ReadAPIKey(apiKeyID)
+	if err != nil {
+		return CreatedAPIKey{}, err
+	}
+
+	return CreatedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+// readAPIKeyFromRow reads an API key from a row containing all API key columns
+func readAPIKeyFromRow(row interface {
+	Scan(dest ...interface{}) error
+}) (APIKey, error) {
+	var apiKey APIKey
+	var scopes string
+	var createdOn, expiresOn time.Time
+	var revokedOn, lastUsedOn *time.Time
+
+	err := row.Scan(&apiKey.ID, &apiKey.Name, &apiKey.Prefix, &scopes, &apiKey.CreatedBy, &createdOn, &expiresOn, &revokedOn,
+		&lastUsedOn, &apiKey.LastUsedIP, &apiKey.UsageCount)
+
+	if err != nil {
+		return APIKey{}, err
+	}
+
+	apiKey.Scopes = strings.Split(scopes, ",")
+	apiKey.CreatedOn = createdOn.Format(time.RFC3339)
+	apiKey.ExpiresOn = expiresOn.Format(time.RFC3339)
+
+	if revokedOn != nil {
+		apiKey.RevokedOn = revokedOn.Format(time.RFC3339)
+	}
+	if lastUsedOn != nil {
+		apiKey.LastUsedOn = lastUsedOn.Format(time.RFC3339)
+	}
+
+	return apiKey, nil
+}
+
+// ListAPIKeys returns a list of all API keys
+func ListAPIKeys() ([]APIKey, error) {
+	rows, err := db.Query(`SELECT ID, Name, Prefix, Scopes, CreatedBy, CreatedOn, ExpiresOn, RevokedOn, LastUsedOn, COALESCE(LastUsedIP, ''), UsageCount
+	FROM APIKeys
+	ORDER BY ID`)
+
+	if err != nil {
+		return []APIKey{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over all rows and store in slice
+	apiKeys := []APIKey{}
+
+	for rows.Next() {
+		apiKey, err := readAPIKeyFromRow(rows)
+		if err != nil {
+			return []APIKey{}, utils.InternalServerError(err)
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	return apiKeys, nil
+}
+
+// ReadAPIKey returns a single API key
+func ReadAPIKey(apiKeyID int) (APIKey, error) {
+	apiKey, err := readAPIKeyFromRow(db.QueryRow(`SELECT ID, Name, Prefix, Scopes, CreatedBy, CreatedOn, ExpiresOn, RevokedOn, LastUsedOn, COALESCE(LastUsedIP, ''), UsageCount
+	FROM APIKeys
+	WHERE ID = $1`, apiKeyID))
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return APIKey{}, utils.NotFoundErrorMessage(fmt.Sprintf("No API key with ID %d found", apiKeyID))
+		}
+		return APIKey{}, utils.InternalServerError(err)
+	}
+
+	return apiKey, nil
+}
+
+// RevokeAPIKey revokes an API key, after which it is no longer accepted
+func RevokeAPIKey(apiKeyID int) error {
+	result, err := db.Exec(`UPDATE APIKeys SET RevokedOn = NOW() WHERE ID = $1 AND RevokedOn IS NULL`, apiKeyID)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+	if n == 0 {
+		return utils.NotFoundErrorMessage(fmt.Sprintf("No active API key with ID %d found", apiKeyID))
+	}
+
+	return nil
+}
+
+// ListAPIKeyUsage returns the number of requests made with an API key per day, for the last days it was used
+func ListAPIKeyUsage(apiKeyID int) ([]APIKeyUsage, error) {
+	// Check whether the key exists
+	_, err := ReadAPIKey(apiKeyID)
+	if err != nil {
+		return []APIKeyUsage{}, err
+	}
+
+	rows, err := db.Query(`SELECT Day, Requests FROM APIKeyUsage
+	WHERE APIKeyID = $1 AND Day > CURRENT_DATE - $2::INTEGER
+	ORDER BY Day DESC`, apiKeyID, APIKeyUsageDays)
+
+	if err != nil {
+		return []APIKeyUsage{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over all rows and store in slice
+	usage := []APIKeyUsage{}
+	var day time.Time
+	var entry APIKeyUsage
+
+	for rows.Next() {
+		err = rows.Scan(&day, &entry.Requests)
+		if err != nil {
+			return []APIKeyUsage{}, utils.InternalServerError(err)
+		}
+
+		entry.Date = day.Format(DateFormat)
+		usage = append(usage, entry)
+	}
+
+	return usage, nil
+}
+
+// readAPIKeySession validates the API key in the request headers and returns a session for it
+func readAPIKeySession(r *http.Request) (Session, error) {
+	key := r.Header.Get(APIKeyHeader)
+	if len(key) == 0 {
+		return Session{}, utils.UnauthorizedErrorMessage("No X-API-Key header was present")
+	}
+
+	var apiKeyID int
+	var name, scopes string
+	var expiresOn time.Time
+
+	err := db.QueryRow(`SELECT ID, Name, Scopes, ExpiresOn FROM APIKeys
+	WHERE KeyHash = $1 AND RevokedOn IS NULL AND ExpiresOn > NOW()`, utils.HashSHA256(key)).Scan(&apiKeyID, &name, &scopes, &expiresOn)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Session{}, utils.UnauthorizedErrorMessage("Invalid, expired or revoked API key")
+		}
+		return Session{}, utils.InternalServerError(err)
+	}
+
+	return Session{
+		ActorType: APIKeyActor,
+		UserID:    apiKeyID,
+		Username:  name,
+		FullName:  name,
+		Scopes:    strings.Split(scopes, ","),
+		ExpiresAt: expiresOn,
+	}, nil
+}
+
+// recordAPIKeyUsage updates the usage statistics of an API key
+func recordAPIKeyUsage(apiKeyID int, ipAddress string) error {
+	_, err := db.Exec(`UPDATE APIKeys
+	SET LastUsedOn = NOW(), LastUsedIP = $1, UsageCount = UsageCount + 1
+	WHERE ID = $2`, ipAddress, apiKeyID)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	_, err = db.Exec(`INSERT INTO APIKeyUsage (APIKeyID, Day, Requests)
+	VALUES ($1, CURRENT_DATE, 1)
+	ON CONFLICT (APIKeyID, Day) DO UPDATE SET Requests = APIKeyUsage.Requests + 1`, apiKeyID)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
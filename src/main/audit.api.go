@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"main/utils"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// auditSearch reads the audit log search parameters from the query string
+func auditSearch(r *http.Request) url.Values {
+	query := r.URL.Query()
+	search := url.Values{}
+
+	for _, param := range []string{"patientId", "actorType", "actorId", "entityType", "entityId", "action", "limit", "offset"} {
+		search[param] = query[param]
+	}
+
+	return search
+}
+
+// HandleListAuditEntries returns audit log entries matching the query parameters to the client
+func HandleListAuditEntries(w http.ResponseWriter, r *http.Request) {
+	entries, err := ListAuditEntries(auditSearch(r))
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, entries)
+}
+
+// HandleListPatientAuditEntries returns the audit log entries of a patient to the client
+func HandleListPatientAuditEntries(w http.ResponseWriter, r *http.Request) {
+	// Read user ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	// Read the audit log entries and write to the client
+	search := auditSearch(r)
+	search.Set("patientId", strconv.Itoa(userID))
+
+	entries, err := ListAuditEntries(search)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, entries)
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"main/utils"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type (
+	// AuditEntry contains a single change recorded in the audit log
+	AuditEntry struct {
+		ID         int             `json:"id"`
+		ActorType  string          `json:"actorType"`
+		ActorID    int             `json:"actorId"`
+		ActorName  string          `json:"actorName"`
+		Action     string          `json:"action"`
+		EntityType string          `json:"entityType"`
+		EntityID   int             `json:"entityId"`
+		PatientID  int             `json:"patientId,omitempty"`
+		Before     json.RawMessage `json:"before"`
+		After      json.RawMessage `json:"after"`
+		RequestID  string          `json:"requestId"`
+		CreatedOn  string          `json:"createdOn"`
+	}
+
+	// AuditActor contains the actor and request on whose behalf a change is made
+	AuditActor struct {
+		Type      string
+		ID        int
+		Name      string
+		RequestID string
+	}
+)
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+
+	AuditActionArchive = "archive"
+	AuditActionRestore = "restore"
+	AuditActionImport  = "import"
+
+	AuditEntityDose          = "dose"
+	AuditEntityPRNMedication = "prnmedication"
+	AuditEntityUser          = "user"
+	AuditEntityMedication    = "medication"
+	AuditEntityProfile       = "clinicalprofile"
+	AuditEntityMeasurement   = "measurement"
+	AuditEntityCareTeam      = "careteammember"
+	AuditEntityRefill        = "dispenserrefill"
+	AuditEntityInteractions  = "druginteraction"
+
+	DefaultAuditLimit = 100
+	MaxAuditLimit     = 1000
+)
+
+var (
+	auditSearchMapping = NewMapping()
+)
+
+func init() {
+	auditSearchMapping.DefineFieldMapping("patientId", FieldMapping{
+		SearchType: SearchTypeEqual,
+		DBField:    "PatientID",
+	})
+
+	auditSearchMapping.DefineFieldMapping("actorType", FieldMapping{
+		SearchType: SearchTypeEqual,
+		DBField:    "ActorType",
+	})
+
+	auditSearchMapping.DefineFieldMapping("actorId", FieldMapping{
+		SearchType: SearchTypeEqual,
+		DBField:    "ActorID",
+	})
+
+	auditSearchMapping.DefineFieldMapping("entityType", FieldMapping{
+		SearchType: SearchTypeEqual,
+		DBField:    "EntityType",
+	})
+
+	auditSearchMapping.DefineFieldMapping("entityId", FieldMapping{
+		SearchType: SearchTypeEqual,
+		DBField:    "EntityID",
+	})
+
+	auditSearchMapping.DefineFieldMapping("action", FieldMapping{
+		SearchType: SearchTypeEqual,
+		DBField:    "Action",
+	})
+}
+
+// ReadAuditActor returns the actor of a request, on whose behalf changes are recorded in the audit log
+func ReadAuditActor(r *http.Request) (AuditActor, error) {
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		return AuditActor{}, err
+	}
+
+	return AuditActor{
+		Type:      session.ActorType,
+		ID:        session.UserID,
+		Name:      session.Username,
+		RequestID: RequestID(r),
+	}, nil
+}
+
+// RecordAudit appends a change to the audit log within the transaction that makes the change, so a change can't be made
+// without being audited. Before and after are stored as JSON, and may be nil for created and deleted entities. A patient ID
+// of 0 means the entity does not belong to a patient
+func RecordAudit(tx *sql.Tx, actor AuditActor, action, entityType string, entityID, patientID int, before, after interface{}) error {
+	// Serialize the entity states
+	beforeJSON, err := marshalAuditState(before)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	afterJSON, err := marshalAuditState(after)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	var patient interface{}
+	if patientID > 0 {
+		patient = patientID
+	}
+
+	_, err = tx.Exec(`INSERT INTO AuditLog (ActorType, ActorID, ActorName, Action, EntityType, EntityID, PatientID, Before, After, RequestID)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`, actor.Type, actor.ID, actor.Name, action, entityType, entityID, patient,
+		beforeJSON, afterJSON, actor.RequestID)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// marshalAuditState serializes an entity state for the audit log, nil is stored as NULL
+func marshalAuditState(state interface{}) (interface{}, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// ListAuditEntries returns the most recent audit log entries matching a search
+func ListAuditEntries(search url.Values) ([]AuditEntry, error) {
+	// Determine the page of entries to return
+	limit, offset, err := readPageParams(search, DefaultAuditLimit, MaxAuditLimit)
+	if err != nil {
+		return []AuditEntry{}, err
+	}
+
+	// Validate the integer search fields, as they would otherwise result in a database error
+	for _, field := range []string{"patientId", "actorId", "entityId"} {
+		for _, value := range search[field] {
+			if _, err := strconv.Atoi(value); len(value) > 0 && err != nil {
+				return []AuditEntry{}, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of query parameter '%s' isn't a valid integer.", value, field))
+			}
+		}
+	}
+
+	query, queryParams := auditSearchMapping.CreateQuery(`SELECT ID, ActorType, ActorID, ActorName, Action, EntityType, EntityID,
+		COALESCE(PatientID, 0), Before, After, RequestID, CreatedOn
+	FROM AuditLog
+	WHERE %MAPPING_CONDITIONS%`, search)
+
+	query = fmt.Sprintf("%s ORDER BY ID DESC LIMIT %d OFFSET %d", query, limit, offset)
+
+	rows, err := db.Query(query, queryParams...)
+	if err != nil {
+		return []AuditEntry{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over all rows and store in slice
+	entries := []AuditEntry{}
+	var entry AuditEntry
+	var before, after []byte
+	var createdOn time.Time
+
+	for rows.Next() {
+		err = rows.Scan(&entry.ID, &entry.ActorType, &entry.ActorID, &entry.ActorName, &entry.Action, &entry.EntityType, &entry.EntityID,
+			&entry.PatientID, &before, &after, &entry.RequestID, &createdOn)
+
+		if err != nil {
+			return []AuditEntry{}, utils.InternalServerError(err)
+		}
+
+		entry.Before = nil
+		if before != nil {
+			entry.Before = json.RawMessage(append([]byte{}, before...))
+		}
+
+		entry.After = nil
+		if after != nil {
+			entry.After = json.RawMessage(append([]byte{}, after...))
+		}
+
+		entry.CreatedOn = createdOn.Format(time.RFC3339)
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -18,7 +18,7 @@ func HandleAuthenticate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Try to authenticate the user
-	token, err := Authenticate(credentials)
+	token, err := Authenticate(credentials, utils.ClientIP(r, config.Host.TrustProxyHeaders), r.UserAgent())
 
 	if err != nil {
 		utils.WriteError(w, err)
@@ -28,3 +28,60 @@ func HandleAuthenticate(w http.ResponseWriter, r *http.Request) {
 	// Return session token to user
 	utils.WriteJSON(w, token)
 }
+
+// HandleRefreshSession handles the exchange of a refresh token for a new session token
+func HandleRefreshSession(w http.ResponseWriter, r *http.Request) {
+	// Read refresh token from request
+	var refreshRequest RefreshRequest
+
+	err := utils.ReadJSONFromRequest(r, &refreshRequest)
+
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Try to refresh the session
+	token, err := RefreshSession(refreshRequest.RefreshToken, utils.ClientIP(r, config.Host.TrustProxyHeaders))
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Return new session token to user
+	utils.WriteJSON(w, token)
+}
+
+// HandleLogout handles the revocation of the current session
+func HandleLogout(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := ReadJWTSession(r)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read the refresh token that should be revoked along with the session, if any
+	var refreshRequest RefreshRequest
+
+	if r.ContentLength > 0 {
+		err = utils.ReadJSONFromRequest(r, &refreshRequest)
+
+		if err != nil {
+			utils.WriteError(w, utils.BadRequestError(err))
+			return
+		}
+	}
+
+	// Revoke the session and respond
+	err = Logout(session, refreshRequest.RefreshToken)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
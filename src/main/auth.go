@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"github.com/dgrijalva/jwt-go"
 	"gopkg.in/hlandau/passlib.v1"
 	"net/http"
 	"main/utils"
+	"sync"
+	"time"
 )
 
 type (
@@ -18,19 +21,56 @@ type (
 
 	// SessionToken containins a JWT token in the response
 	SessionToken struct {
-		Token string `json:"token"`
+		Token        string `json:"token"`
+		RefreshToken string `json:"refreshToken"`
+		ExpiresAt    int64  `json:"expiresAt"`
+
+		// Set instead of the token when a second authentication step is required
+		TwoFactorRequired  bool     `json:"twoFactorRequired,omitempty"`
+		EnrollmentRequired bool     `json:"enrollmentRequired,omitempty"`
+		ChallengeToken     string   `json:"challengeToken,omitempty"`
+		RecoveryCodes      []string `json:"recoveryCodes,omitempty"`
+	}
+
+	// RefreshRequest contains the refresh token that is exchanged for a new session token
+	RefreshRequest struct {
+		RefreshToken string `json:"refreshToken"`
 	}
 
 	// Session contains all data the client needs on the current session
 	Session struct {
+		// Type of actor the session belongs to, the user ID is the ID of the dispenser or API key for other actors
+		ActorType string
+
 		UserID   int
 		Username string
 		FullName string
 		Role     string
 		Email    string
+
+		TokenID   string
+		ExpiresAt time.Time
+
+		// ID of the user session the token belongs to, 0 for dispensers and API keys
+		SessionID int
+
+		// Permissions granted to an API key, other actors are granted the permissions of their role
+		Scopes []string
 	}
 )
 
+const (
+	UserActor      = "user"
+	DispenserActor = "dispenser"
+	APIKeyActor    = "apikey"
+)
+
+var (
+	// Hash of a random password, which login attempts for unknown users are verified against
+	dummyPasswordHash     string
+	dummyPasswordHashOnce sync.Once
+)
+
 // UpdatePasswordHash updates the password hash if necessary
 func UpdatePasswordHash(username, newPasswordHash string) error {
 	_, err := db.Exec(`UPDATE Users
@@ -44,70 +84,327 @@ func UpdatePasswordHash(username, newPasswordHash string) error {
 	return err
 }
 
-// JWTKeyFunc handles the decoding of a clients JWT
+// JWTKeyFunc handles the decoding of a clients JWT, by looking up the verification key by its key ID
 func JWTKeyFunc(token *jwt.Token) (interface{}, error) {
-	return []byte(config.JWT.Secret), nil
+	keyID, _ := token.Header["kid"].(string)
+
+	key, ok := signingKeys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("Unknown signing key '%s'", keyID)
+	}
+
+	// Only accept the algorithm that belongs to the key, to prevent algorithm substitution
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("Unexpected signing algorithm '%s' for key '%s'", token.Method.Alg(), keyID)
+	}
+
+	return key.PublicKey, nil
 }
 
-// ReadJWT reads the JWT session claimsfrom the request headers
-func ReadJWTSession(r *http.Request) (Session, error) {
+// claimsContextKey is the key of the validated token claims in the context of a request
+type claimsContextKey struct{}
+
+// withClaims returns a copy of a request that carries the claims of its validated token
+func withClaims(r *http.Request, claims jwt.MapClaims) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), claimsContextKey{}, claims))
+}
+
+// parseJWT reads and validates the JWT from the request headers, and returns its claims
+func parseJWT(r *http.Request) (jwt.MapClaims, error) {
+	// The token was already validated if CheckJWT handled the request
+	if claims, ok := r.Context().Value(claimsContextKey{}).(jwt.MapClaims); ok {
+		return claims, nil
+	}
+
 	// Read token string from the header
 	tokStr := r.Header.Get("X-JWT")
 	if len(tokStr) == 0 {
-		return Session{}, utils.UnauthorizedErrorMessage("No X-JWT header was present")
+		return nil, utils.UnauthorizedErrorMessage("No X-JWT header was present")
 	}
 
-	// Parse token
+	return parseToken(tokStr)
+}
+
+// parseToken validates a JWT, and returns its claims
+func parseToken(tokStr string) (jwt.MapClaims, error) {
+	// Parse token, this also checks whether the token has expired
 	token, err := jwt.Parse(tokStr, JWTKeyFunc)
 	if err != nil {
-		if err == jwt.ErrSignatureInvalid {
-			return Session{}, utils.UnauthorizedError(err)
+		if _, ok := err.(*jwt.ValidationError); ok {
+			return nil, utils.UnauthorizedError(err)
 		}
-		return Session{}, utils.InternalServerError(err)
+		return nil, utils.InternalServerError(err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, utils.UnauthorizedErrorMessage("Token claims could not be read")
+	}
+
+	// Tokens issued for a specific purpose, such as two-factor challenges, can't be used to access the API
+	if _, ok := claims["scope"]; ok {
+		return nil, utils.UnauthorizedErrorMessage("Token can't be used to access the API")
+	}
+
+	// Check whether the token was revoked
+	tokenID, _ := claims["jti"].(string)
+	if len(tokenID) == 0 {
+		return nil, utils.UnauthorizedErrorMessage("Token has no ID")
+	}
+
+	revoked, err := IsTokenRevoked(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, utils.UnauthorizedErrorMessage("Token has been revoked")
 	}
 
-	claims := token.Claims.(jwt.MapClaims)
+	// Tokens of decommissioned dispensers are no longer accepted
+	if role, _ := claims["role"].(string); role == DispenserRole {
+		dispenserID, _ := claims["userId"].(float64)
+
+		err = touchDispenser(int(dispenserID))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Tokens of revoked user sessions are no longer accepted
+	if sessionID, ok := claims["sid"].(float64); ok {
+		err = touchUserSession(int(sessionID))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return claims, nil
+}
+
+// ReadJWT reads the JWT session claimsfrom the request headers, or the session of the API key if no JWT is present
+func ReadJWTSession(r *http.Request) (Session, error) {
+	if len(r.Header.Get("X-JWT")) == 0 && len(r.Header.Get(APIKeyHeader)) > 0 {
+		return readAPIKeySession(r)
+	}
+
+	claims, err := parseJWT(r)
+	if err != nil {
+		return Session{}, err
+	}
+
+	return sessionFromClaims(claims)
+}
+
+// ReadTokenSession validates a JWT that was not passed in the request headers, and returns its session
+func ReadTokenSession(tokStr string) (Session, error) {
+	claims, err := parseToken(tokStr)
+	if err != nil {
+		return Session{}, err
+	}
+
+	return sessionFromClaims(claims)
+}
+
+// sessionFromClaims fills a session record with the claims of a validated token
+func sessionFromClaims(claims jwt.MapClaims) (Session, error) {
+	// A validly signed token may still lack claims, e.g. when it was issued for another purpose
+	userID, ok1 := claims["userId"].(float64)
+	username, ok2 := claims["username"].(string)
+	fullName, ok3 := claims["fullName"].(string)
+	role, ok4 := claims["role"].(string)
+	email, ok5 := claims["email"].(string)
+	tokenID, ok6 := claims["jti"].(string)
+	expiresAt, ok7 := claims["exp"].(float64)
+
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 || !ok6 || !ok7 {
+		return Session{}, utils.UnauthorizedErrorMessage("Token is missing required claims")
+	}
+
+	actorType := UserActor
+	if role == DispenserRole {
+		actorType = DispenserActor
+	}
+
+	// Tokens issued before sessions were recorded don't have a session ID
+	sessionID, _ := claims["sid"].(float64)
 
 	// Fill session record with the token claims
 	return Session{
-		UserID:   int(claims["userId"].(float64)),
-		Username: claims["username"].(string),
-		FullName: claims["fullName"].(string),
-		Role:     claims["role"].(string),
-		Email:    claims["email"].(string),
+		ActorType: actorType,
+		UserID:    int(userID),
+		Username:  username,
+		FullName:  fullName,
+		Role:      role,
+		Email:     email,
+		TokenID:   tokenID,
+		ExpiresAt: time.Unix(int64(expiresAt), 0),
+		SessionID: int(sessionID),
 	}, nil
 }
 
-// CreateJWT creates a session token struct given a session struct
+// signAccessToken adds the expiry, issued-at and ID claims to a set of claims and signs it
+func signAccessToken(claims jwt.MapClaims, lifetime time.Duration) (string, time.Time, error) {
+	tokenID, err := utils.RandomToken(16)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(lifetime)
+
+	claims["jti"] = tokenID
+	claims["iat"] = now.Unix()
+	claims["exp"] = expiresAt.Unix()
+
+	// Sign with the active key, and include its ID so the token can be verified after the key is rotated
+	token := jwt.NewWithClaims(activeSigningKey.Method, claims)
+	token.Header["kid"] = activeSigningKey.ID
+	tokenString, err := token.SignedString(activeSigningKey.PrivateKey)
+
+	return tokenString, expiresAt, err
+}
+
+// CreateJWT creates a session token struct given a session struct, for the user session with the ID in the session struct
 func CreateJWT(session Session) (SessionToken, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	tokenString, expiresAt, err := signAccessToken(jwt.MapClaims{
 		"userId":   session.UserID,
 		"username": session.Username,
 		"fullName": session.FullName,
 		"role":     session.Role,
 		"email":    session.Email,
-	})
+		"sid":      session.SessionID,
+	}, time.Duration(config.JWT.AccessTokenLifetime)*time.Minute)
+
+	if err != nil {
+		return SessionToken{}, utils.InternalServerError(err)
+	}
+
+	// Create a refresh token to obtain a new access token once it expires
+	refreshToken, err := CreateRefreshToken(UserTokenSubject, session.UserID, session.SessionID, time.Duration(config.JWT.RefreshTokenLifetime)*time.Hour)
+	if err != nil {
+		return SessionToken{}, err
+	}
+
+	return SessionToken{
+		Token:        tokenString,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt.Unix(),
+	}, nil
+}
+
+// readSession reads the session data of a user from the database
+func readSession(userID int) (Session, error) {
+	var session Session
+
+	err := db.QueryRow(`SELECT ID, Username, FullName, Role, Email
+	FROM Users
+	WHERE ID = $1 AND ArchivedOn IS NULL`, userID).Scan(&session.UserID, &session.Username, &session.FullName, &session.Role, &session.Email)
 
-	tokenString, err := token.SignedString([]byte(config.JWT.Secret))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return session, utils.UnauthorizedErrorMessage("User no longer exists")
+		}
+		return session, utils.InternalServerError(err)
+	}
 
-	return SessionToken{tokenString}, err
+	return session, nil
 }
 
-// Authenticate authenticates a user and returns a session token
-func Authenticate(credentials Credentials) (SessionToken, error) {
+// RefreshSession exchanges a refresh token for a new session token
+func RefreshSession(refreshToken, ipAddress string) (SessionToken, error) {
+	// Consume the refresh token, so it can only be used once
+	userID, sessionID, err := ConsumeRefreshToken(UserTokenSubject, refreshToken)
+	if err != nil {
+		return SessionToken{}, err
+	}
+
+	// Re-read the session data, so changes to the user are reflected in the new token
+	session, err := readSession(userID)
+	if err != nil {
+		return SessionToken{}, err
+	}
+
+	// Refresh tokens issued before sessions were recorded continue in a new session
+	if sessionID == 0 {
+		return StartUserSession(session, ipAddress, "")
+	}
+
+	err = refreshUserSession(sessionID, ipAddress)
+	if err != nil {
+		return SessionToken{}, err
+	}
+
+	session.SessionID = sessionID
+	return CreateJWT(session)
+}
+
+// Logout revokes the access token of a session and, if given, its refresh token. The user session the token belongs to
+// is revoked as well
+func Logout(session Session, refreshToken string) error {
+	err := RevokeToken(session.TokenID, session.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	if session.ActorType == UserActor && session.SessionID > 0 {
+		return RevokeUserSession(session.UserID, session.SessionID)
+	}
+
+	if len(refreshToken) > 0 {
+		subjectType := UserTokenSubject
+		if session.ActorType == DispenserActor {
+			subjectType = DispenserTokenSubject
+		}
+
+		return RevokeRefreshToken(subjectType, session.UserID, refreshToken)
+	}
+
+	return nil
+}
+
+// verifyDummyPassword verifies a password against the hash of a random password, so rejecting an unknown user takes as long
+// as rejecting a wrong password
+func verifyDummyPassword(password string) {
+	dummyPasswordHashOnce.Do(func() {
+		dummyPassword, err := utils.RandomToken(16)
+		if err == nil {
+			dummyPasswordHash, err = passlib.Hash(dummyPassword)
+		}
+		if err != nil {
+			utils.LogError(err)
+		}
+	})
+
+	if len(dummyPasswordHash) > 0 {
+		passlib.Verify(password, dummyPasswordHash)
+	}
+}
+
+// Authenticate authenticates a user and returns a session token. A session is started for the device the user logs in on
+func Authenticate(credentials Credentials, ipAddress, device string) (SessionToken, error) {
 	var token SessionToken
 
+	// Check whether the account or IP address is locked out
+	accountKey := userThrottleKey(credentials.Username)
+
+	err := CheckLoginThrottle(accountKey, ipAddress)
+	if err != nil {
+		return token, err
+	}
+
 	// Select the user from the database
 	var passwordHash string
 	var session Session
 
-	err := db.QueryRow(`SELECT ID, Username, FullName, PasswordHash, Role, Email
+	err = db.QueryRow(`SELECT ID, Username, FullName, PasswordHash, Role, Email
     FROM Users
-    WHERE Username = $1`, credentials.Username).Scan(&session.UserID, &session.Username, &session.FullName, &passwordHash, &session.Role, &session.Email)
+    WHERE Username = $1 AND ArchivedOn IS NULL`, credentials.Username).Scan(&session.UserID, &session.Username, &session.FullName, &passwordHash, &session.Role, &session.Email)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return token, utils.NotFoundErrorMessage(fmt.Sprintf("Username %s not found", credentials.Username))
+			// Verify the password anyway, so unknown usernames can't be told apart by the response time
+			verifyDummyPassword(credentials.Password)
+			return token, rejectLogin(accountKey, ipAddress)
 		} else {
 			return token, utils.InternalServerError(err)
 		}
@@ -116,7 +413,12 @@ func Authenticate(credentials Credentials) (SessionToken, error) {
 	// Check whether the password hashes match
 	newPassHash, err := passlib.Verify(credentials.Password, passwordHash)
 	if err != nil {
-		return token, utils.InternalServerError(err)
+		return token, rejectLogin(accountKey, ipAddress)
+	}
+
+	err = ResetLoginThrottle(accountKey)
+	if err != nil {
+		return token, err
 	}
 
 	// Update the password hash if necessary
@@ -127,6 +429,17 @@ func Authenticate(credentials Credentials) (SessionToken, error) {
 		}
 	}
 
-	// Return a signed JSON web token
-	return CreateJWT(session)
+	// Require a second authentication step if two-factor authentication is enabled or required for the role
+	status, err := readTwoFactorStatus(session.UserID)
+	if err != nil {
+		return token, err
+	}
+
+	enabled := status != nil && status.Enabled
+	if enabled || IsTwoFactorRequired(session.Role) {
+		return createTwoFactorChallenge(session, !enabled)
+	}
+
+	// Start a session and return a signed JSON web token
+	return StartUserSession(session, ipAddress, device)
 }
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"main/utils"
+	"net/http"
+	"strconv"
+)
+
+// HandleListCareTeam returns the care team of a patient to the client
+func HandleListCareTeam(w http.ResponseWriter, r *http.Request) {
+	// Read patient ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	// Read the care team from the database and write to the client
+	members, err := ListCareTeam(userID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, members)
+}
+
+// HandleEndCareTeamMembership handles the removal of a member from the care team of a patient
+func HandleEndCareTeamMembership(w http.ResponseWriter, r *http.Request) {
+	// Read patient ID and member ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	memberID, err := strconv.Atoi(vars["memberId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'memberId' isn't a valid integer.", vars["memberId"])))
+		return
+	}
+
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// End the membership and respond
+	err = EndCareTeamMembership(userID, memberID, actor)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleListCareTeamInvitations returns all invitations to the care team of a patient to the client
+func HandleListCareTeamInvitations(w http.ResponseWriter, r *http.Request) {
+	// Read patient ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	// Read the invitations from the database and write to the client
+	invitations, err := ListPatientCareTeamInvitations(userID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, invitations)
+}
+
+// HandleCreateCareTeamInvitation handles the invitation of a user to the care team of a patient
+func HandleCreateCareTeamInvitation(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read patient ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	// Read the invitation from the request body
+	var newInvitation NewCareTeamInvitation
+
+	err = utils.ReadJSONFromRequest(r, &newInvitation)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Create the invitation and write to the client
+	invitation, err := InviteToCareTeam(userID, session.UserID, newInvitation)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, invitation)
+}
+
+// HandleCancelCareTeamInvitation handles the cancellation of a pending invitation to the care team of a patient
+func HandleCancelCareTeamInvitation(w http.ResponseWriter, r *http.Request) {
+	// Read patient ID and invitation ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	invitationID, err := strconv.Atoi(vars["invitationId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'invitationId' isn't a valid integer.", vars["invitationId"])))
+		return
+	}
+
+	// Cancel the invitation and respond
+	err = CancelCareTeamInvitation(userID, invitationID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleListOwnInvitations returns the pending care team invitations of the current user to the client
+func HandleListOwnInvitations(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := readCurrentUserSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read the invitations from the database and write to the client
+	invitations, err := ListPendingInvitations(session.UserID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, invitations)
+}
+
+// HandleAcceptInvitation handles the acceptance of a care team invitation by the current user
+func HandleAcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := readCurrentUserSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read invitation ID from URL
+	vars := mux.Vars(r)
+
+	invitationID, err := strconv.Atoi(vars["invitationId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'invitationId' isn't a valid integer.", vars["invitationId"])))
+		return
+	}
+
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Accept the invitation and write to the client
+	invitation, err := AcceptCareTeamInvitation(session.UserID, invitationID, actor)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, invitation)
+}
+
+// HandleDeclineInvitation handles the refusal of a care team invitation by the current user
+func HandleDeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := readCurrentUserSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read invitation ID from URL
+	vars := mux.Vars(r)
+
+	invitationID, err := strconv.Atoi(vars["invitationId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'invitationId' isn't a valid integer.", vars["invitationId"])))
+		return
+	}
+
+	// Decline the invitation and respond
+	err = DeclineCareTeamInvitation(session.UserID, invitationID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,563 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"main/utils"
+	"strings"
+	"time"
+)
+
+type (
+	// CareTeamMember contains a membership of the care team of a patient
+	CareTeamMember struct {
+		User         UserSummary `json:"user"`
+		RelationType string      `json:"relationType"`
+		StartsOn     string      `json:"startsOn"`
+		EndsOn       string      `json:"endsOn"`
+		Active       bool        `json:"active"`
+	}
+
+	// CareTeamInvitation contains an invitation of a user to join the care team of a patient
+	CareTeamInvitation struct {
+		ID           int         `json:"id"`
+		Patient      UserSummary `json:"patient"`
+		Invitee      UserSummary `json:"invitee"`
+		RelationType string      `json:"relationType"`
+		StartsOn     string      `json:"startsOn"`
+		EndsOn       string      `json:"endsOn"`
+		InvitedBy    int         `json:"invitedBy"`
+		Status       string      `json:"status"`
+		CreatedOn    string      `json:"createdOn"`
+		ExpiresOn    string      `json:"expiresOn"`
+	}
+
+	// NewCareTeamInvitation contains the user that is invited to a care team, and the membership offered to them
+	NewCareTeamInvitation struct {
+		InviteeID    int    `json:"inviteeId"`
+		RelationType string `json:"relationType"`
+		StartsOn     string `json:"startsOn"`
+		EndsOn       string `json:"endsOn"`
+	}
+)
+
+const (
+	RelationPrimaryDoctor    = "primarydoctor"
+	RelationConsultingDoctor = "consultingdoctor"
+	RelationPharmacist       = "pharmacist"
+	RelationCaregiver        = "caregiver"
+
+	InvitationPending   = "pending"
+	InvitationAccepted  = "accepted"
+	InvitationDeclined  = "declined"
+	InvitationCancelled = "cancelled"
+	InvitationExpired   = "expired"
+
+	CareTeamInvitationTTL   = 14 * 24 * time.Hour
+	careTeamInvitationTitle = "You have been invited to a care team"
+
+	// Condition on PatientRelations PR that selects the memberships that are active today
+	activeMembershipCondition = "PR.StartsOn <= CURRENT_DATE AND (PR.EndsOn IS NULL OR PR.EndsOn > CURRENT_DATE)"
+)
+
+var (
+	// Role a user must have for each relation type
+	relationTypeRoles = map[string]string{
+		RelationPrimaryDoctor:    DoctorRole,
+		RelationConsultingDoctor: DoctorRole,
+		RelationPharmacist:       PharmacistRole,
+		RelationCaregiver:        CaregiverRole,
+	}
+)
+
+// ListCareTeam returns all current, past and future members of the care team of a patient
+func ListCareTeam(patientID int) ([]CareTeamMember, error) {
+	err := checkIsPatient(db, patientID)
+	if err != nil {
+		return []CareTeamMember{}, err
+	}
+
+	rows, err := db.Query(fmt.Sprintf(`SELECT U.ID, U.Username, U.FullName, U.Role, U.Email, U.Phone, PR.RelationType, PR.StartsOn, PR.EndsOn,
+		%s AND U.ArchivedOn IS NULL
+	FROM PatientRelations PR
+	INNER JOIN Users U ON PR.RelationID = U.ID
+	WHERE PR.PatientID = $1
+	ORDER BY PR.StartsOn, U.FullName`, activeMembershipCondition), patientID)
+
+	if err != nil {
+		return []CareTeamMember{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over all rows and store in slice
+	members := []CareTeamMember{}
+	var member CareTeamMember
+	var startsOn time.Time
+	var endsOn *time.Time
+
+	for rows.Next() {
+		err = rows.Scan(&member.User.ID, &member.User.Username, &member.User.FullName, &member.User.Role, &member.User.Email,
+			&member.User.Phone, &member.RelationType, &startsOn, &endsOn, &member.Active)
+
+		if err != nil {
+			return []CareTeamMember{}, utils.InternalServerError(err)
+		}
+
+		member.User.EmailMD5 = utils.HashMD5(member.User.Email)
+		member.StartsOn = startsOn.Format(DateFormat)
+		member.EndsOn = ""
+		if endsOn != nil {
+			member.EndsOn = endsOn.Format(DateFormat)
+		}
+
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+// EndCareTeamMembership ends the membership of a user of the care team of a patient as of today
+func EndCareTeamMembership(patientID, memberID int, actor AuditActor) error {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	result, err := tx.Exec(`UPDATE PatientRelations
+	SET EndsOn = GREATEST(CURRENT_DATE, StartsOn)
+	WHERE PatientID = $1 AND RelationID = $2 AND (EndsOn IS NULL OR EndsOn > CURRENT_DATE)`, patientID, memberID)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	} else if n == 0 {
+		utils.RollbackOrLog(tx)
+		return utils.NotFoundErrorMessage(fmt.Sprintf("User %d isn't a current or future member of the care team of patient %d", memberID, patientID))
+	}
+
+	err = RecordAudit(tx, actor, AuditActionDelete, AuditEntityCareTeam, memberID, patientID, nil, nil)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// hasPrimaryDoctor returns whether a patient has a primary doctor other than the given user during a period, an empty
+// end date means the period doesn't end
+func hasPrimaryDoctor(q querier, patientID, exceptUserID int, startsOn, endsOn string) (bool, error) {
+	var exists bool
+
+	var end interface{}
+	if len(endsOn) > 0 {
+		end = endsOn
+	}
+
+	err := q.QueryRow(`SELECT EXISTS(SELECT 1 FROM PatientRelations
+	WHERE PatientID = $1 AND RelationID <> $2 AND RelationType = $3
+		AND (EndsOn IS NULL OR EndsOn > $4::DATE)
+		AND ($5::DATE IS NULL OR StartsOn < $5::DATE))`, patientID, exceptUserID, RelationPrimaryDoctor, startsOn, end).Scan(&exists)
+
+	if err != nil {
+		return false, utils.InternalServerError(err)
+	}
+
+	return exists, nil
+}
+
+// InviteToCareTeam invites a user to join the care team of a patient, and notifies them by e-mail
+func InviteToCareTeam(patientID, inviterID int, newInvitation NewCareTeamInvitation) (CareTeamInvitation, error) {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return CareTeamInvitation{}, utils.InternalServerError(err)
+	}
+
+	invitation, err := inviteToCareTeam(tx, patientID, inviterID, newInvitation)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return CareTeamInvitation{}, err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return CareTeamInvitation{}, utils.InternalServerError(err)
+	}
+
+	notifyCareTeamInvitee(invitation)
+
+	return invitation, nil
+}
+
+// inviteToCareTeam stores an invitation of a user to join the care team of a patient inside a transaction. The invitee
+// is notified after the transaction is committed, see notifyCareTeamInvitee
+func inviteToCareTeam(tx *sql.Tx, patientID, inviterID int, newInvitation NewCareTeamInvitation) (CareTeamInvitation, error) {
+	err := checkIsPatient(tx, patientID)
+	if err != nil {
+		return CareTeamInvitation{}, err
+	}
+
+	// Validate the offered membership
+	role, ok := relationTypeRoles[newInvitation.RelationType]
+	if !ok {
+		return CareTeamInvitation{}, utils.BadRequestErrorMessage(fmt.Sprintf("Relation type must be one of %s", relationTypeList()))
+	}
+
+	if len(newInvitation.StartsOn) == 0 {
+		newInvitation.StartsOn = time.Now().Format(DateFormat)
+	}
+
+	startsOn, err := time.Parse(DateFormat, newInvitation.StartsOn)
+	if err != nil {
+		return CareTeamInvitation{}, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of field 'startsOn' isn't a valid date.", newInvitation.StartsOn))
+	}
+
+	var endsOn interface{}
+	if len(newInvitation.EndsOn) > 0 {
+		end, err := time.Parse(DateFormat, newInvitation.EndsOn)
+		if err != nil {
+			return CareTeamInvitation{}, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of field 'endsOn' isn't a valid date.", newInvitation.EndsOn))
+		}
+		if !end.After(startsOn) {
+			return CareTeamInvitation{}, utils.BadRequestErrorMessage("Memberships must end after they start")
+		}
+
+		endsOn = newInvitation.EndsOn
+	}
+
+	// Check the invitee
+	var inviteeRole string
+	var archived bool
+
+	err = tx.QueryRow(`SELECT Role, ArchivedOn IS NOT NULL FROM Users WHERE ID = $1`, newInvitation.InviteeID).Scan(&inviteeRole, &archived)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return CareTeamInvitation{}, utils.NotFoundErrorMessage(fmt.Sprintf("No user with ID %d found", newInvitation.InviteeID))
+		}
+		return CareTeamInvitation{}, utils.InternalServerError(err)
+	}
+
+	if archived || inviteeRole != role {
+		return CareTeamInvitation{}, utils.BadRequestErrorMessage(fmt.Sprintf("Only active users with the %s role can be invited as %s", role, newInvitation.RelationType))
+	}
+
+	// Check whether the user is already a member or invited
+	var member, invited bool
+
+	err = tx.QueryRow(`SELECT
+		EXISTS(SELECT 1 FROM PatientRelations WHERE PatientID = $1 AND RelationID = $2 AND (EndsOn IS NULL OR EndsOn > CURRENT_DATE)),
+		EXISTS(SELECT 1 FROM CareTeamInvitations WHERE PatientID = $1 AND InviteeID = $2 AND Status = $3 AND ExpiresOn > NOW())`,
+		patientID, newInvitation.InviteeID, InvitationPending).Scan(&member, &invited)
+
+	if err != nil {
+		return CareTeamInvitation{}, utils.InternalServerError(err)
+	}
+
+	if member {
+		return CareTeamInvitation{}, utils.BadRequestErrorMessage(fmt.Sprintf("User %d already is a member of the care team", newInvitation.InviteeID))
+	}
+	if invited {
+		return CareTeamInvitation{}, utils.BadRequestErrorMessage(fmt.Sprintf("User %d already has a pending invitation to the care team", newInvitation.InviteeID))
+	}
+
+	// A patient has at most one primary doctor at a time
+	if newInvitation.RelationType == RelationPrimaryDoctor {
+		exists, err := hasPrimaryDoctor(tx, patientID, newInvitation.InviteeID, newInvitation.StartsOn, newInvitation.EndsOn)
+		if err != nil {
+			return CareTeamInvitation{}, err
+		}
+		if exists {
+			return CareTeamInvitation{}, utils.BadRequestErrorMessage("The patient already has a primary doctor during this period")
+		}
+	}
+
+	// Store the invitation, users created by API keys have no inviter
+	var invitedBy interface{}
+	if inviterID > 0 {
+		invitedBy = inviterID
+	}
+
+	var invitationID int
+
+	err = tx.QueryRow(`INSERT INTO CareTeamInvitations (PatientID, InviteeID, RelationType, StartsOn, EndsOn, InvitedBy, ExpiresOn)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING ID`, patientID, newInvitation.InviteeID, newInvitation.RelationType,
+		newInvitation.StartsOn, endsOn, invitedBy, time.Now().Add(CareTeamInvitationTTL)).Scan(&invitationID)
+
+	if err != nil {
+		return CareTeamInvitation{}, utils.InternalServerError(err)
+	}
+
+	return readCareTeamInvitation(tx, invitationID)
+}
+
+// notifyCareTeamInvitee e-mails the invitee of a care team invitation, the invitation is also listed when they log in
+func notifyCareTeamInvitee(invitation CareTeamInvitation) {
+	body := fmt.Sprintf("Hello %s,\r\n\r\nYou have been invited to join the care team of %s as %s. Log in to MySMDS to accept or decline the invitation.\r\n\r\nThis invitation expires on %s.\r\n",
+		invitation.Invitee.FullName, invitation.Patient.FullName, invitation.RelationType, invitation.ExpiresOn)
+
+	err := mailer.SendMail(invitation.Invitee.Email, careTeamInvitationTitle, body)
+	if err != nil {
+		utils.LogErrorMessage(fmt.Sprintf("Failed to notify user %d of care team invitation %d: %s", invitation.Invitee.ID, invitation.ID, err.Error()))
+	}
+}
+
+// readCareTeamInvitationFromRow reads a care team invitation from a row containing all invitation columns, errors are returned unwrapped
+func readCareTeamInvitationFromRow(row interface {
+	Scan(dest ...interface{}) error
+}) (CareTeamInvitation, error) {
+	var invitation CareTeamInvitation
+	var startsOn time.Time
+	var endsOn *time.Time
+	var createdOn, expiresOn time.Time
+
+	err := row.Scan(&invitation.ID, &invitation.RelationType, &startsOn, &endsOn, &invitation.InvitedBy, &invitation.Status, &createdOn, &expiresOn,
+		&invitation.Patient.ID, &invitation.Patient.Username, &invitation.Patient.FullName, &invitation.Patient.Role, &invitation.Patient.Email, &invitation.Patient.Phone,
+		&invitation.Invitee.ID, &invitation.Invitee.Username, &invitation.Invitee.FullName, &invitation.Invitee.Role, &invitation.Invitee.Email, &invitation.Invitee.Phone)
+
+	if err != nil {
+		return CareTeamInvitation{}, err
+	}
+
+	invitation.StartsOn = startsOn.Format(DateFormat)
+	if endsOn != nil {
+		invitation.EndsOn = endsOn.Format(DateFormat)
+	}
+
+	invitation.CreatedOn = createdOn.Format(time.RFC3339)
+	invitation.ExpiresOn = expiresOn.Format(time.RFC3339)
+	invitation.Patient.EmailMD5 = utils.HashMD5(invitation.Patient.Email)
+	invitation.Invitee.EmailMD5 = utils.HashMD5(invitation.Invitee.Email)
+
+	// Pending invitations that weren't answered in time have expired
+	if invitation.Status == InvitationPending && time.Now().After(expiresOn) {
+		invitation.Status = InvitationExpired
+	}
+
+	return invitation, nil
+}
+
+// careTeamInvitationQuery selects all invitation columns, followed by the conditions in the given format string
+const careTeamInvitationQuery = `SELECT I.ID, I.RelationType, I.StartsOn, I.EndsOn, COALESCE(I.InvitedBy, 0), I.Status, I.CreatedOn, I.ExpiresOn,
+	P.ID, P.Username, P.FullName, P.Role, P.Email, P.Phone,
+	U.ID, U.Username, U.FullName, U.Role, U.Email, U.Phone
+	FROM CareTeamInvitations I
+	INNER JOIN Users P ON I.PatientID = P.ID
+	INNER JOIN Users U ON I.InviteeID = U.ID
+	%s`
+
+// listCareTeamInvitations returns the invitations matching a condition, most recent first
+func listCareTeamInvitations(condition string, args ...interface{}) ([]CareTeamInvitation, error) {
+	rows, err := db.Query(fmt.Sprintf(careTeamInvitationQuery, condition+" ORDER BY I.CreatedOn DESC"), args...)
+	if err != nil {
+		return []CareTeamInvitation{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over all rows and store in slice
+	invitations := []CareTeamInvitation{}
+
+	for rows.Next() {
+		invitation, err := readCareTeamInvitationFromRow(rows)
+		if err != nil {
+			return []CareTeamInvitation{}, utils.InternalServerError(err)
+		}
+
+		invitations = append(invitations, invitation)
+	}
+
+	return invitations, nil
+}
+
+// ListPatientCareTeamInvitations returns all invitations to the care team of a patient
+func ListPatientCareTeamInvitations(patientID int) ([]CareTeamInvitation, error) {
+	return listCareTeamInvitations("WHERE I.PatientID = $1", patientID)
+}
+
+// ListPendingInvitations returns the pending invitations of a user to join care teams
+func ListPendingInvitations(userID int) ([]CareTeamInvitation, error) {
+	return listCareTeamInvitations("WHERE I.InviteeID = $1 AND I.Status = $2 AND I.ExpiresOn > NOW()", userID, InvitationPending)
+}
+
+// ReadCareTeamInvitation returns a single care team invitation
+func ReadCareTeamInvitation(invitationID int) (CareTeamInvitation, error) {
+	return readCareTeamInvitation(db, invitationID)
+}
+
+// readCareTeamInvitation reads a single care team invitation inside or outside a transaction
+func readCareTeamInvitation(q querier, invitationID int) (CareTeamInvitation, error) {
+	invitation, err := readCareTeamInvitationFromRow(q.QueryRow(fmt.Sprintf(careTeamInvitationQuery, "WHERE I.ID = $1"), invitationID))
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return CareTeamInvitation{}, utils.NotFoundErrorMessage(fmt.Sprintf("No care team invitation with ID %d found", invitationID))
+		}
+		return CareTeamInvitation{}, utils.InternalServerError(err)
+	}
+
+	return invitation, nil
+}
+
+// CancelCareTeamInvitation cancels a pending invitation to the care team of a patient
+func CancelCareTeamInvitation(patientID, invitationID int) error {
+	result, err := db.Exec(`UPDATE CareTeamInvitations
+	SET Status = $1, RespondedOn = NOW()
+	WHERE ID = $2 AND PatientID = $3 AND Status = $4`, InvitationCancelled, invitationID, patientID, InvitationPending)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return utils.InternalServerError(err)
+	} else if n == 0 {
+		return utils.NotFoundErrorMessage(fmt.Sprintf("No pending invitation with ID %d found for patient %d", invitationID, patientID))
+	}
+
+	return nil
+}
+
+// DeclineCareTeamInvitation declines a pending invitation of a user
+func DeclineCareTeamInvitation(userID, invitationID int) error {
+	result, err := db.Exec(`UPDATE CareTeamInvitations
+	SET Status = $1, RespondedOn = NOW()
+	WHERE ID = $2 AND InviteeID = $3 AND Status = $4 AND ExpiresOn > NOW()`, InvitationDeclined, invitationID, userID, InvitationPending)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return utils.InternalServerError(err)
+	} else if n == 0 {
+		return utils.NotFoundErrorMessage(fmt.Sprintf("No pending invitation with ID %d found", invitationID))
+	}
+
+	return nil
+}
+
+// AcceptCareTeamInvitation accepts a pending invitation of a user, which adds them to the care team of the patient
+func AcceptCareTeamInvitation(userID, invitationID int, actor AuditActor) (CareTeamInvitation, error) {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return CareTeamInvitation{}, utils.InternalServerError(err)
+	}
+
+	// Lock the invitation, so it can only be accepted once
+	var patientID int
+	var relationType string
+	var startsOn time.Time
+	var endsOn *time.Time
+
+	err = tx.QueryRow(`SELECT PatientID, RelationType, StartsOn, EndsOn FROM CareTeamInvitations
+	WHERE ID = $1 AND InviteeID = $2 AND Status = $3 AND ExpiresOn > NOW()
+	FOR UPDATE`, invitationID, userID, InvitationPending).Scan(&patientID, &relationType, &startsOn, &endsOn)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		if err == sql.ErrNoRows {
+			return CareTeamInvitation{}, utils.NotFoundErrorMessage(fmt.Sprintf("No pending invitation with ID %d found", invitationID))
+		}
+		return CareTeamInvitation{}, utils.InternalServerError(err)
+	}
+
+	var end interface{}
+	endStr := ""
+	if endsOn != nil {
+		endStr = endsOn.Format(DateFormat)
+		end = endStr
+	}
+
+	// Lock the patient, so invitations to their care team are accepted one at a time and the checks below hold until commit
+	_, err = tx.Exec(`SELECT ID FROM Users WHERE ID = $1 FOR UPDATE`, patientID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return CareTeamInvitation{}, utils.InternalServerError(err)
+	}
+
+	// The user may have joined the care team since the invitation was sent
+	var member bool
+
+	err = tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM PatientRelations
+	WHERE PatientID = $1 AND RelationID = $2 AND (EndsOn IS NULL OR EndsOn > CURRENT_DATE))`, patientID, userID).Scan(&member)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return CareTeamInvitation{}, utils.InternalServerError(err)
+	}
+	if member {
+		utils.RollbackOrLog(tx)
+		return CareTeamInvitation{}, utils.BadRequestErrorMessage(fmt.Sprintf("User %d already is a member of the care team", userID))
+	}
+
+	// The patient may have gotten a primary doctor since the invitation was sent
+	if relationType == RelationPrimaryDoctor {
+		exists, err := hasPrimaryDoctor(tx, patientID, userID, startsOn.Format(DateFormat), endStr)
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return CareTeamInvitation{}, err
+		}
+		if exists {
+			utils.RollbackOrLog(tx)
+			return CareTeamInvitation{}, utils.BadRequestErrorMessage("The patient already has a primary doctor during this period")
+		}
+	}
+
+	// Add the user to the care team, ended memberships are kept as history
+	_, err = tx.Exec(`INSERT INTO PatientRelations (PatientID, RelationID, RelationType, StartsOn, EndsOn)
+	VALUES ($1, $2, $3, $4, $5)`, patientID, userID, relationType, startsOn.Format(DateFormat), end)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return CareTeamInvitation{}, utils.InternalServerError(err)
+	}
+
+	_, err = tx.Exec(`UPDATE CareTeamInvitations SET Status = $1, RespondedOn = NOW() WHERE ID = $2`, InvitationAccepted, invitationID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return CareTeamInvitation{}, utils.InternalServerError(err)
+	}
+
+	// Audit the new membership
+	invitation, err := readCareTeamInvitation(tx, invitationID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return CareTeamInvitation{}, err
+	}
+
+	err = RecordAudit(tx, actor, AuditActionCreate, AuditEntityCareTeam, userID, patientID, nil, invitation)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return CareTeamInvitation{}, err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return CareTeamInvitation{}, utils.InternalServerError(err)
+	}
+
+	return invitation, nil
+}
+
+// relationTypeList returns a readable list of the relation types
+func relationTypeList() string {
+	return strings.Join([]string{RelationPrimaryDoctor, RelationConsultingDoctor, RelationPharmacist, RelationCaregiver}, ", ")
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"main/utils"
+	"net/http"
+	"strconv"
+)
+
+// HandleReadClinicalProfile returns the clinical profile of a patient to the client
+func HandleReadClinicalProfile(w http.ResponseWriter, r *http.Request) {
+	// Read patient ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	// Read the profile from the database and write to the client
+	profile, err := ReadClinicalProfile(userID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, profile)
+}
+
+// HandleUpdateClinicalProfile handles an update of the clinical profile of a patient
+func HandleUpdateClinicalProfile(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read patient ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	// Read the updated profile from the request body
+	var updatedProfile UpdatedClinicalProfile
+
+	err = utils.ReadJSONFromRequest(r, &updatedProfile)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Update the profile and write to the client
+	profile, err := UpdateClinicalProfile(userID, session.UserID, updatedProfile, actor)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, profile)
+}
+
+// HandleCreateMeasurement handles the recording of the weight and/or height of a patient
+func HandleCreateMeasurement(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read patient ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	// Read the measurement from the request body
+	var newMeasurement NewMeasurement
+
+	err = utils.ReadJSONFromRequest(r, &newMeasurement)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Record the measurement and write to the client
+	measurement, err := CreateMeasurement(userID, session.UserID, newMeasurement, actor)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, measurement)
+}
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"main/utils"
+	"strings"
+	"time"
+)
+
+type (
+	// ClinicalProfile contains the clinical context of a patient that is relevant when composing doses
+	ClinicalProfile struct {
+		Allergies       []Allergy     `json:"allergies"`
+		Conditions      []Condition   `json:"conditions"`
+		Measurements    []Measurement `json:"measurements"`
+		RenalImpairment string        `json:"renalImpairment"`
+		Dialysis        bool          `json:"dialysis"`
+		UpdatedOn       string        `json:"updatedOn,omitempty"`
+		UpdatedBy       int           `json:"updatedBy,omitempty"`
+	}
+
+	// UpdatedClinicalProfile contains the allergies, conditions and renal function of a patient, which replace the current ones
+	UpdatedClinicalProfile struct {
+		Allergies       []Allergy   `json:"allergies"`
+		Conditions      []Condition `json:"conditions"`
+		RenalImpairment string      `json:"renalImpairment"`
+		Dialysis        bool        `json:"dialysis"`
+	}
+
+	// Allergy contains an allergy or intolerance of a patient
+	Allergy struct {
+		ID        int    `json:"id"`
+		Substance string `json:"substance"`
+		Reaction  string `json:"reaction"`
+		Severity  string `json:"severity"`
+	}
+
+	// Condition contains a chronic condition of a patient
+	Condition struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		Since string `json:"since"`
+		Notes string `json:"notes"`
+	}
+
+	// Measurement contains the weight and/or height of a patient on a day, a value of 0 means it wasn't measured
+	Measurement struct {
+		ID         int     `json:"id"`
+		MeasuredOn string  `json:"measuredOn"`
+		WeightKg   float64 `json:"weightKg,omitempty"`
+		HeightCm   float64 `json:"heightCm,omitempty"`
+		RecordedBy int     `json:"recordedBy,omitempty"`
+	}
+
+	// NewMeasurement contains a to-be recorded measurement
+	NewMeasurement struct {
+		MeasuredOn string  `json:"measuredOn"`
+		WeightKg   float64 `json:"weightKg"`
+		HeightCm   float64 `json:"heightCm"`
+	}
+)
+
+const (
+	RenalImpairmentUnknown  = "unknown"
+	RenalImpairmentNone     = "none"
+	RenalImpairmentMild     = "mild"
+	RenalImpairmentModerate = "moderate"
+	RenalImpairmentSevere   = "severe"
+
+	AllergySeverityMild     = "mild"
+	AllergySeverityModerate = "moderate"
+	AllergySeveritySevere   = "severe"
+
+	// Bounds of plausible measurements, to catch values entered in the wrong unit
+	MaxWeightKg = 500
+	MaxHeightCm = 300
+)
+
+var (
+	renalImpairments  = []string{RenalImpairmentUnknown, RenalImpairmentNone, RenalImpairmentMild, RenalImpairmentModerate, RenalImpairmentSevere}
+	allergySeverities = []string{AllergySeverityMild, AllergySeverityModerate, AllergySeveritySevere}
+)
+
+// checkIsPatient returns an error if the user with the given ID isn't a patient
+func checkIsPatient(q querier, userID int) error {
+	var role string
+
+	err := q.QueryRow(`SELECT Role FROM Users WHERE ID = $1`, userID).Scan(&role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return utils.NotFoundErrorMessage(fmt.Sprintf("No user with ID %d found", userID))
+		}
+		return utils.InternalServerError(err)
+	}
+
+	if role != PatientRole {
+		return utils.BadRequestErrorMessage(fmt.Sprintf("User with ID %d isn't a patient", userID))
+	}
+
+	return nil
+}
+
+// ReadClinicalProfile returns the clinical profile of a patient, patients without a profile have an empty profile
+func ReadClinicalProfile(patientID int) (ClinicalProfile, error) {
+	err := checkIsPatient(db, patientID)
+	if err != nil {
+		return ClinicalProfile{}, err
+	}
+
+	return readClinicalProfile(db, patientID)
+}
+
+// readClinicalProfile reads the clinical profile of a patient inside or outside a transaction
+func readClinicalProfile(q querier, patientID int) (ClinicalProfile, error) {
+	profile := ClinicalProfile{
+		Allergies:       []Allergy{},
+		Conditions:      []Condition{},
+		Measurements:    []Measurement{},
+		RenalImpairment: RenalImpairmentUnknown,
+	}
+
+	// Read the renal function
+	var updatedOn time.Time
+	var updatedBy *int
+
+	err := q.QueryRow(`SELECT RenalImpairment, Dialysis, UpdatedOn, UpdatedBy FROM ClinicalProfiles
+	WHERE PatientID = $1`, patientID).Scan(&profile.RenalImpairment, &profile.Dialysis, &updatedOn, &updatedBy)
+
+	if err != nil && err != sql.ErrNoRows {
+		return ClinicalProfile{}, utils.InternalServerError(err)
+	}
+
+	if err == nil {
+		profile.UpdatedOn = updatedOn.Format(time.RFC3339)
+		if updatedBy != nil {
+			profile.UpdatedBy = *updatedBy
+		}
+	}
+
+	// Read the allergies
+	rows, err := q.Query(`SELECT ID, Substance, Reaction, Severity FROM PatientAllergies
+	WHERE PatientID = $1
+	ORDER BY Substance`, patientID)
+
+	if err != nil {
+		return ClinicalProfile{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	var allergy Allergy
+	for rows.Next() {
+		err = rows.Scan(&allergy.ID, &allergy.Substance, &allergy.Reaction, &allergy.Severity)
+		if err != nil {
+			return ClinicalProfile{}, utils.InternalServerError(err)
+		}
+
+		profile.Allergies = append(profile.Allergies, allergy)
+	}
+
+	// Read the conditions
+	conditionRows, err := q.Query(`SELECT ID, Name, Since, Notes FROM PatientConditions
+	WHERE PatientID = $1
+	ORDER BY Name`, patientID)
+
+	if err != nil {
+		return ClinicalProfile{}, utils.InternalServerError(err)
+	}
+	defer conditionRows.Close()
+
+	var condition Condition
+	var since *time.Time
+
+	for conditionRows.Next() {
+		err = conditionRows.Scan(&condition.ID, &condition.Name, &since, &condition.Notes)
+		if err != nil {
+			return ClinicalProfile{}, utils.InternalServerError(err)
+		}
+
+		condition.Since = ""
+		if since != nil {
+			condition.Since = since.Format(DateFormat)
+		}
+
+		profile.Conditions = append(profile.Conditions, condition)
+	}
+
+	// Read the measurements, most recent first
+	profile.Measurements, err = listMeasurements(q, patientID)
+	if err != nil {
+		return ClinicalProfile{}, err
+	}
+
+	return profile, nil
+}
+
+// ListMeasurements returns the weight and height history of a patient, most recent first
+func ListMeasurements(patientID int) ([]Measurement, error) {
+	return listMeasurements(db, patientID)
+}
+
+// listMeasurements reads the measurements of a patient inside or outside a transaction
+func listMeasurements(q querier, patientID int) ([]Measurement, error) {
+	rows, err := q.Query(`SELECT ID, MeasuredOn, COALESCE(WeightKg, 0), COALESCE(HeightCm, 0), COALESCE(RecordedBy, 0)
+	FROM PatientMeasurements
+	WHERE PatientID = $1
+	ORDER BY MeasuredOn DESC, ID DESC`, patientID)
+
+	if err != nil {
+		return []Measurement{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over all rows and store in slice
+	measurements := []Measurement{}
+	var measurement Measurement
+	var measuredOn time.Time
+
+	for rows.Next() {
+		err = rows.Scan(&measurement.ID, &measuredOn, &measurement.WeightKg, &measurement.HeightCm, &measurement.RecordedBy)
+		if err != nil {
+			return []Measurement{}, utils.InternalServerError(err)
+		}
+
+		measurement.MeasuredOn = measuredOn.Format(DateFormat)
+		measurements = append(measurements, measurement)
+	}
+
+	return measurements, nil
+}
+
+// validateClinicalProfile returns an error if an updated clinical profile is invalid
+func validateClinicalProfile(updatedProfile UpdatedClinicalProfile) error {
+	if !containsString(renalImpairments, updatedProfile.RenalImpairment) {
+		return utils.BadRequestErrorMessage(fmt.Sprintf("Renal impairment must be one of %s", strings.Join(renalImpairments, ", ")))
+	}
+
+	for _, allergy := range updatedProfile.Allergies {
+		if len(strings.TrimSpace(allergy.Substance)) == 0 {
+			return utils.BadRequestErrorMessage("Allergies must have a substance")
+		}
+		if !containsString(allergySeverities, allergy.Severity) {
+			return utils.BadRequestErrorMessage(fmt.Sprintf("Severity of the allergy to '%s' must be one of %s", allergy.Substance, strings.Join(allergySeverities, ", ")))
+		}
+	}
+
+	for _, condition := range updatedProfile.Conditions {
+		if len(strings.TrimSpace(condition.Name)) == 0 {
+			return utils.BadRequestErrorMessage("Conditions must have a name")
+		}
+		if len(condition.Since) > 0 {
+			if _, err := time.Parse(DateFormat, condition.Since); err != nil {
+				return utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of field 'since' isn't a valid date.", condition.Since))
+			}
+		}
+	}
+
+	return nil
+}
+
+// UpdateClinicalProfile replaces the allergies, conditions and renal function of a patient
+func UpdateClinicalProfile(patientID, doctorID int, updatedProfile UpdatedClinicalProfile, actor AuditActor) (ClinicalProfile, error) {
+	err := checkIsPatient(db, patientID)
+	if err != nil {
+		return ClinicalProfile{}, err
+	}
+
+	err = validateClinicalProfile(updatedProfile)
+	if err != nil {
+		return ClinicalProfile{}, err
+	}
+
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return ClinicalProfile{}, utils.InternalServerError(err)
+	}
+
+	// Lock the patient, so concurrent updates of the profile are audited in order, and get the current profile
+	_, err = tx.Exec(`SELECT ID FROM Users WHERE ID = $1 FOR UPDATE`, patientID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return ClinicalProfile{}, utils.InternalServerError(err)
+	}
+
+	oldProfile, err := readClinicalProfile(tx, patientID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return ClinicalProfile{}, err
+	}
+
+	_, err = tx.Exec(`INSERT INTO ClinicalProfiles (PatientID, RenalImpairment, Dialysis, UpdatedOn, UpdatedBy)
+	VALUES ($1, $2, $3, NOW(), $4)
+	ON CONFLICT (PatientID) DO UPDATE
+	SET RenalImpairment = EXCLUDED.RenalImpairment, Dialysis = EXCLUDED.Dialysis, UpdatedOn = EXCLUDED.UpdatedOn, UpdatedBy = EXCLUDED.UpdatedBy`,
+		patientID, updatedProfile.RenalImpairment, updatedProfile.Dialysis, doctorID)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return ClinicalProfile{}, utils.InternalServerError(err)
+	}
+
+	// Replace the allergies and conditions
+	_, err = tx.Exec(`DELETE FROM PatientAllergies WHERE PatientID = $1`, patientID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return ClinicalProfile{}, utils.InternalServerError(err)
+	}
+
+	for _, allergy := range updatedProfile.Allergies {
+		_, err = tx.Exec(`INSERT INTO PatientAllergies (PatientID, Substance, Reaction, Severity)
+		VALUES ($1, $2, $3, $4)`, patientID, strings.TrimSpace(allergy.Substance), allergy.Reaction, allergy.Severity)
+
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return ClinicalProfile{}, utils.InternalServerError(err)
+		}
+	}
+
+	_, err = tx.Exec(`DELETE FROM PatientConditions WHERE PatientID = $1`, patientID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return ClinicalProfile{}, utils.InternalServerError(err)
+	}
+
+	for _, condition := range updatedProfile.Conditions {
+		var since interface{}
+		if len(condition.Since) > 0 {
+			since = condition.Since
+		}
+
+		_, err = tx.Exec(`INSERT INTO PatientConditions (PatientID, Name, Since, Notes)
+		VALUES ($1, $2, $3, $4)`, patientID, strings.TrimSpace(condition.Name), since, condition.Notes)
+
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return ClinicalProfile{}, utils.InternalServerError(err)
+		}
+	}
+
+	// Audit the change
+	profile, err := readClinicalProfile(tx, patientID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return ClinicalProfile{}, err
+	}
+
+	err = RecordAudit(tx, actor, AuditActionUpdate, AuditEntityProfile, patientID, patientID, oldProfile, profile)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return ClinicalProfile{}, err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return ClinicalProfile{}, utils.InternalServerError(err)
+	}
+
+	return profile, nil
+}
+
+// CreateMeasurement records the weight and/or height of a patient
+func CreateMeasurement(patientID, recordedBy int, newMeasurement NewMeasurement, actor AuditActor) (Measurement, error) {
+	err := checkIsPatient(db, patientID)
+	if err != nil {
+		return Measurement{}, err
+	}
+
+	// Validate the measurement
+	measuredOn := time.Now().Format(DateFormat)
+	if len(newMeasurement.MeasuredOn) > 0 {
+		measuredOn = newMeasurement.MeasuredOn
+	}
+
+	if _, err := time.Parse(DateFormat, measuredOn); err != nil {
+		return Measurement{}, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of field 'measuredOn' isn't a valid date.", measuredOn))
+	}
+
+	if newMeasurement.WeightKg <= 0 && newMeasurement.HeightCm <= 0 {
+		return Measurement{}, utils.BadRequestErrorMessage("Measurements must contain a weight, a height or both")
+	}
+
+	if newMeasurement.WeightKg < 0 || newMeasurement.WeightKg > MaxWeightKg {
+		return Measurement{}, utils.BadRequestErrorMessage(fmt.Sprintf("Weight must be between 0 and %d kg", MaxWeightKg))
+	}
+
+	if newMeasurement.HeightCm < 0 || newMeasurement.HeightCm > MaxHeightCm {
+		return Measurement{}, utils.BadRequestErrorMessage(fmt.Sprintf("Height must be between 0 and %d cm", MaxHeightCm))
+	}
+
+	var weight, height interface{}
+	if newMeasurement.WeightKg > 0 {
+		weight = newMeasurement.WeightKg
+	}
+	if newMeasurement.HeightCm > 0 {
+		height = newMeasurement.HeightCm
+	}
+
+	// Store the measurement
+	measurement := Measurement{
+		MeasuredOn: measuredOn,
+		WeightKg:   newMeasurement.WeightKg,
+		HeightCm:   newMeasurement.HeightCm,
+		RecordedBy: recordedBy,
+	}
+
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return Measurement{}, utils.InternalServerError(err)
+	}
+
+	err = tx.QueryRow(`INSERT INTO PatientMeasurements (PatientID, MeasuredOn, WeightKg, HeightCm, RecordedBy)
+	VALUES ($1, $2, $3, $4, $5) RETURNING ID`, patientID, measuredOn, weight, height, recordedBy).Scan(&measurement.ID)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return Measurement{}, utils.InternalServerError(err)
+	}
+
+	err = RecordAudit(tx, actor, AuditActionCreate, AuditEntityMeasurement, measurement.ID, patientID, nil, measurement)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return Measurement{}, err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return Measurement{}, utils.InternalServerError(err)
+	}
+
+	return measurement, nil
+}
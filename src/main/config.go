@@ -7,6 +7,8 @@ import (
 	"log"
 
 	_ "github.com/lib/pq"
+	"main/dispatch"
+	"main/mail"
 	"main/utils"
 	"os"
 )
@@ -25,7 +27,47 @@ type (
 
 		// JSON Web Token settings
 		JWT struct {
-			Secret string
+			// Directory containing PEM encoded signing keys named <key ID>.pem, or <key ID>.pub.pem for verification-only keys
+			KeyDirectory string
+
+			// ID of the key used to sign new tokens, by default the last key in the directory
+			ActiveKeyID string
+
+			// Whether a key may be generated on startup if none are configured, only meant for development
+			AllowEphemeralKey bool
+
+			// Lifetime of access tokens in minutes and of refresh tokens in hours
+			AccessTokenLifetime  int
+			RefreshTokenLifetime int
+
+			// Lifetime of dispenser access tokens in minutes and of dispenser refresh tokens in hours
+			DispenserAccessTokenLifetime  int
+			DispenserRefreshTokenLifetime int
+		}
+
+		// Two-factor authentication settings
+		TwoFactor struct {
+			// Comma-separated list of roles that must use two-factor authentication
+			RequiredRoles string
+		}
+
+		// Mail settings, the driver is either "file" or "smtp"
+		Mail struct {
+			Driver    string
+			Directory string
+			Host      string
+			Port      string
+			Username  string
+			Password  string
+			From      string
+
+			// URL of the web application page on which a password can be reset
+			PasswordResetURL string
+		}
+
+		// Cross-origin settings, a comma-separated list of origins of web applications that may use the API and dispatcher
+		CORS struct {
+			AllowedOrigins string
 		}
 
 		// Web host settings
@@ -33,13 +75,26 @@ type (
 			Host       string
 			Port       string
 			UseEnvPort bool
+
+			// Whether the client IP may be read from the X-Forwarded-For header set by a reverse proxy
+			TrustProxyHeaders bool
 		}
 	}
+
+	// querier is implemented by both *sql.DB and *sql.Tx, so entities can be read inside or outside a transaction
+	querier interface {
+		Exec(query string, args ...interface{}) (sql.Result, error)
+		Query(query string, args ...interface{}) (*sql.Rows, error)
+		QueryRow(query string, args ...interface{}) *sql.Row
+	}
 )
 
 var (
 	db     *sql.DB
 	config AppConfig
+	mailer mail.Mailer
+
+	originPolicy *utils.OriginPolicy
 )
 
 func init() {
@@ -74,4 +129,35 @@ func init() {
 	if config.Host.UseEnvPort {
 		config.Host.Port = os.Getenv("PORT")
 	}
+
+	// Fall back to the default token lifetimes if none were configured
+	if config.JWT.AccessTokenLifetime <= 0 {
+		config.JWT.AccessTokenLifetime = 15
+	}
+	if config.JWT.RefreshTokenLifetime <= 0 {
+		config.JWT.RefreshTokenLifetime = 24
+	}
+	if config.JWT.DispenserAccessTokenLifetime <= 0 {
+		config.JWT.DispenserAccessTokenLifetime = 60
+	}
+	if config.JWT.DispenserRefreshTokenLifetime <= 0 {
+		config.JWT.DispenserRefreshTokenLifetime = 24 * 30
+	}
+
+	// Create the origin policy, which is shared by the REST API and the dispatcher
+	originPolicy = utils.NewOriginPolicy(config.CORS.AllowedOrigins)
+	dispatch.SetOriginPolicy(originPolicy)
+
+	// Load the JWT signing keys
+	loadSigningKeys()
+
+	// Create the mailer
+	switch config.Mail.Driver {
+	case "smtp":
+		mailer = mail.NewSMTPMailer(config.Mail.Host, config.Mail.Port, config.Mail.Username, config.Mail.Password, config.Mail.From)
+	case "file", "":
+		mailer = mail.NewFileMailer(config.Mail.Directory, config.Mail.From)
+	default:
+		utils.LogErrorMessageFatal(fmt.Sprintf("Unknown mail driver '%s'", config.Mail.Driver))
+	}
 }
@@ -1,17 +1,75 @@
 package main
 
 const (
-	Dispenser          = "dispenser"
-	Admin              = "admin"
-	Doctor             = "admin,doctor"
-	DoctorDispenser    = "admin,doctor,dispenser"
-	Pharmacist         = "admin,pharmacist"
-	DoctorOrPharmacist = "admin,doctor,pharmacist"
-	Patient            = "admin,patient"
-
 	DispenserRole  = "dispenser"
 	AdminRole      = "admin"
 	DoctorRole     = "doctor"
 	PatientRole    = "patient"
 	PharmacistRole = "pharmacist"
+	CaregiverRole  = "caregiver"
 )
+
+const (
+	UsersReadPermission           = "users:read"
+	UsersWritePermission          = "users:write"
+	UsersPurgePermission          = "users:purge"
+	UsersStaffPermission          = "users:staff"
+	PasswordsResetPermission      = "passwords:reset"
+	MedicationsReadPermission     = "medications:read"
+	MedicationsWritePermission    = "medications:write"
+	DosesReadPermission           = "doses:read"
+	DosesWritePermission          = "doses:write"
+	HistoryReadPermission         = "history:read"
+	HistoryWritePermission        = "history:write"
+	PRNMedicationsReadPermission  = "prnmedications:read"
+	PRNMedicationsWritePermission = "prnmedications:write"
+	SummariesReadPermission       = "summaries:read"
+	ProfilesReadPermission        = "profiles:read"
+	ProfilesWritePermission       = "profiles:write"
+	CareTeamsReadPermission       = "careteams:read"
+	CareTeamsManagePermission     = "careteams:manage"
+	AllPatientsPermission         = "patients:all"
+	DispensersManagePermission    = "dispensers:manage"
+	DispensersAssignPermission    = "dispensers:assign"
+	LockoutsManagePermission      = "lockouts:manage"
+	RolesManagePermission         = "roles:manage"
+	AuditReadPermission           = "audit:read"
+	APIKeysManagePermission       = "apikeys:manage"
+	SessionsManagePermission      = "sessions:manage"
+	InteractionsManagePermission  = "interactions:manage"
+	InventoryReadPermission       = "inventory:read"
+	InventoryWritePermission      = "inventory:write"
+)
+
+// Permissions contains all permissions that can be granted to a role
+var Permissions = []string{
+	UsersReadPermission,
+	UsersWritePermission,
+	UsersPurgePermission,
+	UsersStaffPermission,
+	PasswordsResetPermission,
+	MedicationsReadPermission,
+	MedicationsWritePermission,
+	DosesReadPermission,
+	DosesWritePermission,
+	HistoryReadPermission,
+	HistoryWritePermission,
+	PRNMedicationsReadPermission,
+	PRNMedicationsWritePermission,
+	SummariesReadPermission,
+	ProfilesReadPermission,
+	ProfilesWritePermission,
+	CareTeamsReadPermission,
+	CareTeamsManagePermission,
+	AllPatientsPermission,
+	DispensersManagePermission,
+	DispensersAssignPermission,
+	LockoutsManagePermission,
+	RolesManagePermission,
+	AuditReadPermission,
+	APIKeysManagePermission,
+	SessionsManagePermission,
+	InteractionsManagePermission,
+	InventoryReadPermission,
+	InventoryWritePermission,
+}
@@ -3,6 +3,7 @@ package dispatch
 import (
 	"fmt"
 	"reflect"
+	"sync"
 )
 
 type (
@@ -12,6 +13,14 @@ type (
 		Subscriptions         []subscription
 		OutgoingMessages      chan outgoingMessage
 		SubscriptionIDCounter int
+
+		// Principal is nil until the client has authenticated
+		Principal Principal
+
+		// Closed when the dispatcher disconnects the client, with the reason in closeReason
+		closing     chan bool
+		closeReason string
+		closeOnce   sync.Once
 	}
 
 	// Contains information on an incoming message
@@ -34,9 +43,18 @@ func newClient(dispatcher *Dispatcher) *client {
 		Subscriptions:         make([]subscription, 0),
 		OutgoingMessages:      make(chan outgoingMessage, 10),
 		SubscriptionIDCounter: 1,
+		closing:               make(chan bool),
 	}
 }
 
+// disconnect signals the connection handler of the client to close the connection
+func (c *client) disconnect(reason string) {
+	c.closeOnce.Do(func() {
+		c.closeReason = reason
+		close(c.closing)
+	})
+}
+
 // isSubscribedTo returns whether a client is subscribed to a subject with the given title
 func (c *client) isSubscribedTo(subjectTitle string) bool {
 	for _, subscription := range c.Subscriptions {
@@ -61,7 +79,16 @@ func (c *client) getSubscription(subjectTitle string) subscription {
 
 // Subscribe subscribes a client to a subscription
 func (c *client) Subscribe(subjectTitle string, subscriptionParams map[string]interface{}) (subscription, error) {
+	if c.Principal == nil {
+		return subscription{}, UnauthorizedErrorMessage("The client must authenticate before subscribing")
+	}
+
 	for _, subject := range c.Dispatcher.subjects {
+		title := subject.GetTitle()
+		if title != subjectTitle {
+			continue
+		}
+
 		params, err := subject.CreateSubscriptionParams(subscriptionParams)
 
 		if err != nil {
@@ -77,18 +104,25 @@ func (c *client) Subscribe(subjectTitle string, subscriptionParams map[string]in
 			}
 		}
 
-		// Subscribe
-		if title := subject.GetTitle(); title == subjectTitle {
-			c.Subscriptions = append(c.Subscriptions, subscription{
-				SubscriptionID:     c.SubscriptionIDCounter,
-				SubjectTitle:       title,
-				SubscriptionParams: params,
-			})
+		// Check whether the client may subscribe
+		err = subject.AuthorizeSubscription(c.Principal, params)
+		if err != nil {
+			return subscription{}, err
+		}
 
-			c.SubscriptionIDCounter++
+		// Subscribe, the dispatcher reads the subscriptions while sending messages
+		c.Dispatcher.clientsMutex.Lock()
+		defer c.Dispatcher.clientsMutex.Unlock()
 
-			return c.Subscriptions[len(c.Subscriptions)-1], nil
-		}
+		c.Subscriptions = append(c.Subscriptions, subscription{
+			SubscriptionID:     c.SubscriptionIDCounter,
+			SubjectTitle:       title,
+			SubscriptionParams: params,
+		})
+
+		c.SubscriptionIDCounter++
+
+		return c.Subscriptions[len(c.Subscriptions)-1], nil
 	}
 
 	return subscription{}, UndefinedSubjectError(subjectTitle)
@@ -96,6 +130,9 @@ func (c *client) Subscribe(subjectTitle string, subscriptionParams map[string]in
 
 // Unsubscribe unsubscribes a client from a subscription with the given subscription ID
 func (c *client) Unsubscribe(subscriptionID int) {
+	c.Dispatcher.clientsMutex.Lock()
+	defer c.Dispatcher.clientsMutex.Unlock()
+
 	index := -1
 
 	for i, sub := range c.Subscriptions {
@@ -110,9 +147,57 @@ func (c *client) Unsubscribe(subscriptionID int) {
 	}
 }
 
+// Authenticate validates the token of a client and stores its principal
+func (c *client) Authenticate(token string) error {
+	principal, err := c.Dispatcher.authenticate(token)
+	if err != nil {
+		return err
+	}
+
+	c.setPrincipal(principal)
+	return nil
+}
+
+// setPrincipal stores the principal of the client, the dispatcher reads it while closing clients
+func (c *client) setPrincipal(principal Principal) {
+	c.Dispatcher.clientsMutex.Lock()
+	defer c.Dispatcher.clientsMutex.Unlock()
+
+	c.Principal = principal
+}
+
 // handleIncomingMessage handles an incoming message from the client
 func (c *client) handleIncomingMessage(msg incomingMessage) {
 	switch msg.Action {
+	case "authenticate":
+		// Type checks
+		t, ok := msg.Payload["token"]
+		if !ok {
+			c.OutgoingMessages <- BadRequestErrorMessage("Missing field 'token' in payload of authenticate action").OutgoingMessage(msg.RequestID)
+			return
+		}
+
+		token, ok := t.(string)
+		if !ok {
+			c.OutgoingMessages <- BadRequestErrorMessage(fmt.Sprintf("Invalid type for field 'token' in payload of authenticate action: expected string, got %s", reflect.TypeOf(t).Name())).OutgoingMessage(msg.RequestID)
+			return
+		}
+
+		// Authenticate
+		err := c.Authenticate(token)
+
+		if err != nil {
+			c.OutgoingMessages <- ToDispatcherError(err).OutgoingMessage(msg.RequestID)
+			return
+		}
+
+		c.OutgoingMessages <- outgoingMessage{
+			SubscriptionID: 0,
+			RequestID:      msg.RequestID,
+			Action:         "authenticate",
+			Payload:        map[string]interface{}{},
+		}
+
 	case "subscribe":
 		// Type checks
 		s, ok := msg.Payload["subject"]
@@ -5,6 +5,9 @@ type (
 	CollectionSubject struct {
 		Title    string
 		messages chan SubjectMessage
+
+		// Authorize optionally checks whether a client may subscribe to the collection
+		Authorize func(principal Principal) error
 	}
 
 	// collectionSubjectSubscriptionParams contains the subscription parameters to a CollectionSubject
@@ -67,6 +70,14 @@ func (cs *CollectionSubject) GetMessageChan() <-chan SubjectMessage {
 	return cs.messages
 }
 
+func (cs *CollectionSubject) AuthorizeSubscription(principal Principal, params SubscriptionParams) error {
+	if cs.Authorize == nil {
+		return nil
+	}
+
+	return cs.Authorize(principal)
+}
+
 // EntityAdded notifies subscribers of the subject that a new entity has been added
 func (cs *CollectionSubject) EntityAdded(entityID int, addedEntity interface{}) {
 	cs.messages <- SubjectMessage{
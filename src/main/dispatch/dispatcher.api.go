@@ -5,6 +5,7 @@ import (
 	"main/utils"
 	"net/http"
 	"encoding/json"
+	"time"
 )
 
 type (
@@ -14,52 +15,94 @@ type (
 	}
 )
 
+const (
+	// AuthenticationTimeout is the time within which a client must authenticate after connecting
+	AuthenticationTimeout = 10 * time.Second
+)
+
 var (
-	upgrader websocket.Upgrader
+	upgrader     websocket.Upgrader
+	originPolicy = utils.NewOriginPolicy("")
 )
 
 func init() {
 	upgrader = websocket.Upgrader{
 		CheckOrigin: func(r *http.Request) bool {
-			return true
+			return originPolicy.IsAllowedRequest(r)
 		},
 	}
 }
 
+// SetOriginPolicy sets the policy that decides from which web applications clients may connect
+func SetOriginPolicy(policy *utils.OriginPolicy) {
+	originPolicy = policy
+}
+
 // CreateDispatchHandler returns a REST API handler for a given dispatcher
 func CreateDispatchHandler(dispatcher *Dispatcher) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// Authenticate using the token in the query string or headers, if any. Clients that can't set either
+		// authenticate using their first message instead
+		token := r.URL.Query().Get("token")
+		if len(token) == 0 {
+			token = r.Header.Get("X-JWT")
+		}
+
+		var principal Principal
+
+		if len(token) > 0 {
+			var err error
+			principal, err = dispatcher.authenticate(token)
+
+			if err != nil {
+				utils.WriteError(w, utils.UnauthorizedError(err))
+				return
+			}
+		}
+
 		// Upgrade the HTTP request to a WebSocket
 		c, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
-			utils.WriteError(w, utils.InternalServerError(err))
+			utils.LogError(err)
 			return
 		}
 		defer c.Close()
 
 		// Create a client
 		clnt := dispatcher.CreateClient()
+		clnt.setPrincipal(principal)
+		defer dispatcher.RemoveClient(clnt)
 
 		// Start a goroutine listening for incoming messages
 		incomingMessages := make(chan webSocketMessage, 10)
 		closed := make(chan bool)
+		done := make(chan bool)
+		defer close(done)
 
 		go func() {
+			defer close(closed)
+
 			for {
 				msgType, content, err := c.ReadMessage()
 
 				if err != nil {
-					if websocket.IsCloseError(err) || websocket.IsUnexpectedCloseError(err) {
-						closed <- true
-						return
+					if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+						utils.LogError(err)
 					}
-					utils.LogError(err)
+					return
 				}
 
-				incomingMessages <- webSocketMessage{Content: content, Type: msgType}
+				select {
+				case incomingMessages <- webSocketMessage{Content: content, Type: msgType}:
+				case <-done:
+					return
+				}
 			}
 		}()
 
+		// Clients that did not authenticate during the handshake must do so within a limited time
+		authenticationTimeout := time.After(AuthenticationTimeout)
+
 		// Listen for incoming and outgoing messages
 		for {
 			select {
@@ -82,14 +125,17 @@ func CreateDispatchHandler(dispatcher *Dispatcher) func(w http.ResponseWriter, r
 					utils.LogError(err)
 					continue
 				}
-			case _ = <-closed:
+			case <-authenticationTimeout:
+				if clnt.Principal == nil {
+					c.WriteJSON(UnauthorizedErrorMessage("The client did not authenticate in time").OutgoingMessage(-1))
+					return
+				}
+			case <-clnt.closing:
+				c.WriteJSON(UnauthorizedErrorMessage(clnt.closeReason).OutgoingMessage(-1))
+				return
+			case <-closed:
 				return
 			}
 		}
-
-		close(incomingMessages)
-		close(closed)
-
-		dispatcher.RemoveClient(clnt)
 	}
 }
@@ -1,7 +1,10 @@
 package dispatch
 
 import (
+	"fmt"
+	"main/utils"
 	"reflect"
+	"sync"
 )
 
 type (
@@ -18,8 +21,17 @@ type (
 
 		// GetMessageChan returns the outgoing message channel of the subject
 		GetMessageChan() <-chan SubjectMessage
+
+		// AuthorizeSubscription returns an error if a client with the given principal may not subscribe with the given subscription parameters
+		AuthorizeSubscription(principal Principal, subscriptionParams SubscriptionParams) error
 	}
 
+	// Principal contains the identity of an authenticated client, as returned by the Authenticator of the dispatcher
+	Principal interface{}
+
+	// Authenticator validates the token a client authenticates with, and returns the identity of the client
+	Authenticator func(token string) (Principal, error)
+
 	SubscriptionParams interface {
 		// IsEqualTo returns whether the subscription params are equal to another SubscriptionParams
 		IsEqualTo(subscriptionParams SubscriptionParams) bool
@@ -33,8 +45,10 @@ type (
 
 	// Dispatcher contains the information on a dispatcher
 	Dispatcher struct {
-		subjects []Subject
-		clients  []*client
+		subjects      []Subject
+		clients       []*client
+		clientsMutex  sync.Mutex
+		authenticator Authenticator
 	}
 
 	// Subscription contains information on a subscription to a subject of a client
@@ -66,17 +80,41 @@ func (d *Dispatcher) RegisterSubject(subject Subject) {
 	d.subjects = append(d.subjects, subject)
 }
 
+// SetAuthenticator sets the function with which the tokens of clients are validated
+func (d *Dispatcher) SetAuthenticator(authenticator Authenticator) {
+	d.authenticator = authenticator
+}
+
+// authenticate validates a client token using the authenticator of the dispatcher
+func (d *Dispatcher) authenticate(token string) (Principal, error) {
+	if d.authenticator == nil {
+		return nil, UnauthorizedErrorMessage("The dispatcher does not accept any tokens")
+	}
+
+	principal, err := d.authenticator(token)
+	if err != nil {
+		return nil, UnauthorizedErrorMessage(err.Error())
+	}
+
+	return principal, nil
+}
+
 // CreateClient creates a new client in the dispatcher
 func (d *Dispatcher) CreateClient() *client {
 	client := newClient(d)
 
+	d.clientsMutex.Lock()
 	d.clients = append(d.clients, client)
+	d.clientsMutex.Unlock()
 
 	return client
 }
 
 // RemoveClient unregisters a client with a dispatcher
 func (d *Dispatcher) RemoveClient(clnt *client) {
+	d.clientsMutex.Lock()
+	defer d.clientsMutex.Unlock()
+
 	index := -1
 
 	for i, c := range d.clients {
@@ -85,12 +123,33 @@ func (d *Dispatcher) RemoveClient(clnt *client) {
 		}
 	}
 
+	if index < 0 {
+		return
+	}
+
 	close(d.clients[index].OutgoingMessages)
 
 	d.clients[index] = d.clients[len(d.clients)-1]
 	d.clients = d.clients[:len(d.clients)-1]
 }
 
+// CloseClients disconnects all clients of which the principal matches, and returns the number of disconnected clients
+func (d *Dispatcher) CloseClients(match func(principal Principal) bool, reason string) int {
+	d.clientsMutex.Lock()
+	defer d.clientsMutex.Unlock()
+
+	n := 0
+
+	for _, c := range d.clients {
+		if c.Principal != nil && match(c.Principal) {
+			c.disconnect(reason)
+			n++
+		}
+	}
+
+	return n
+}
+
 // Start starts the dispatcher process
 func (d *Dispatcher) Start() {
 	// Create a list of channels to which can be subscribed
@@ -111,6 +170,8 @@ func (d *Dispatcher) Start() {
 		subject := d.subjects[idx]
 
 		// Iterate over all clients
+		d.clientsMutex.Lock()
+
 		for _, c := range d.clients {
 
 			// If the client is subscribed to the sending subject, send the message to the client
@@ -118,14 +179,21 @@ func (d *Dispatcher) Start() {
 				sub := c.getSubscription(subject.GetTitle())
 
 				if subject.MessageShouldBeSentToSubscription(message, sub.SubscriptionParams) {
-					c.OutgoingMessages <- outgoingMessage{
+					// Don't let a client that doesn't keep up block the dispatcher
+					select {
+					case c.OutgoingMessages <- outgoingMessage{
 						SubscriptionID: sub.SubscriptionID,
 						Action:         message.Action,
 						Payload:        message.Payload,
 						RequestID:      -1,
+					}:
+					default:
+						utils.LogErrorMessage(fmt.Sprintf("Dropped message for subscription %d to subject %s, client isn't keeping up", sub.SubscriptionID, subject.GetTitle()))
 					}
 				}
 			}
 		}
+
+		d.clientsMutex.Unlock()
 	}
 }
@@ -70,4 +70,20 @@ func AlreadySubscribedError(subject string) *DispatcherError {
 		Code:    "already_subscribed",
 		Message: fmt.Sprintf("This client is already subscribed to subject %s with the same subscription parameters", subject),
 	}
-}
\ No newline at end of file
+}
+
+// UnauthorizedErrorMessage creates a new unauthorized dispatcher error with the given message
+func UnauthorizedErrorMessage(message string) *DispatcherError {
+	return &DispatcherError{
+		Code:    "unauthorized",
+		Message: message,
+	}
+}
+
+// ForbiddenErrorMessage creates a new forbidden dispatcher error with the given message
+func ForbiddenErrorMessage(message string) *DispatcherError {
+	return &DispatcherError{
+		Code:    "forbidden",
+		Message: message,
+	}
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"fmt"
+	"github.com/gorilla/mux"
 	"net/http"
 	"main/utils"
+	"strconv"
 )
 
 //HandleAuthenticateDispenser handles an authentication request from a dispenser
@@ -18,7 +21,7 @@ func HandleAuthenticateDispenser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Try to authenticate the dispenser
-	token, err := AuthenticateDispenser(auth)
+	token, err := AuthenticateDispenser(auth, utils.ClientIP(r, config.Host.TrustProxyHeaders))
 
 	if err != nil {
 		utils.WriteError(w, err)
@@ -27,4 +30,203 @@ func HandleAuthenticateDispenser(w http.ResponseWriter, r *http.Request) {
 
 	// Return session token to user
 	utils.WriteJSON(w, token)
-}
\ No newline at end of file
+}
+
+// HandleRefreshDispenserSession handles the exchange of a dispenser refresh token for a new session token
+func HandleRefreshDispenserSession(w http.ResponseWriter, r *http.Request) {
+	// Read refresh token from request
+	var refreshRequest RefreshRequest
+
+	err := utils.ReadJSONFromRequest(r, &refreshRequest)
+
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Try to refresh the dispenser session
+	token, err := RefreshDispenserSession(refreshRequest.RefreshToken)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Return new session token to the dispenser
+	utils.WriteJSON(w, token)
+}
+
+// HandleAssignDispenser handles the binding of a dispenser to a patient
+func HandleAssignDispenser(w http.ResponseWriter, r *http.Request) {
+	// Read dispenser ID from URL
+	vars := mux.Vars(r)
+
+	dispenserID, err := strconv.Atoi(vars["dispenserId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'dispenserId' isn't a valid integer.", vars["dispenserId"])))
+		return
+	}
+
+	// Read the assignment from the request body
+	var newAssignment NewDispenserAssignment
+
+	err = utils.ReadJSONFromRequest(r, &newAssignment)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Assign the dispenser and respond
+	assignment, err := AssignDispenser(dispenserID, newAssignment)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, assignment)
+}
+
+// HandleReadDispenserAssignment returns the patient a dispenser is bound to
+func HandleReadDispenserAssignment(w http.ResponseWriter, r *http.Request) {
+	// Read dispenser ID from URL
+	vars := mux.Vars(r)
+
+	dispenserID, err := strconv.Atoi(vars["dispenserId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'dispenserId' isn't a valid integer.", vars["dispenserId"])))
+		return
+	}
+
+	// Read the assignment and respond
+	assignment, err := ReadDispenserAssignment(dispenserID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, assignment)
+}
+
+// HandleUnassignDispenser handles the removal of the binding between a dispenser and its patient
+func HandleUnassignDispenser(w http.ResponseWriter, r *http.Request) {
+	// Read dispenser ID from URL
+	vars := mux.Vars(r)
+
+	dispenserID, err := strconv.Atoi(vars["dispenserId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'dispenserId' isn't a valid integer.", vars["dispenserId"])))
+		return
+	}
+
+	// Remove the assignment and respond
+	err = UnassignDispenser(dispenserID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleCreateDispenser handles the registration of a new dispenser
+func HandleCreateDispenser(w http.ResponseWriter, r *http.Request) {
+	// Read the new dispenser from the request body
+	var newDispenser NewDispenser
+
+	err := utils.ReadJSONFromRequest(r, &newDispenser)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Register the dispenser and return its credentials
+	credentials, err := CreateDispenser(newDispenser)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, credentials)
+}
+
+// HandleListDispensers returns a list of all dispensers to the client
+func HandleListDispensers(w http.ResponseWriter, r *http.Request) {
+	dispensers, err := ListDispensers()
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, dispensers)
+}
+
+// HandleReadDispenser returns a single dispenser to the client
+func HandleReadDispenser(w http.ResponseWriter, r *http.Request) {
+	// Read dispenser ID from URL
+	vars := mux.Vars(r)
+
+	dispenserID, err := strconv.Atoi(vars["dispenserId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'dispenserId' isn't a valid integer.", vars["dispenserId"])))
+		return
+	}
+
+	// Read the dispenser and respond
+	dispenser, err := ReadDispenser(dispenserID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, dispenser)
+}
+
+// HandleRotateDispenserCredentials handles the replacement of the auth token of a dispenser
+func HandleRotateDispenserCredentials(w http.ResponseWriter, r *http.Request) {
+	// Read dispenser ID from URL
+	vars := mux.Vars(r)
+
+	dispenserID, err := strconv.Atoi(vars["dispenserId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'dispenserId' isn't a valid integer.", vars["dispenserId"])))
+		return
+	}
+
+	// Rotate the credentials and return the new ones
+	credentials, err := RotateDispenserCredentials(dispenserID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, credentials)
+}
+
+// HandleDecommissionDispenser handles the retirement of a dispenser
+func HandleDecommissionDispenser(w http.ResponseWriter, r *http.Request) {
+	// Read dispenser ID from URL
+	vars := mux.Vars(r)
+
+	dispenserID, err := strconv.Atoi(vars["dispenserId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'dispenserId' isn't a valid integer.", vars["dispenserId"])))
+		return
+	}
+
+	// Decommission the dispenser and respond
+	err = DecommissionDispenser(dispenserID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/dgrijalva/jwt-go"
 	"gopkg.in/hlandau/passlib.v1"
 	"main/utils"
+	"time"
 )
 
 type (
@@ -14,6 +15,39 @@ type (
 		ID        int    `json:"id"`
 		AuthToken string `json:"authToken"`
 	}
+
+	// DispenserAssignment contains information on the patient a dispenser is bound to
+	DispenserAssignment struct {
+		DispenserID int         `json:"dispenserId"`
+		Patient     UserSummary `json:"patient"`
+		AssignedOn  string      `json:"assignedOn"`
+	}
+
+	// NewDispenserAssignment contains the patient a dispenser should be bound to
+	NewDispenserAssignment struct {
+		PatientID int `json:"patientId"`
+	}
+
+	// DispenserSummary contains information on a registered dispenser
+	DispenserSummary struct {
+		ID               int          `json:"id"`
+		Label            string       `json:"label"`
+		RegisteredOn     string       `json:"registeredOn"`
+		LastSeenOn       string       `json:"lastSeenOn"`
+		Decommissioned   bool         `json:"decommissioned"`
+		DecommissionedOn string       `json:"decommissionedOn"`
+		Patient          *UserSummary `json:"patient,omitempty"`
+	}
+
+	// NewDispenser contains all information on a to-be registered dispenser
+	NewDispenser struct {
+		Label string `json:"label"`
+	}
+)
+
+const (
+	// LastSeenUpdateInterval is the minimum time between two updates of the last seen time of a dispenser
+	LastSeenUpdateInterval = time.Minute
 )
 
 // UpdateDispenserAuthToken updates the auth token of a dispenser
@@ -29,32 +63,391 @@ func UpdateDispenserAuthToken(id int, newToken string) error {
 	return err
 }
 
-// CreateDispenserJWT
+// IsDispenserAssignedTo returns whether a dispenser is bound to a patient
+func IsDispenserAssignedTo(dispenserID, patientID int) (bool, error) {
+	var assigned bool
+
+	err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM DispenserAssignments
+	WHERE DispenserID = $1 AND PatientID = $2)`, dispenserID, patientID).Scan(&assigned)
+
+	if err != nil {
+		return false, utils.InternalServerError(err)
+	}
+
+	return assigned, nil
+}
+
+// generateDispenserAuthToken generates a new auth token for a dispenser, and returns the token and its hash
+func generateDispenserAuthToken() (string, string, error) {
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		return "", "", utils.InternalServerError(err)
+	}
+
+	tokenHash, err := passlib.Hash(token)
+	if err != nil {
+		return "", "", utils.InternalServerError(err)
+	}
+
+	return token, tokenHash, nil
+}
+
+// CreateDispenser registers a new dispenser and returns its credentials, the auth token can't be retrieved afterwards
+func CreateDispenser(newDispenser NewDispenser) (DispenserAuth, error) {
+	token, tokenHash, err := generateDispenserAuthToken()
+	if err != nil {
+		return DispenserAuth{}, err
+	}
+
+	var dispenserID int
+	err = db.QueryRow(`INSERT INTO Dispensers (AuthToken, Label)
+	VALUES ($1, $2) RETURNING ID`, tokenHash, newDispenser.Label).Scan(&dispenserID)
+
+	if err != nil {
+		return DispenserAuth{}, utils.InternalServerError(err)
+	}
+
+	return DispenserAuth{ID: dispenserID, AuthToken: token}, nil
+}
+
+// ListDispensers returns a list of all registered dispensers
+func ListDispensers() ([]DispenserSummary, error) {
+	rows, err := db.Query(`SELECT D.ID, D.Label, D.RegisteredOn, D.LastSeenOn, D.DecommissionedOn,
+		U.ID, U.Username, U.FullName, U.Role, U.Email, U.Phone
+	FROM Dispensers D
+	LEFT JOIN DispenserAssignments DA ON DA.DispenserID = D.ID
+	LEFT JOIN Users U ON DA.PatientID = U.ID
+	ORDER BY D.ID`)
+
+	if err != nil {
+		return []DispenserSummary{}, utils.InternalServerError(err)
+	}
+
+	// Iterate over all rows and store in slice
+	dispensers := []DispenserSummary{}
+
+	for rows.Next() {
+		dispenser, err := readDispenserFromRow(rows)
+		if err != nil {
+			return []DispenserSummary{}, utils.InternalServerError(err)
+		}
+
+		dispensers = append(dispensers, dispenser)
+	}
+
+	return dispensers, nil
+}
+
+// ReadDispenser returns a single registered dispenser
+func ReadDispenser(dispenserID int) (DispenserSummary, error) {
+	row := db.QueryRow(`SELECT D.ID, D.Label, D.RegisteredOn, D.LastSeenOn, D.DecommissionedOn,
+		U.ID, U.Username, U.FullName, U.Role, U.Email, U.Phone
+	FROM Dispensers D
+	LEFT JOIN DispenserAssignments DA ON DA.DispenserID = D.ID
+	LEFT JOIN Users U ON DA.PatientID = U.ID
+	WHERE D.ID = $1`, dispenserID)
+
+	dispenser, err := readDispenserFromRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return DispenserSummary{}, utils.NotFoundErrorMessage(fmt.Sprintf("No dispenser with ID %d", dispenserID))
+		}
+		return DispenserSummary{}, utils.InternalServerError(err)
+	}
+
+	return dispenser, nil
+}
+
+// RotateDispenserCredentials replaces the auth token of a dispenser and revokes its refresh tokens
+func RotateDispenserCredentials(dispenserID int) (DispenserAuth, error) {
+	token, tokenHash, err := generateDispenserAuthToken()
+	if err != nil {
+		return DispenserAuth{}, err
+	}
+
+	result, err := db.Exec(`UPDATE Dispensers
+	SET AuthToken = $1
+	WHERE ID = $2 AND DecommissionedOn IS NULL`, tokenHash, dispenserID)
+
+	if err != nil {
+		return DispenserAuth{}, utils.InternalServerError(err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return DispenserAuth{}, utils.InternalServerError(err)
+	} else if n == 0 {
+		return DispenserAuth{}, utils.NotFoundErrorMessage(fmt.Sprintf("No active dispenser with ID %d", dispenserID))
+	}
+
+	// Sessions started with the old credentials may not be refreshed anymore
+	err = RevokeAllRefreshTokens(DispenserTokenSubject, dispenserID)
+	if err != nil {
+		return DispenserAuth{}, err
+	}
+
+	return DispenserAuth{ID: dispenserID, AuthToken: token}, nil
+}
+
+// DecommissionDispenser retires a dispenser, after which its tokens are no longer accepted
+func DecommissionDispenser(dispenserID int) error {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	result, err := tx.Exec(`UPDATE Dispensers
+	SET DecommissionedOn = NOW()
+	WHERE ID = $1 AND DecommissionedOn IS NULL`, dispenserID)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	} else if n == 0 {
+		utils.RollbackOrLog(tx)
+		return utils.NotFoundErrorMessage(fmt.Sprintf("No active dispenser with ID %d", dispenserID))
+	}
+
+	// A retired dispenser no longer dispenses for its patient
+	_, err = tx.Exec(`DELETE FROM DispenserAssignments WHERE DispenserID = $1`, dispenserID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	_, err = tx.Exec(`UPDATE RefreshTokens
+	SET Revoked = TRUE
+	WHERE SubjectType = $1 AND SubjectID = $2`, DispenserTokenSubject, dispenserID)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// touchDispenser updates the last seen time of an active dispenser, and returns an error if it has been decommissioned.
+// Tokens are checked several times per request, so the last seen time is only written when it is out of date
+func touchDispenser(dispenserID int) error {
+	var active, outdated bool
+
+	err := db.QueryRow(`SELECT DecommissionedOn IS NULL, LastSeenOn IS NULL OR LastSeenOn < NOW() - $2 * INTERVAL '1 second'
+	FROM Dispensers
+	WHERE ID = $1`, dispenserID, LastSeenUpdateInterval.Seconds()).Scan(&active, &outdated)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return utils.UnauthorizedErrorMessage(fmt.Sprintf("No dispenser with ID %d", dispenserID))
+		}
+		return utils.InternalServerError(err)
+	}
+
+	if !active {
+		return utils.UnauthorizedErrorMessage(fmt.Sprintf("Dispenser with ID %d has been decommissioned", dispenserID))
+	}
+
+	if !outdated {
+		return nil
+	}
+
+	_, err = db.Exec(`UPDATE Dispensers SET LastSeenOn = NOW() WHERE ID = $1`, dispenserID)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// readDispenserFromRow is a helper function to read a dispenser and its assigned patient from a row, errors are returned unwrapped
+func readDispenserFromRow(row interface {
+	Scan(dest ...interface{}) error
+}) (DispenserSummary, error) {
+	var dispenser DispenserSummary
+	var registeredOn time.Time
+	var lastSeenOn, decommissionedOn *time.Time
+	var patientID *int
+	var username, fullName, role, email, phone *string
+
+	err := row.Scan(&dispenser.ID, &dispenser.Label, &registeredOn, &lastSeenOn, &decommissionedOn,
+		&patientID, &username, &fullName, &role, &email, &phone)
+
+	if err != nil {
+		return dispenser, err
+	}
+
+	dispenser.RegisteredOn = registeredOn.Format(time.RFC3339)
+
+	if lastSeenOn != nil {
+		dispenser.LastSeenOn = lastSeenOn.Format(time.RFC3339)
+	}
+
+	if decommissionedOn != nil {
+		dispenser.Decommissioned = true
+		dispenser.DecommissionedOn = decommissionedOn.Format(time.RFC3339)
+	}
+
+	if patientID != nil {
+		dispenser.Patient = &UserSummary{
+			ID:       *patientID,
+			Username: *username,
+			FullName: *fullName,
+			Role:     *role,
+			Email:    *email,
+			EmailMD5: utils.HashMD5(*email),
+			Phone:    *phone,
+		}
+	}
+
+	return dispenser, nil
+}
+
+// AssignDispenser binds a dispenser to a patient, replacing any previous binding
+func AssignDispenser(dispenserID int, newAssignment NewDispenserAssignment) (DispenserAssignment, error) {
+	// Check whether the dispenser exists and is still in service
+	var decommissioned bool
+	err := db.QueryRow(`SELECT DecommissionedOn IS NOT NULL FROM Dispensers WHERE ID = $1`, dispenserID).Scan(&decommissioned)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return DispenserAssignment{}, utils.NotFoundErrorMessage(fmt.Sprintf("No dispenser with ID %d", dispenserID))
+		}
+		return DispenserAssignment{}, utils.InternalServerError(err)
+	}
+	if decommissioned {
+		return DispenserAssignment{}, utils.BadRequestErrorMessage(fmt.Sprintf("Dispenser with ID %d has been decommissioned", dispenserID))
+	}
+
+	// Check whether the user a dispenser is bound to is a patient
+	patient, err := ReadUser(newAssignment.PatientID)
+	if err != nil {
+		return DispenserAssignment{}, err
+	}
+	if patient.Role != PatientRole {
+		return DispenserAssignment{}, utils.BadRequestErrorMessage(fmt.Sprintf("User with ID %d is not a patient", newAssignment.PatientID))
+	}
+
+	// Insert or replace the assignment
+	_, err = db.Exec(`INSERT INTO DispenserAssignments (DispenserID, PatientID)
+	VALUES ($1, $2)
+	ON CONFLICT (DispenserID) DO UPDATE SET PatientID = EXCLUDED.PatientID, AssignedOn = NOW()`, dispenserID, newAssignment.PatientID)
+
+	if err != nil {
+		return DispenserAssignment{}, utils.InternalServerError(err)
+	}
+
+	return ReadDispenserAssignment(dispenserID)
+}
+
+// ReadDispenserAssignment returns the patient a dispenser is bound to
+func ReadDispenserAssignment(dispenserID int) (DispenserAssignment, error) {
+	var assignment DispenserAssignment
+	var assignedOn time.Time
+
+	err := db.QueryRow(`SELECT DA.DispenserID, DA.AssignedOn, U.ID, U.Username, U.FullName, U.Role, U.Email, U.Phone
+	FROM DispenserAssignments DA
+	LEFT JOIN Users U ON DA.PatientID = U.ID
+	WHERE DA.DispenserID = $1`, dispenserID).Scan(&assignment.DispenserID, &assignedOn, &assignment.Patient.ID, &assignment.Patient.Username,
+		&assignment.Patient.FullName, &assignment.Patient.Role, &assignment.Patient.Email, &assignment.Patient.Phone)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return DispenserAssignment{}, utils.NotFoundErrorMessage(fmt.Sprintf("Dispenser with ID %d is not bound to a patient", dispenserID))
+		}
+		return DispenserAssignment{}, utils.InternalServerError(err)
+	}
+
+	assignment.AssignedOn = assignedOn.Format(time.RFC3339)
+	assignment.Patient.EmailMD5 = utils.HashMD5(assignment.Patient.Email)
+
+	return assignment, nil
+}
+
+// UnassignDispenser removes the binding between a dispenser and its patient
+func UnassignDispenser(dispenserID int) error {
+	_, err := db.Exec(`DELETE FROM DispenserAssignments WHERE DispenserID = $1`, dispenserID)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// CreateDispenserJWT creates a session token for the dispenser with the given ID
 func CreateDispenserJWT(id int) (SessionToken, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	tokenString, expiresAt, err := signAccessToken(jwt.MapClaims{
 		"userId":   id,
 		"username": "",
 		"fullName": "",
-		"role":     "dispenser",
+		"role":     DispenserRole,
 		"email":    "",
-	})
-	tokenString, err := token.SignedString([]byte(config.JWT.Secret))
+	}, time.Duration(config.JWT.DispenserAccessTokenLifetime)*time.Minute)
+
+	if err != nil {
+		return SessionToken{}, utils.InternalServerError(err)
+	}
+
+	// Dispensers run unattended, so they receive a longer-lived refresh token than users
+	refreshToken, err := CreateRefreshToken(DispenserTokenSubject, id, 0, time.Duration(config.JWT.DispenserRefreshTokenLifetime)*time.Hour)
+	if err != nil {
+		return SessionToken{}, err
+	}
+
+	return SessionToken{
+		Token:        tokenString,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt.Unix(),
+	}, nil
+}
+
+// RefreshDispenserSession exchanges a dispenser refresh token for a new session token
+func RefreshDispenserSession(refreshToken string) (SessionToken, error) {
+	dispenserID, _, err := ConsumeRefreshToken(DispenserTokenSubject, refreshToken)
+	if err != nil {
+		return SessionToken{}, err
+	}
+
+	err = touchDispenser(dispenserID)
+	if err != nil {
+		return SessionToken{}, err
+	}
 
-	return SessionToken{tokenString}, err
+	return CreateDispenserJWT(dispenserID)
 }
 
-// AuthenticateDispenser creates a JSON web token for a
-func AuthenticateDispenser(auth DispenserAuth) (SessionToken, error) {
+// AuthenticateDispenser creates a JSON web token for a dispenser
+func AuthenticateDispenser(auth DispenserAuth, ipAddress string) (SessionToken, error) {
 	var token SessionToken
 
+	// Check whether the dispenser or IP address is locked out
+	accountKey := dispenserThrottleKey(auth.ID)
+
+	err := CheckLoginThrottle(accountKey, ipAddress)
+	if err != nil {
+		return token, err
+	}
+
 	// Read the dispenser from the database
 	var tokenHash string
 
-	err := db.QueryRow(`SELECT AuthToken FROM Dispensers WHERE ID = $1`, auth.ID).Scan(&tokenHash)
+	err = db.QueryRow(`SELECT AuthToken FROM Dispensers WHERE ID = $1 AND DecommissionedOn IS NULL`, auth.ID).Scan(&tokenHash)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return token, utils.NotFoundErrorMessage(fmt.Sprintf("No dispenser with ID %d", auth.ID))
+			return token, rejectLogin(accountKey, ipAddress)
 		} else {
 			return token, utils.InternalServerError(err)
 		}
@@ -63,7 +456,12 @@ func AuthenticateDispenser(auth DispenserAuth) (SessionToken, error) {
 	// Check whether the password hashes match
 	newTokenHash, err := passlib.Verify(auth.AuthToken, tokenHash)
 	if err != nil {
-		return token, utils.InternalServerError(err)
+		return token, rejectLogin(accountKey, ipAddress)
+	}
+
+	err = ResetLoginThrottle(accountKey)
+	if err != nil {
+		return token, err
 	}
 
 	// Update the auth token if necessary
@@ -74,5 +472,10 @@ func AuthenticateDispenser(auth DispenserAuth) (SessionToken, error) {
 		}
 	}
 
+	err = touchDispenser(auth.ID)
+	if err != nil {
+		return token, err
+	}
+
 	return CreateDispenserJWT(auth.ID)
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"net/http"
+	"net/url"
 	"github.com/gorilla/mux"
 	"strconv"
 	"fmt"
@@ -10,6 +11,13 @@ import (
 
 // HandleCreateDoseHistoryEntry handles the creation of a new dose history entry
 func HandleCreateDoseHistoryEntry(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
 	// Read user ID from the URL parameters
 	vars := mux.Vars(r)
 
@@ -29,7 +37,7 @@ func HandleCreateDoseHistoryEntry(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create the new dose history entry and respond
-	doseHistoryEntry, err := CreateDoseHistoryEntry(userID, newDoseHistoryEntry)
+	doseHistoryEntry, err := CreateDoseHistoryEntry(userID, dispensingDispenserID(session), newDoseHistoryEntry)
 
 	if err != nil {
 		utils.WriteError(w, err)
@@ -51,9 +59,9 @@ func HandleListDoseHistoryEntries(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Read dose history entries from database
-	doses, err := ListDoseHistoryEntries(userID, map[string]string{
-		"dose": r.URL.Query().Get("dose"),
-		"dispensedday": r.URL.Query().Get("dispensedday"),
+	doses, err := ListDoseHistoryEntries(userID, url.Values{
+		"dose": r.URL.Query()["dose"],
+		"dispensedday": r.URL.Query()["dispensedday"],
 	})
 
 	if err != nil {
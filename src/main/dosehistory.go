@@ -1,7 +1,9 @@
 package main
 
 import (
+	"fmt"
 	"main/utils"
+	"net/url"
 	"time"
 )
 
@@ -53,16 +55,51 @@ func init() {
 	})
 }
 
-func CreateDoseHistoryEntry(userID int, newDoseHistoryEntry NewDoseHistoryEntry) (DoseHistoryEntryDetails, error) {
+// CreateDoseHistoryEntry creates a new dose history entry for a dose of the given user
+func CreateDoseHistoryEntry(userID, dispenserID int, newDoseHistoryEntry NewDoseHistoryEntry) (DoseHistoryEntryDetails, error) {
+	// Check whether the dose belongs to the user
+	belongs, err := DoseBelongsToUser(userID, newDoseHistoryEntry.DoseID)
+	if err != nil {
+		return DoseHistoryEntryDetails{}, err
+	}
+	if !belongs {
+		return DoseHistoryEntryDetails{}, utils.BadRequestErrorMessage(fmt.Sprintf("Dose with ID %d does not belong to the user with ID %d.", newDoseHistoryEntry.DoseID, userID))
+	}
+
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return DoseHistoryEntryDetails{}, utils.InternalServerError(err)
+	}
+
 	// Insert the new dose history in the database
 	var doseHistoryEntryID int
-	err := db.QueryRow(`INSERT INTO DoseHistory (DoseID, DispensedDay, DispensedTime)
+	err = tx.QueryRow(`INSERT INTO DoseHistory (DoseID, DispensedDay, DispensedTime)
 	VALUES ($1, $2, $3) RETURNING id`, newDoseHistoryEntry.DoseID, newDoseHistoryEntry.DispensedDay, newDoseHistoryEntry.DispensedTime).Scan(&doseHistoryEntryID)
 
 	if err != nil {
+		utils.RollbackOrLog(tx)
 		return DoseHistoryEntryDetails{}, utils.InternalServerError(err)
 	}
 
+	// Take the medications of the dose from the stock of the dispenser along with the history entry
+	inventoryDispenserID, err := DecrementDoseInventory(tx, dispenserID, userID, newDoseHistoryEntry.DoseID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return DoseHistoryEntryDetails{}, err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return DoseHistoryEntryDetails{}, utils.InternalServerError(err)
+	}
+
+	if inventoryDispenserID != 0 {
+		notifyInventoryUpdated(inventoryDispenserID)
+	}
+
 	// Notify the dispatcher that the dose summaries have been updated
 	summaries, err := ListDoseSummaries(userID)
 	if err != nil {
@@ -105,7 +142,7 @@ func CreateDoseHistoryEntry(userID int, newDoseHistoryEntry NewDoseHistoryEntry)
 }
 
 // ListDoseHistoryEntries returns a list of all dose history entries for a given user and search query
-func ListDoseHistoryEntries(userID int, search map[string]string) ([]DoseHistoryEntrySummary, error) {
+func ListDoseHistoryEntries(userID int, search url.Values) ([]DoseHistoryEntrySummary, error) {
 	// Create the query using the search mapping
 	query, queryParams := doseHistorySearchMapping.CreateQuery(`SELECT DH.ID, DH.DispensedDay, DH.DispensedTime, D.ID, D.Title FROM DoseHistory DH
 	LEFT JOIN Doses D ON DH.DoseID = D.ID
@@ -28,8 +28,15 @@ func HandleCreateDose(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
 	// Create the new dose and respond
-	dose, err := CreateDose(userID, newDose)
+	dose, err := CreateDose(userID, newDose, actor)
 
 	if err != nil {
 		utils.WriteError(w, err)
@@ -114,8 +121,15 @@ func HandleUpdateDose(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
 	// Update and return the updated dose
-	dose, err := UpdateDose(userID, doseID, updatedDose)
+	dose, err := UpdateDose(userID, doseID, updatedDose, actor)
 
 	if err != nil {
 		utils.WriteError(w, err)
@@ -142,8 +156,15 @@ func HandleDeleteDose(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
 	// Delete the dose and respond
-	err = DeleteDose(userID, doseID)
+	err = DeleteDose(userID, doseID, actor)
 
 	if err != nil {
 		utils.WriteError(w, err)
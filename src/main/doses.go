@@ -31,6 +31,12 @@ type (
 		DispenseBefore string           `json:"dispenseBefore"`
 		Description    string           `json:"description"`
 		Medications    []DoseMedication `json:"medications"`
+
+		// Reason the dose was saved despite a contraindication, if any
+		InteractionOverrideReason string `json:"interactionOverrideReason,omitempty"`
+
+		// Only set when the dose is saved, contains the interactions of its medications
+		Warnings []InteractionWarning `json:"warnings,omitempty"`
 	}
 
 	// NewDose contains all information on a to-be inserted dose
@@ -43,6 +49,9 @@ type (
 			MedicationID int `json:"medicationId"`
 			Amount       int `json:"amount"`
 		}
+
+		// Required to save a dose with contraindicated medications
+		OverrideReason string `json:"overrideReason"`
 	}
 
 	// UpdatedDose contains all information on a to-be updated dose
@@ -57,6 +66,9 @@ type (
 				ID int `json:"id"`
 			} `json:"medication"`
 		}
+
+		// Required to save a dose with contraindicated medications
+		OverrideReason string `json:"overrideReason"`
 	}
 )
 
@@ -72,34 +84,32 @@ func (dd DoseDetails) ToSummary() DoseSummary {
 }
 
 // CreateDose creates a new dose
-func CreateDose(userID int, newDose NewDose) (DoseDetails, error) {
+func CreateDose(userID int, newDose NewDose, actor AuditActor) (DoseDetails, error) {
+	// Collect the medications of the dose, which are checked for interactions once the transaction has begun
+	medicationIDs := []int{}
+	for _, medication := range newDose.Medications {
+		medicationIDs = append(medicationIDs, medication.MedicationID)
+	}
+
 	// Begin a SQL transaction
 	tx, err := db.Begin()
 	if err != nil {
 		return DoseDetails{}, utils.InternalServerError(err)
 	}
 
-	// Insert the dose into the Doses table
-	var doseID int
-
-	err = tx.QueryRow(`INSERT INTO Doses (Title, Description, UserID, DispenseAfter, DispenseBefore)
-  VALUES ($1, $2, $3, $4, $5) RETURNING id`, newDose.Title, newDose.Description, userID, newDose.DispenseAfter, newDose.DispenseBefore).Scan(&doseID)
-
+	// Check the medications for interactions with each other and the other medication of the patient
+	warnings, err := checkDoseInteractions(tx, userID, 0, medicationIDs, newDose.OverrideReason)
 	if err != nil {
 		utils.RollbackOrLog(tx)
-		return DoseDetails{}, utils.InternalServerError(err)
+		return DoseDetails{}, err
 	}
 
-	// Commit the created user as Postgres won't accept the foreign keys otherwise
-	err = tx.Commit()
-
-	if err != nil {
-		utils.RollbackOrLog(tx)
-		return DoseDetails{}, utils.InternalServerError(err)
-	}
+	// Insert the dose into the Doses table
+	var doseID int
 
-	// Begin new SQL transaction
-	tx, err = db.Begin()
+	err = tx.QueryRow(`INSERT INTO Doses (Title, Description, UserID, DispenseAfter, DispenseBefore, InteractionOverrideReason)
+  VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, newDose.Title, newDose.Description, userID, newDose.DispenseAfter, newDose.DispenseBefore,
+		overrideReasonFor(warnings, newDose.OverrideReason)).Scan(&doseID)
 
 	if err != nil {
 		utils.RollbackOrLog(tx)
@@ -117,23 +127,32 @@ func CreateDose(userID int, newDose NewDose) (DoseDetails, error) {
 		}
 	}
 
-	// Commit SQL transaction
-	err = tx.Commit()
+	// Audit the created dose
+	dose, err := readDose(tx, userID, doseID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return DoseDetails{}, err
+	}
 
+	err = RecordAudit(tx, actor, AuditActionCreate, AuditEntityDose, doseID, userID, nil, dose)
 	if err != nil {
 		utils.RollbackOrLog(tx)
-		return DoseDetails{}, utils.InternalServerError(err)
+		return DoseDetails{}, err
 	}
 
-	// Notify the dispatcher and return
-	dose, err := ReadDose(userID, int(doseID))
+	// Commit SQL transaction
+	err = tx.Commit()
 
 	if err != nil {
-		return dose, err
+		utils.RollbackOrLog(tx)
+		return DoseDetails{}, utils.InternalServerError(err)
 	}
 
+	// Notify the dispatcher and return
 	dosesSubject.DoseAdded(userID, dose.ToSummary())
 
+	dose.Warnings = warnings
+
 	return dose, err
 }
 
@@ -144,7 +163,7 @@ func ListDoses(userID int) ([]DoseSummary, error) {
 
 	rows, err := db.Query(`SELECT ID, Title, DispenseAfter, DispenseBefore, Description
   FROM Doses
-  WHERE UserID = $1
+  WHERE UserID = $1 AND EndedOn IS NULL
   ORDER BY DispenseAfter`, userID)
 
 	if err != nil {
@@ -172,14 +191,19 @@ func ListDoses(userID int) ([]DoseSummary, error) {
 
 // ReadDose returns a dose for a given user and dose ID
 func ReadDose(userID, doseID int) (DoseDetails, error) {
+	return readDose(db, userID, doseID)
+}
+
+// readDose reads a dose for a given user and dose ID inside or outside a transaction
+func readDose(q querier, userID, doseID int) (DoseDetails, error) {
 	// Read dose from the database
 	var dose DoseDetails
 
 	var dispenseAfter, dispenseBefore time.Time
 
-	err := db.QueryRow(`SELECT ID, Title, DispenseAfter, DispenseBefore, Description
+	err := q.QueryRow(`SELECT ID, Title, DispenseAfter, DispenseBefore, Description, InteractionOverrideReason
   FROM Doses
-  WHERE ID = $1 AND UserID = $2`, doseID, userID).Scan(&dose.ID, &dose.Title, &dispenseAfter, &dispenseBefore, &dose.Description)
+  WHERE ID = $1 AND UserID = $2 AND EndedOn IS NULL`, doseID, userID).Scan(&dose.ID, &dose.Title, &dispenseAfter, &dispenseBefore, &dose.Description, &dose.InteractionOverrideReason)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -192,13 +216,14 @@ func ReadDose(userID, doseID int) (DoseDetails, error) {
 	dose.DispenseBefore = dispenseBefore.Format(TimeFormat)
 
 	// Read the dose medications from the database
-	rows, err := db.Query(`SELECT DM.Amount, M.ID, M.Title, M.Description FROM DoseMedications DM
+	rows, err := q.Query(`SELECT DM.Amount, M.ID, M.Title, M.Description FROM DoseMedications DM
   LEFT JOIN Medications M ON DM.MedicationID = M.ID
   WHERE DoseID = $1`, doseID)
 
 	if err != nil {
 		return dose, utils.InternalServerError(err)
 	}
+	defer rows.Close()
 
 	// Read does medications into a slice
 	dose.Medications = make([]DoseMedication, 0)
@@ -216,29 +241,62 @@ func ReadDose(userID, doseID int) (DoseDetails, error) {
 	return dose, nil
 }
 
+// DoseBelongsToUser returns whether the dose with the given ID is a dose of the given user
+func DoseBelongsToUser(userID, doseID int) (bool, error) {
+	var belongs bool
+
+	err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM Doses WHERE ID = $1 AND UserID = $2 AND EndedOn IS NULL)`, doseID, userID).Scan(&belongs)
+	if err != nil {
+		return false, utils.InternalServerError(err)
+	}
+
+	return belongs, nil
+}
+
 // UpdateDose updates a dose for a given user and dose ID
-func UpdateDose(userID, doseID int, updatedDose UpdatedDose) (DoseDetails, error) {
+func UpdateDose(userID, doseID int, updatedDose UpdatedDose, actor AuditActor) (DoseDetails, error) {
+	// Collect the medications of the dose, which are checked for interactions once the transaction has begun
+	medicationIDs := []int{}
+	for _, medication := range updatedDose.Medications {
+		medicationIDs = append(medicationIDs, medication.Medication.ID)
+	}
+
 	// Begin a SQL transaction
 	tx, err := db.Begin()
 	if err != nil {
 		return DoseDetails{}, utils.InternalServerError(err)
 	}
 
-	// Get the current state of the dose
-	dose, err := ReadDose(userID, doseID)
+	// Check the medications for interactions with each other and the other medication of the patient
+	warnings, err := checkDoseInteractions(tx, userID, doseID, medicationIDs, updatedDose.OverrideReason)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return DoseDetails{}, err
+	}
+
+	// Lock the dose and get its current state
+	_, err = tx.Exec(`SELECT ID FROM Doses WHERE ID = $1 AND UserID = $2 FOR UPDATE`, doseID, userID)
 	if err != nil {
 		utils.RollbackOrLog(tx)
 		return DoseDetails{}, utils.InternalServerError(err)
 	}
 
+	dose, err := readDose(tx, userID, doseID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return DoseDetails{}, err
+	}
+
 	// Update the dose
 	_, err = tx.Exec(`UPDATE Doses
 	SET
 		Title = $1,
 		Description = $2,
 		DispenseAfter = $3,
-		DispenseBefore = $4
-	WHERE UserID = $5 AND ID = $6`, updatedDose.Title, updatedDose.Description, updatedDose.DispenseAfter, updatedDose.DispenseBefore, userID, doseID)
+		DispenseBefore = $4,
+		InteractionOverrideReason = $5
+	WHERE UserID = $6 AND ID = $7`, updatedDose.Title, updatedDose.Description, updatedDose.DispenseAfter, updatedDose.DispenseBefore,
+		overrideReasonFor(warnings, updatedDose.OverrideReason), userID, doseID)
 
 	if err != nil {
 		utils.RollbackOrLog(tx)
@@ -307,6 +365,19 @@ func UpdateDose(userID, doseID int, updatedDose UpdatedDose) (DoseDetails, error
 		}
 	}
 
+	// Audit the change
+	updated, err := readDose(tx, userID, doseID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return DoseDetails{}, err
+	}
+
+	err = RecordAudit(tx, actor, AuditActionUpdate, AuditEntityDose, doseID, userID, dose, updated)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return DoseDetails{}, err
+	}
+
 	// Commit the transaction
 	err = tx.Commit()
 
@@ -316,23 +387,54 @@ func UpdateDose(userID, doseID int, updatedDose UpdatedDose) (DoseDetails, error
 	}
 
 	// Notify the dispatcher and return
-	dose, err = ReadDose(userID, int(doseID))
-
-	if err != nil {
-		return dose, err
-	}
+	dose = updated
 
 	dosesSubject.DoseUpdated(userID, dose.ToSummary())
 
+	dose.Warnings = warnings
+
 	return dose, err
 }
 
 // DeleteDose deletes a dose for a given user and dose ID
-func DeleteDose(userID, doseID int) error {
+func DeleteDose(userID, doseID int, actor AuditActor) error {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	// Lock the dose and get its current state for the audit log
+	_, err = tx.Exec(`SELECT ID FROM Doses WHERE ID = $1 AND UserID = $2 FOR UPDATE`, doseID, userID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	dose, err := readDose(tx, userID, doseID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
 	// Delete the dose in the database
-	_, err := db.Exec(`DELETE FROM Doses WHERE UserID = $1 AND ID = $2`, userID, doseID)
+	_, err = tx.Exec(`DELETE FROM Doses WHERE UserID = $1 AND ID = $2`, userID, doseID)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
 
+	err = RecordAudit(tx, actor, AuditActionDelete, AuditEntityDose, doseID, userID, dose, nil)
 	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
 		return utils.InternalServerError(err)
 	}
 
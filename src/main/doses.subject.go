@@ -101,6 +101,15 @@ func (ds *DosesSubject) GetMessageChan() <-chan dispatch.SubjectMessage {
 	return ds.messages
 }
 
+func (ds *DosesSubject) AuthorizeSubscription(principal dispatch.Principal, sp dispatch.SubscriptionParams) error {
+	subscriptionParams, ok := sp.(*dosesSubjectSubscriptionParams)
+	if !ok {
+		return dispatch.BadRequestErrorMessage("Invalid subscription parameters")
+	}
+
+	return authorizeSubscription(principal, DosesReadPermission, subscriptionParams.UserID)
+}
+
 // DoseAdded notifies subscribers of the subject that a dose has been added
 func (ds *DosesSubject) DoseAdded(userID int, addedDose DoseSummary) {
 	ds.messages <- dispatch.SubjectMessage {
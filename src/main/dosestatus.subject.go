@@ -96,6 +96,15 @@ func (dss *DoseStatusesSubject) GetMessageChan() <-chan dispatch.SubjectMessage
 	return dss.messages
 }
 
+func (dss *DoseStatusesSubject) AuthorizeSubscription(principal dispatch.Principal, sp dispatch.SubscriptionParams) error {
+	subscriptionParams, ok := sp.(*doseStatusesSubscriptionParams)
+	if !ok {
+		return dispatch.BadRequestErrorMessage("Invalid subscription parameters")
+	}
+
+	return authorizeSubscription(principal, DosesReadPermission, subscriptionParams.UserID)
+}
+
 // DoseStatusesUpdated notifies subscribers of the subject that the dose statuses for a given user ID and date have been updated
 func (dss *DoseStatusesSubject) DoseStatusesUpdated(userID int, date string, updatedDoseStatuses []DoseStatus) {
 	dss.messages <- dispatch.SubjectMessage {
@@ -41,7 +41,7 @@ func ListDoseSummaries(userID int) ([]DoseSummarySummary, error) {
               ((D.DispenseAfter < D.DispenseBefore AND CURRENT_TIME < D.DispenseBefore) OR
                D.DispenseAfter > D.DispenseBefore)) AS PendingDayCount,
   (SELECT COUNT(*) FROM Doses
-    WHERE UserID = $1 AND DATE(CreatedOn) <= H.DispensedDay) AS TotalDayCount
+    WHERE UserID = $1 AND DATE(CreatedOn) <= H.DispensedDay AND (EndedOn IS NULL OR DATE(EndedOn) > H.DispensedDay)) AS TotalDayCount
   FROM (SELECT
     CASE
       WHEN (D.DispenseAfter > D.DispenseBefore AND DH.DispensedTime < D.DispenseAfter)
@@ -91,7 +91,7 @@ func ReadDoseSummary(userID int, date string) ([]DoseStatus, error) {
     ON DH.DoseID = D.ID AND DH.DispensedDay = $1 AND
       (D.DispenseAfter < D.DispenseBefore OR DH.DispensedTime >= D.DispenseAfter)
   WHERE
-    D.createdon::date <= $1 AND D.UserID = $2
+    D.createdon::date <= $1 AND D.UserID = $2 AND (D.EndedOn IS NULL OR D.EndedOn::date > $1)
   ORDER BY D.DispenseAfter`, date, userID)
 
 	if err != nil {
@@ -126,7 +126,7 @@ func ReadPRNStatuses(userID int, date string) ([]PRNStatus, error) {
 			GROUP BY prnmedicationid) ph ON ph.prnmedicationid = pm.id
 		LEFT JOIN users u ON pm.userid = u.id
 		LEFT JOIN medications m ON m.id = pm.medicationid
-	WHERE u.id = $2`, date, userID)
+	WHERE u.id = $2 AND (pm.endedon IS NULL OR pm.endedon::date > $1)`, date, userID)
 
 	if err != nil {
 		return []PRNStatus{}, utils.InternalServerError(err)
@@ -83,6 +83,15 @@ func (dss *DoseSummariesSubject) GetMessageChan() <-chan dispatch.SubjectMessage
 	return dss.messages
 }
 
+func (dss *DoseSummariesSubject) AuthorizeSubscription(principal dispatch.Principal, sp dispatch.SubscriptionParams) error {
+	subscriptionParams, ok := sp.(*doseSummariesSubscriptionParams)
+	if !ok {
+		return dispatch.BadRequestErrorMessage("Invalid subscription parameters")
+	}
+
+	return authorizeSubscription(principal, SummariesReadPermission, subscriptionParams.UserID)
+}
+
 // DoseSummariesUpdated notifies subscribers of the subject that the dose summaries list has been updated
 func (dss *DoseSummariesSubject) DoseSummariesUpdated(userID int, updatedDoseSummaries []DoseSummarySummary) {
 	dss.messages <- dispatch.SubjectMessage{
@@ -0,0 +1,75 @@
+package interaction
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Types of interaction subjects: ingredients ("ingredient:paracetamol"), ATC codes or groups ("atc:N02A") and
+	// medications ("medication:12")
+	SubjectIngredient = "ingredient"
+	SubjectATC        = "atc"
+	SubjectMedication = "medication"
+)
+
+var (
+	// ATC codes of any level, from the anatomical group (N) to the chemical substance (N02BE01)
+	atcGroupPattern = regexp.MustCompile(`^[A-Z]([0-9]{2}([A-Z]([A-Z]([0-9]{2})?)?)?)?$`)
+)
+
+// NormalizeSubject validates an interaction subject, and returns it with ingredients in lower case and ATC codes in upper
+// case
+func NormalizeSubject(subject string) (string, error) {
+	parts := strings.SplitN(strings.TrimSpace(subject), ":", 2)
+	if len(parts) != 2 || len(strings.TrimSpace(parts[1])) == 0 {
+		return "", fmt.Errorf("subject '%s' must be of the form 'ingredient:<name>', 'atc:<code>' or 'medication:<id>'", subject)
+	}
+
+	kind := strings.ToLower(strings.TrimSpace(parts[0]))
+	value := strings.TrimSpace(parts[1])
+
+	switch kind {
+	case SubjectIngredient:
+		value = strings.ToLower(value)
+	case SubjectATC:
+		value = strings.ToUpper(value)
+		if !atcGroupPattern.MatchString(value) {
+			return "", fmt.Errorf("'%s' isn't an ATC code or group", value)
+		}
+	case SubjectMedication:
+		if _, err := strconv.Atoi(value); err != nil {
+			return "", fmt.Errorf("'%s' isn't a valid medication ID", value)
+		}
+	default:
+		return "", fmt.Errorf("unknown subject type '%s'", kind)
+	}
+
+	return kind + ":" + value, nil
+}
+
+// NormalizePair normalizes the subjects of an interaction and orders them, so each pair is stored once. Subjects are
+// ordered by their bytes, like the "C" collation of the columns they are stored in
+func NormalizePair(subjectA, subjectB string) (string, string, error) {
+	a, err := NormalizeSubject(subjectA)
+	if err != nil {
+		return "", "", err
+	}
+
+	b, err := NormalizeSubject(subjectB)
+	if err != nil {
+		return "", "", err
+	}
+
+	if a == b {
+		return "", "", errors.New("a subject can't interact with itself")
+	}
+	if a > b {
+		a, b = b, a
+	}
+
+	return a, b, nil
+}
@@ -0,0 +1,67 @@
+package interaction
+
+import "testing"
+
+func TestNormalizeSubject(t *testing.T) {
+	cases := []struct {
+		subject  string
+		expected string
+		valid    bool
+	}{
+		{"ingredient:Paracetamol", "ingredient:paracetamol", true},
+		{" Ingredient : Co-Trimoxazole ", "ingredient:co-trimoxazole", true},
+		{"atc:n02be01", "atc:N02BE01", true},
+		{"ATC:N", "atc:N", true},
+		{"atc:N02A", "atc:N02A", true},
+		{"medication:12", "medication:12", true},
+		{"atc:N2", "", false},
+		{"atc:N02BE01X", "", false},
+		{"medication:twelve", "", false},
+		{"ingredient:", "", false},
+		{"paracetamol", "", false},
+		{"substance:paracetamol", "", false},
+	}
+
+	for _, c := range cases {
+		subject, err := NormalizeSubject(c.subject)
+		if c.valid && err != nil {
+			t.Errorf("%q: expected %q, got error %v", c.subject, c.expected, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("%q: expected an error, got %q", c.subject, subject)
+		} else if subject != c.expected {
+			t.Errorf("%q: expected %q, got %q", c.subject, c.expected, subject)
+		}
+	}
+}
+
+func TestNormalizePair(t *testing.T) {
+	cases := []struct {
+		name      string
+		subjectA  string
+		subjectB  string
+		expectedA string
+		expectedB string
+		valid     bool
+	}{
+		{"ordered", "ingredient:codeine", "ingredient:paracetamol", "ingredient:codeine", "ingredient:paracetamol", true},
+		{"reversed", "ingredient:paracetamol", "ingredient:codeine", "ingredient:codeine", "ingredient:paracetamol", true},
+
+		// Byte order puts '-' before 'd', where linguistic collations ignore the hyphen and sort codeine first
+		{"hyphen", "ingredient:codeine", "ingredient:co-trimoxazole", "ingredient:co-trimoxazole", "ingredient:codeine", true},
+		{"normalized before ordering", "ingredient:Warfarin", "atc:b01aa03", "atc:B01AA03", "ingredient:warfarin", true},
+		{"mixed types", "medication:3", "atc:N02", "atc:N02", "medication:3", true},
+		{"same subject", "ingredient:Codeine", "ingredient:codeine", "", "", false},
+		{"invalid subject", "ingredient:codeine", "atc:12", "", "", false},
+	}
+
+	for _, c := range cases {
+		a, b, err := NormalizePair(c.subjectA, c.subjectB)
+		if c.valid && err != nil {
+			t.Errorf("%s: expected (%q, %q), got error %v", c.name, c.expectedA, c.expectedB, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("%s: expected an error, got (%q, %q)", c.name, a, b)
+		} else if a != c.expectedA || b != c.expectedB {
+			t.Errorf("%s: expected (%q, %q), got (%q, %q)", c.name, c.expectedA, c.expectedB, a, b)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"main/utils"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// HandleCheckInteractions returns the interactions of medications with each other and with the other medication of a
+// patient to the client
+func HandleCheckInteractions(w http.ResponseWriter, r *http.Request) {
+	// Read patient ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	// Read the medications to check from the request body
+	var check InteractionCheck
+
+	err = utils.ReadJSONFromRequest(r, &check)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Check the interactions and write to the client
+	warnings, err := CheckInteractions(userID, check.DoseID, check.MedicationIDs)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, warnings)
+}
+
+// HandleListDrugInteractions returns the interactions of the subject in the query parameters, or all interactions, to the client
+func HandleListDrugInteractions(w http.ResponseWriter, r *http.Request) {
+	// Read the interactions from the database and write to the client
+	interactions, err := ListDrugInteractions(r.URL.Query().Get("subject"))
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, interactions)
+}
+
+// HandleImportDrugInteractions handles the import of an interaction table from CSV or JSON
+func HandleImportDrugInteractions(w http.ResponseWriter, r *http.Request) {
+	// Read the rows from the request body, depending on its content type
+	var interactions []ImportedDrugInteraction
+	var err error
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+		interactions, err = ReadInteractionImportCSV(r.Body)
+	} else {
+		interactions, err = ReadInteractionImportJSON(r.Body)
+	}
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Import the interactions and respond
+	query := r.URL.Query()
+
+	imported, err := ImportDrugInteractions(interactions, query.Get("source"), query.Get("replace") == "true", actor)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, DrugInteractionImportResult{Imported: imported})
+}
+
+// HandleDeleteDrugInteraction handles the removal of an interaction from the interaction table
+func HandleDeleteDrugInteraction(w http.ResponseWriter, r *http.Request) {
+	// Read interaction ID from URL
+	vars := mux.Vars(r)
+
+	interactionID, err := strconv.Atoi(vars["interactionId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'interactionId' isn't a valid integer.", vars["interactionId"])))
+		return
+	}
+
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Delete the interaction and respond
+	err = DeleteDrugInteraction(interactionID, actor)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
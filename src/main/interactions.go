@@ -0,0 +1,532 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/lib/pq"
+	"io"
+	"main/interaction"
+	"main/utils"
+	"sort"
+	"strings"
+)
+
+type (
+	// DrugInteraction contains an interaction between two ingredients, ATC groups or medications
+	DrugInteraction struct {
+		ID          int    `json:"id"`
+		SubjectA    string `json:"subjectA"`
+		SubjectB    string `json:"subjectB"`
+		Severity    string `json:"severity"`
+		Description string `json:"description"`
+		Source      string `json:"source"`
+	}
+
+	// ImportedDrugInteraction contains a row of an interaction table import
+	ImportedDrugInteraction struct {
+		SubjectA    string `json:"subjectA"`
+		SubjectB    string `json:"subjectB"`
+		Severity    string `json:"severity"`
+		Description string `json:"description"`
+	}
+
+	// DrugInteractionImportResult contains the number of interactions stored by an import
+	DrugInteractionImportResult struct {
+		Imported int `json:"imported"`
+	}
+
+	// drugInteractionImport contains what an import changed in the interaction table, for the audit log
+	drugInteractionImport struct {
+		Source   string `json:"source"`
+		Replace  bool   `json:"replace"`
+		Imported int    `json:"imported"`
+		Removed  int    `json:"removed"`
+	}
+
+	// InteractionWarning contains an interaction between a medication that is checked and another medication of the patient
+	InteractionWarning struct {
+		Severity      string            `json:"severity"`
+		Description   string            `json:"description"`
+		Medication    MedicationSummary `json:"medication"`
+		InteractsWith MedicationSummary `json:"interactsWith"`
+
+		// Where the other medication is used, such as "dose 'Morning'", empty if it is one of the checked medications
+		UsedIn string `json:"usedIn"`
+	}
+
+	// InteractionCheck contains the medications that are checked for interactions, and the dose they will be part of
+	InteractionCheck struct {
+		MedicationIDs []int `json:"medicationIds"`
+		DoseID        int   `json:"doseId"`
+	}
+
+	// interactionMedication contains a medication that is checked for interactions, and the subjects it matches
+	interactionMedication struct {
+		Medication MedicationDetails
+		UsedIn     string
+		Subjects   []string
+	}
+)
+
+const (
+	InteractionSeverityMinor           = "minor"
+	InteractionSeverityModerate        = "moderate"
+	InteractionSeverityMajor           = "major"
+	InteractionSeverityContraindicated = "contraindicated"
+
+	MaxInteractionImportRows = 100000
+)
+
+var (
+	// Ranks of the severities, from least to most severe
+	interactionSeverityRanks = map[string]int{
+		InteractionSeverityMinor:           1,
+		InteractionSeverityModerate:        2,
+		InteractionSeverityMajor:           3,
+		InteractionSeverityContraindicated: 4,
+	}
+
+	interactionImportColumns = []string{"subjecta", "subjectb", "severity", "description"}
+)
+
+// interactionSubjects returns all subjects a medication matches: the medication itself, its ingredients and every level of
+// its ATC code
+func interactionSubjects(medication MedicationDetails) []string {
+	subjects := []string{fmt.Sprintf("%s:%d", interaction.SubjectMedication, medication.ID)}
+
+	for _, ingredient := range medication.Ingredients {
+		subjects = append(subjects, interaction.SubjectIngredient+":"+strings.ToLower(ingredient.Name))
+	}
+
+	for _, length := range []int{1, 3, 4, 5, 7} {
+		if len(medication.ATCCode) >= length {
+			subjects = append(subjects, interaction.SubjectATC+":"+medication.ATCCode[:length])
+		}
+	}
+
+	return subjects
+}
+
+// ReadInteractionImportCSV reads the rows of an interaction table from a CSV file with a header row
+func ReadInteractionImportCSV(reader io.Reader) ([]ImportedDrugInteraction, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, utils.BadRequestErrorMessage(fmt.Sprintf("Invalid CSV: %s", err.Error()))
+	}
+
+	if len(records) == 0 {
+		return nil, utils.BadRequestErrorMessage("The CSV file has no header row")
+	}
+
+	// Map the columns by their lowercase header
+	columns := make(map[string]int)
+
+	for i, header := range records[0] {
+		column := strings.ToLower(strings.TrimSpace(header))
+		if !containsString(interactionImportColumns, column) {
+			return nil, utils.BadRequestErrorMessage(fmt.Sprintf("Unknown column '%s'", header))
+		}
+
+		columns[column] = i
+	}
+
+	for _, column := range interactionImportColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, utils.BadRequestErrorMessage(fmt.Sprintf("Missing required column '%s'", column))
+		}
+	}
+
+	// Read the rows
+	interactions := []ImportedDrugInteraction{}
+
+	for _, record := range records[1:] {
+		field := func(column string) string {
+			if i := columns[column]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		interactions = append(interactions, ImportedDrugInteraction{
+			SubjectA:    field("subjecta"),
+			SubjectB:    field("subjectb"),
+			Severity:    field("severity"),
+			Description: field("description"),
+		})
+	}
+
+	return interactions, nil
+}
+
+// ReadInteractionImportJSON reads the rows of an interaction table from a JSON array
+func ReadInteractionImportJSON(reader io.Reader) ([]ImportedDrugInteraction, error) {
+	interactions := []ImportedDrugInteraction{}
+
+	err := json.NewDecoder(reader).Decode(&interactions)
+	if err != nil {
+		return nil, utils.BadRequestError(err)
+	}
+
+	return interactions, nil
+}
+
+// ImportDrugInteractions validates an interaction table and stores it in a single transaction. Existing interactions
+// between the same subjects are replaced, and when replace is set all other interactions from the same source are removed
+func ImportDrugInteractions(interactions []ImportedDrugInteraction, source string, replace bool, actor AuditActor) (int, error) {
+	if len(interactions) == 0 {
+		return 0, utils.BadRequestErrorMessage("The import contains no interactions")
+	}
+	if len(interactions) > MaxInteractionImportRows {
+		return 0, utils.BadRequestErrorMessage(fmt.Sprintf("An import can contain at most %d interactions", MaxInteractionImportRows))
+	}
+
+	// Validate and normalize all rows before changing anything
+	for i := range interactions {
+		row := &interactions[i]
+
+		subjectA, subjectB, err := interaction.NormalizePair(row.SubjectA, row.SubjectB)
+		if err != nil {
+			return 0, utils.BadRequestErrorMessage(fmt.Sprintf("Row %d: %s", i+1, err.Error()))
+		}
+
+		row.SubjectA = subjectA
+		row.SubjectB = subjectB
+		row.Severity = strings.ToLower(strings.TrimSpace(row.Severity))
+
+		if _, ok := interactionSeverityRanks[row.Severity]; !ok {
+			return 0, utils.BadRequestErrorMessage(fmt.Sprintf("Row %d: severity must be one of %s, %s, %s or %s", i+1,
+				InteractionSeverityMinor, InteractionSeverityModerate, InteractionSeverityMajor, InteractionSeverityContraindicated))
+		}
+		if len(strings.TrimSpace(row.Description)) == 0 {
+			return 0, utils.BadRequestErrorMessage(fmt.Sprintf("Row %d: description is required", i+1))
+		}
+	}
+
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, utils.InternalServerError(err)
+	}
+
+	summary := drugInteractionImport{Source: source, Replace: replace, Imported: len(interactions)}
+
+	if replace {
+		result, err := tx.Exec(`DELETE FROM DrugInteractions WHERE Source = $1`, source)
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return 0, utils.InternalServerError(err)
+		}
+
+		removed, err := result.RowsAffected()
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return 0, utils.InternalServerError(err)
+		}
+		summary.Removed = int(removed)
+	}
+
+	for _, row := range interactions {
+		_, err = tx.Exec(`INSERT INTO DrugInteractions (SubjectA, SubjectB, Severity, Description, Source)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (SubjectA, SubjectB) DO UPDATE
+		SET Severity = EXCLUDED.Severity, Description = EXCLUDED.Description, Source = EXCLUDED.Source, ImportedOn = NOW()`,
+			row.SubjectA, row.SubjectB, row.Severity, row.Description, source)
+
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return 0, utils.InternalServerError(err)
+		}
+	}
+
+	// The import is audited as a whole, as an interaction table can contain many thousands of rows
+	err = RecordAudit(tx, actor, AuditActionImport, AuditEntityInteractions, 0, 0, nil, summary)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return 0, err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return 0, utils.InternalServerError(err)
+	}
+
+	return len(interactions), nil
+}
+
+// ListDrugInteractions returns all interactions of a subject, or all interactions if the subject is empty
+func ListDrugInteractions(subject string) ([]DrugInteraction, error) {
+	if len(subject) > 0 {
+		var err error
+		subject, err = interaction.NormalizeSubject(subject)
+		if err != nil {
+			return []DrugInteraction{}, utils.BadRequestError(err)
+		}
+	}
+
+	rows, err := db.Query(`SELECT ID, SubjectA, SubjectB, Severity, Description, Source FROM DrugInteractions
+	WHERE $1 = '' OR SubjectA = $1 OR SubjectB = $1
+	ORDER BY SubjectA, SubjectB`, subject)
+
+	if err != nil {
+		return []DrugInteraction{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over all rows and store in slice
+	interactions := []DrugInteraction{}
+	var interaction DrugInteraction
+
+	for rows.Next() {
+		err = rows.Scan(&interaction.ID, &interaction.SubjectA, &interaction.SubjectB, &interaction.Severity, &interaction.Description, &interaction.Source)
+		if err != nil {
+			return []DrugInteraction{}, utils.InternalServerError(err)
+		}
+
+		interactions = append(interactions, interaction)
+	}
+
+	return interactions, nil
+}
+
+// DeleteDrugInteraction deletes an interaction from the interaction table
+func DeleteDrugInteraction(interactionID int, actor AuditActor) error {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	// Lock the interaction and get its current state for the audit log
+	var deleted DrugInteraction
+
+	err = tx.QueryRow(`SELECT ID, SubjectA, SubjectB, Severity, Description, Source FROM DrugInteractions
+	WHERE ID = $1 FOR UPDATE`, interactionID).Scan(&deleted.ID, &deleted.SubjectA, &deleted.SubjectB, &deleted.Severity,
+		&deleted.Description, &deleted.Source)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		if err == sql.ErrNoRows {
+			return utils.NotFoundErrorMessage(fmt.Sprintf("No interaction with ID %d found", interactionID))
+		}
+		return utils.InternalServerError(err)
+	}
+
+	_, err = tx.Exec(`DELETE FROM DrugInteractions WHERE ID = $1`, interactionID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	err = RecordAudit(tx, actor, AuditActionDelete, AuditEntityInteractions, interactionID, 0, deleted, nil)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// listPatientMedications returns the medications of the doses and PRN medications of a patient, except those of the
+// excluded dose, inside or outside a transaction
+func listPatientMedications(q querier, patientID, excludedDoseID int) ([]interactionMedication, error) {
+	rows, err := q.Query(`SELECT DM.MedicationID, 'dose ''' || D.Title || '''' FROM DoseMedications DM
+		INNER JOIN Doses D ON DM.DoseID = D.ID
+		WHERE D.UserID = $1 AND D.ID <> $2 AND D.EndedOn IS NULL
+	UNION ALL
+	SELECT medicationid, 'PRN medication' FROM prnmedications
+		WHERE userid = $1 AND endedon IS NULL`, patientID, excludedDoseID)
+
+	if err != nil {
+		return nil, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	// Read the used medications before reading their details, as the rows must be closed first
+	type usedMedication struct {
+		MedicationID int
+		UsedIn       string
+	}
+
+	used := []usedMedication{}
+	var u usedMedication
+
+	for rows.Next() {
+		err = rows.Scan(&u.MedicationID, &u.UsedIn)
+		if err != nil {
+			return nil, utils.InternalServerError(err)
+		}
+
+		used = append(used, u)
+	}
+	rows.Close()
+
+	medications := []interactionMedication{}
+	details := make(map[int]MedicationDetails)
+
+	for _, u := range used {
+		medication, ok := details[u.MedicationID]
+		if !ok {
+			medication, err = readMedication(q, u.MedicationID)
+			if err != nil {
+				return nil, err
+			}
+			details[u.MedicationID] = medication
+		}
+
+		medications = append(medications, interactionMedication{medication, u.UsedIn, interactionSubjects(medication)})
+	}
+
+	return medications, nil
+}
+
+// CheckInteractions returns the interactions between the given medications, and between them and the other doses and PRN
+// medications of a patient, most severe first. The medications of the dose with the given ID, if any, are ignored, as the
+// checked medications replace them
+func CheckInteractions(patientID, doseID int, medicationIDs []int) ([]InteractionWarning, error) {
+	return checkInteractions(db, patientID, doseID, medicationIDs)
+}
+
+// checkInteractions checks medications for interactions inside or outside a transaction
+func checkInteractions(q querier, patientID, doseID int, medicationIDs []int) ([]InteractionWarning, error) {
+	// Read the checked medications
+	checked := []interactionMedication{}
+	seen := make(map[int]bool)
+
+	for _, medicationID := range medicationIDs {
+		if seen[medicationID] {
+			continue
+		}
+		seen[medicationID] = true
+
+		medication, err := readMedication(q, medicationID)
+		if err != nil {
+			return []InteractionWarning{}, err
+		}
+
+		checked = append(checked, interactionMedication{medication, "", interactionSubjects(medication)})
+	}
+
+	if len(checked) == 0 {
+		return []InteractionWarning{}, nil
+	}
+
+	others, err := listPatientMedications(q, patientID, doseID)
+	if err != nil {
+		return []InteractionWarning{}, err
+	}
+
+	// Read the interactions between any of the subjects
+	subjects := []string{}
+	for _, medication := range append(checked, others...) {
+		subjects = append(subjects, medication.Subjects...)
+	}
+
+	rows, err := q.Query(`SELECT SubjectA, SubjectB, Severity, Description FROM DrugInteractions
+	WHERE SubjectA = ANY($1) AND SubjectB = ANY($1)`, pq.Array(subjects))
+
+	if err != nil {
+		return []InteractionWarning{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	// Match each interaction to the pairs of medications it applies to
+	warnings := []InteractionWarning{}
+	var interaction DrugInteraction
+
+	for rows.Next() {
+		err = rows.Scan(&interaction.SubjectA, &interaction.SubjectB, &interaction.Severity, &interaction.Description)
+		if err != nil {
+			return []InteractionWarning{}, utils.InternalServerError(err)
+		}
+
+		matches := func(a, b interactionMedication) bool {
+			return (containsString(a.Subjects, interaction.SubjectA) && containsString(b.Subjects, interaction.SubjectB)) ||
+				(containsString(a.Subjects, interaction.SubjectB) && containsString(b.Subjects, interaction.SubjectA))
+		}
+
+		for i, medication := range checked {
+			// Pairs within the checked medications are only considered once
+			candidates := append(append([]interactionMedication{}, checked[i+1:]...), others...)
+
+			for _, other := range candidates {
+				if other.Medication.ID == medication.Medication.ID || !matches(medication, other) {
+					continue
+				}
+
+				warnings = append(warnings, InteractionWarning{
+					Severity:      interaction.Severity,
+					Description:   interaction.Description,
+					Medication:    medication.Medication.ToSummary(),
+					InteractsWith: other.Medication.ToSummary(),
+					UsedIn:        other.UsedIn,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(warnings, func(i, j int) bool {
+		return interactionSeverityRanks[warnings[i].Severity] > interactionSeverityRanks[warnings[j].Severity]
+	})
+
+	return warnings, nil
+}
+
+// checkDoseInteractions checks the medications of a dose that is about to be saved for interactions, inside the transaction
+// that saves it. Contraindications block the save unless an override reason is given, the other interactions are returned
+// as warnings
+func checkDoseInteractions(tx *sql.Tx, patientID, doseID int, medicationIDs []int, overrideReason string) ([]InteractionWarning, error) {
+	// Lock the patient, so doses that are saved concurrently are checked against each other
+	_, err := tx.Exec(`SELECT ID FROM Users WHERE ID = $1 FOR UPDATE`, patientID)
+	if err != nil {
+		return nil, utils.InternalServerError(err)
+	}
+
+	warnings, err := checkInteractions(tx, patientID, doseID, medicationIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(strings.TrimSpace(overrideReason)) > 0 {
+		return warnings, nil
+	}
+
+	contraindications := []string{}
+	for _, warning := range warnings {
+		if warning.Severity == InteractionSeverityContraindicated {
+			contraindications = append(contraindications, fmt.Sprintf("%s with %s: %s", warning.Medication.Title, warning.InteractsWith.Title, warning.Description))
+		}
+	}
+
+	if len(contraindications) > 0 {
+		return nil, utils.BadRequestErrorMessage(fmt.Sprintf("The dose is contraindicated, give an override reason to save it anyway. %s",
+			strings.Join(contraindications, "; ")))
+	}
+
+	return warnings, nil
+}
+
+// overrideReasonFor returns the override reason that is stored with a dose, which is only kept when it overrides a
+// contraindication
+func overrideReasonFor(warnings []InteractionWarning, overrideReason string) string {
+	for _, warning := range warnings {
+		if warning.Severity == InteractionSeverityContraindicated {
+			return strings.TrimSpace(overrideReason)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"main/utils"
+	"net/http"
+	"strconv"
+)
+
+// readInventoryRequest reads the dispenser ID from the URL, and checks whether the current session may access its inventory
+func readInventoryRequest(r *http.Request) (Session, int, error) {
+	// Read session from request
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		return Session{}, 0, err
+	}
+
+	// Read dispenser ID from URL
+	vars := mux.Vars(r)
+
+	dispenserID, err := strconv.Atoi(vars["dispenserId"])
+	if err != nil {
+		return Session{}, 0, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'dispenserId' isn't a valid integer.", vars["dispenserId"]))
+	}
+
+	err = AuthorizeInventoryAccess(session, dispenserID)
+	if err != nil {
+		return Session{}, 0, err
+	}
+
+	return session, dispenserID, nil
+}
+
+// HandleListDispenserInventory returns the current stock of a dispenser to the client
+func HandleListDispenserInventory(w http.ResponseWriter, r *http.Request) {
+	_, dispenserID, err := readInventoryRequest(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read the inventory from the database and write to the client
+	inventory, err := ListDispenserInventory(dispenserID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, inventory)
+}
+
+// HandleListDispenserRefills returns all refills of a dispenser to the client
+func HandleListDispenserRefills(w http.ResponseWriter, r *http.Request) {
+	_, dispenserID, err := readInventoryRequest(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read the refills from the database and write to the client
+	refills, err := ListDispenserRefills(dispenserID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, refills)
+}
+
+// HandleRefillDispenser handles the recording of a refill of a dispenser
+func HandleRefillDispenser(w http.ResponseWriter, r *http.Request) {
+	session, dispenserID, err := readInventoryRequest(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read the refill from the request body
+	var newRefill NewDispenserRefill
+
+	err = utils.ReadJSONFromRequest(r, &newRefill)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Record the refill and write to the client
+	refill, err := RefillDispenser(dispenserID, session.UserID, newRefill, actor)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, refill)
+}
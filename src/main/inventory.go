@@ -0,0 +1,387 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"main/utils"
+	"time"
+)
+
+type (
+	// InventoryItem contains the stock of a medication in a dispenser. The shortfall is the number of units that were
+	// dispensed since the last refill while the recorded stock was used up, which means the stock is wrong
+	InventoryItem struct {
+		Medication MedicationSummary `json:"medication"`
+		Stock      int               `json:"stock"`
+		Shortfall  int               `json:"shortfall"`
+		UpdatedOn  string            `json:"updatedOn"`
+	}
+
+	// DispenserRefill contains information on a refill of a dispenser
+	DispenserRefill struct {
+		ID             int               `json:"id"`
+		DispenserID    int               `json:"dispenserId"`
+		Medication     MedicationSummary `json:"medication"`
+		Amount         int               `json:"amount"`
+		RefilledByID   int               `json:"refilledById"`
+		RefilledByName string            `json:"refilledByName"`
+		RefilledOn     string            `json:"refilledOn"`
+		Notes          string            `json:"notes"`
+	}
+
+	// NewDispenserRefill contains all information on a to-be recorded refill of a dispenser
+	NewDispenserRefill struct {
+		MedicationID int    `json:"medicationId"`
+		Amount       int    `json:"amount"`
+		Notes        string `json:"notes"`
+	}
+)
+
+// dispenserRefillQuery selects all columns of a dispenser refill
+const dispenserRefillQuery = `SELECT DR.ID, DR.DispenserID, M.ID, M.Title, M.Description, DR.Amount, COALESCE(U.ID, 0),
+	COALESCE(U.FullName, ''), DR.RefilledOn, DR.Notes
+FROM DispenserRefills DR
+INNER JOIN Medications M ON DR.MedicationID = M.ID
+LEFT JOIN Users U ON DR.RefilledBy = U.ID`
+
+// dispenserPatientID returns the ID of the patient a dispenser is bound to, or 0 if the dispenser isn't bound
+func dispenserPatientID(q querier, dispenserID int) (int, error) {
+	var patientID int
+
+	err := q.QueryRow(`SELECT PatientID FROM DispenserAssignments WHERE DispenserID = $1`, dispenserID).Scan(&patientID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, utils.InternalServerError(err)
+	}
+
+	return patientID, nil
+}
+
+// AuthorizeInventoryAccess checks whether a session may access the inventory of a dispenser. Dispensers may only access their
+// own inventory, other users must be able to access the patient the dispenser is bound to
+func AuthorizeInventoryAccess(session Session, dispenserID int) error {
+	if session.ActorType == DispenserActor {
+		if session.UserID != dispenserID {
+			return utils.ForbiddenErrorMessage("Dispensers can only access their own inventory.")
+		}
+		return nil
+	}
+
+	patientID, err := dispenserPatientID(db, dispenserID)
+	if err != nil {
+		return err
+	}
+	if patientID == 0 {
+		return nil
+	}
+
+	return AuthorizePatientAccess(session, patientID)
+}
+
+// ListDispenserInventory returns the stock of all medications in a dispenser
+func ListDispenserInventory(dispenserID int) ([]InventoryItem, error) {
+	_, err := ReadDispenser(dispenserID)
+	if err != nil {
+		return []InventoryItem{}, err
+	}
+
+	rows, err := db.Query(`SELECT M.ID, M.Title, M.Description, DI.Stock, DI.Shortfall, DI.UpdatedOn
+	FROM DispenserInventory DI
+	INNER JOIN Medications M ON DI.MedicationID = M.ID
+	WHERE DI.DispenserID = $1
+	ORDER BY M.Title, M.ID`, dispenserID)
+
+	if err != nil {
+		return []InventoryItem{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over all rows and store in slice
+	items := []InventoryItem{}
+	var item InventoryItem
+	var updatedOn time.Time
+
+	for rows.Next() {
+		err = rows.Scan(&item.Medication.ID, &item.Medication.Title, &item.Medication.Description, &item.Stock, &item.Shortfall, &updatedOn)
+
+		if err != nil {
+			return []InventoryItem{}, utils.InternalServerError(err)
+		}
+
+		item.UpdatedOn = updatedOn.Format(time.RFC3339)
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// readDispenserRefillFromRow reads a dispenser refill from a row selected with dispenserRefillQuery
+func readDispenserRefillFromRow(row interface {
+	Scan(...interface{}) error
+}) (DispenserRefill, error) {
+	var refill DispenserRefill
+	var refilledOn time.Time
+
+	err := row.Scan(&refill.ID, &refill.DispenserID, &refill.Medication.ID, &refill.Medication.Title, &refill.Medication.Description,
+		&refill.Amount, &refill.RefilledByID, &refill.RefilledByName, &refilledOn, &refill.Notes)
+
+	if err != nil {
+		return DispenserRefill{}, err
+	}
+
+	refill.RefilledOn = refilledOn.Format(time.RFC3339)
+
+	return refill, nil
+}
+
+// ListDispenserRefills returns all refills of a dispenser, most recent first
+func ListDispenserRefills(dispenserID int) ([]DispenserRefill, error) {
+	_, err := ReadDispenser(dispenserID)
+	if err != nil {
+		return []DispenserRefill{}, err
+	}
+
+	rows, err := db.Query(dispenserRefillQuery+`
+	WHERE DR.DispenserID = $1
+	ORDER BY DR.RefilledOn DESC, DR.ID DESC`, dispenserID)
+
+	if err != nil {
+		return []DispenserRefill{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over all rows and store in slice
+	refills := []DispenserRefill{}
+
+	for rows.Next() {
+		refill, err := readDispenserRefillFromRow(rows)
+		if err != nil {
+			return []DispenserRefill{}, utils.InternalServerError(err)
+		}
+
+		refills = append(refills, refill)
+	}
+
+	return refills, nil
+}
+
+// ReadDispenserRefill returns a single refill of a dispenser
+func ReadDispenserRefill(dispenserID, refillID int) (DispenserRefill, error) {
+	return readDispenserRefill(db, dispenserID, refillID)
+}
+
+// readDispenserRefill reads a single refill of a dispenser inside or outside a transaction
+func readDispenserRefill(q querier, dispenserID, refillID int) (DispenserRefill, error) {
+	refill, err := readDispenserRefillFromRow(q.QueryRow(dispenserRefillQuery+`
+	WHERE DR.DispenserID = $1 AND DR.ID = $2`, dispenserID, refillID))
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return DispenserRefill{}, utils.NotFoundErrorMessage(fmt.Sprintf("No refill with ID %d for dispenser with ID %d", refillID, dispenserID))
+		}
+		return DispenserRefill{}, utils.InternalServerError(err)
+	}
+
+	return refill, nil
+}
+
+// RefillDispenser records a refill of a dispenser and adds the refilled amount to its stock
+func RefillDispenser(dispenserID, userID int, newRefill NewDispenserRefill, actor AuditActor) (DispenserRefill, error) {
+	// Validate the refill
+	dispenser, err := ReadDispenser(dispenserID)
+	if err != nil {
+		return DispenserRefill{}, err
+	}
+	if dispenser.Decommissioned {
+		return DispenserRefill{}, utils.BadRequestErrorMessage(fmt.Sprintf("Dispenser with ID %d has been decommissioned.", dispenserID))
+	}
+
+	if newRefill.Amount == 0 {
+		return DispenserRefill{}, utils.BadRequestErrorMessage("The amount of a refill can't be 0.")
+	}
+
+	var medicationExists bool
+	err = db.QueryRow(`SELECT EXISTS(SELECT 1 FROM Medications WHERE ID = $1)`, newRefill.MedicationID).Scan(&medicationExists)
+	if err != nil {
+		return DispenserRefill{}, utils.InternalServerError(err)
+	}
+	if !medicationExists {
+		return DispenserRefill{}, utils.BadRequestErrorMessage(fmt.Sprintf("No medication with ID %d", newRefill.MedicationID))
+	}
+
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return DispenserRefill{}, utils.InternalServerError(err)
+	}
+
+	// Add the amount to the stock of the medication, a refill resolves the shortfall. Corrections can't remove more than
+	// the dispenser holds
+	if newRefill.Amount > 0 {
+		_, err = tx.Exec(`INSERT INTO DispenserInventory (DispenserID, MedicationID, Stock) VALUES ($1, $2, $3)
+		ON CONFLICT (DispenserID, MedicationID) DO UPDATE
+		SET Stock = DispenserInventory.Stock + EXCLUDED.Stock, Shortfall = 0, UpdatedOn = NOW()`,
+			dispenserID, newRefill.MedicationID, newRefill.Amount)
+	} else {
+		var result sql.Result
+		result, err = tx.Exec(`UPDATE DispenserInventory
+		SET Stock = Stock + $3, UpdatedOn = NOW()
+		WHERE DispenserID = $1 AND MedicationID = $2 AND Stock + $3 >= 0`, dispenserID, newRefill.MedicationID, newRefill.Amount)
+
+		if err == nil {
+			if n, err := result.RowsAffected(); err != nil {
+				utils.RollbackOrLog(tx)
+				return DispenserRefill{}, utils.InternalServerError(err)
+			} else if n == 0 {
+				utils.RollbackOrLog(tx)
+				return DispenserRefill{}, utils.BadRequestErrorMessage(fmt.Sprintf("Dispenser with ID %d holds fewer than %d units of medication with ID %d.",
+					dispenserID, -newRefill.Amount, newRefill.MedicationID))
+			}
+		}
+	}
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return DispenserRefill{}, utils.InternalServerError(err)
+	}
+
+	// Record the refill
+	var refillID int
+	err = tx.QueryRow(`INSERT INTO DispenserRefills (DispenserID, MedicationID, Amount, RefilledBy, Notes)
+	VALUES ($1, $2, $3, $4, $5) RETURNING ID`, dispenserID, newRefill.MedicationID, newRefill.Amount, userID, newRefill.Notes).Scan(&refillID)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return DispenserRefill{}, utils.InternalServerError(err)
+	}
+
+	// Audit the refill under the patient the dispenser is bound to, if any
+	refill, err := readDispenserRefill(tx, dispenserID, refillID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return DispenserRefill{}, err
+	}
+
+	patientID, err := dispenserPatientID(tx, dispenserID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return DispenserRefill{}, err
+	}
+
+	err = RecordAudit(tx, actor, AuditActionCreate, AuditEntityRefill, refillID, patientID, nil, refill)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return DispenserRefill{}, err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return DispenserRefill{}, utils.InternalServerError(err)
+	}
+
+	notifyInventoryUpdated(dispenserID)
+
+	return refill, nil
+}
+
+// dispensingDispenserID returns the ID of the dispenser that is dispensing medication in a session, or 0 if the session
+// doesn't belong to a dispenser
+func dispensingDispenserID(session Session) int {
+	if session.ActorType == DispenserActor {
+		return session.UserID
+	}
+
+	return 0
+}
+
+// resolveDispensingDispenser returns the dispenser medication of a patient was dispensed from. Without an explicit dispenser,
+// the only dispenser bound to the patient is used. If the patient has no or several dispensers, 0 is returned
+func resolveDispensingDispenser(q querier, dispenserID, patientID int) (int, error) {
+	if dispenserID != 0 {
+		return dispenserID, nil
+	}
+
+	rows, err := q.Query(`SELECT DispenserID FROM DispenserAssignments WHERE PatientID = $1`, patientID)
+	if err != nil {
+		return 0, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	dispenserIDs := []int{}
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return 0, utils.InternalServerError(err)
+		}
+
+		dispenserIDs = append(dispenserIDs, id)
+	}
+
+	if len(dispenserIDs) != 1 {
+		return 0, nil
+	}
+
+	return dispenserIDs[0], nil
+}
+
+// decrementDispenserInventory lowers the stock of a dispenser by the medication a dispense took from it, in the transaction
+// that records the dispense. The query must update the stock of dispenser $1 using the dispensed entity with ID $2. The ID
+// of the dispenser is returned if its inventory changed, so it can be notified once the transaction is committed
+func decrementDispenserInventory(tx *sql.Tx, dispenserID, patientID int, query string, entityID int) (int, error) {
+	dispenserID, err := resolveDispensingDispenser(tx, dispenserID, patientID)
+	if err != nil {
+		return 0, err
+	}
+	if dispenserID == 0 {
+		return 0, nil
+	}
+
+	result, err := tx.Exec(query, dispenserID, entityID)
+	if err != nil {
+		return 0, utils.InternalServerError(err)
+	}
+
+	// Medications that were never refilled aren't tracked, so there is nothing to notify about
+	if n, err := result.RowsAffected(); err != nil {
+		return 0, utils.InternalServerError(err)
+	} else if n == 0 {
+		return 0, nil
+	}
+
+	return dispenserID, nil
+}
+
+// DecrementDoseInventory lowers the stock of a dispenser by the medications of a dispensed dose. Units dispensed beyond the
+// recorded stock are added to the shortfall
+func DecrementDoseInventory(tx *sql.Tx, dispenserID, patientID, doseID int) (int, error) {
+	return decrementDispenserInventory(tx, dispenserID, patientID, `UPDATE DispenserInventory DI
+	SET Stock = GREATEST(DI.Stock - DM.Amount, 0), Shortfall = DI.Shortfall + GREATEST(DM.Amount - DI.Stock, 0), UpdatedOn = NOW()
+	FROM DoseMedications DM
+	WHERE DI.DispenserID = $1 AND DM.DoseID = $2 AND DI.MedicationID = DM.MedicationID`, doseID)
+}
+
+// DecrementPRNInventory lowers the stock of a dispenser by a single unit of a dispensed PRN medication. A unit dispensed
+// without stock is added to the shortfall
+func DecrementPRNInventory(tx *sql.Tx, dispenserID, patientID, prnMedicationID int) (int, error) {
+	return decrementDispenserInventory(tx, dispenserID, patientID, `UPDATE DispenserInventory DI
+	SET Stock = GREATEST(DI.Stock - 1, 0), Shortfall = DI.Shortfall + GREATEST(1 - DI.Stock, 0), UpdatedOn = NOW()
+	FROM PRNMedications P
+	WHERE DI.DispenserID = $1 AND P.ID = $2 AND DI.MedicationID = P.MedicationID`, prnMedicationID)
+}
+
+// notifyInventoryUpdated notifies the dispatcher that the inventory of a dispenser has been updated. The inventory has
+// already been stored, so a failure is only logged
+func notifyInventoryUpdated(dispenserID int) {
+	inventory, err := ListDispenserInventory(dispenserID)
+	if err != nil {
+		utils.LogError(err)
+		return
+	}
+
+	inventorySubject.InventoryUpdated(dispenserID, inventory)
+}
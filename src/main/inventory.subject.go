@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"main/dispatch"
+	"reflect"
+)
+
+type (
+	// InventorySubject represents a subscribable subject pertaining to the inventory of a dispenser
+	InventorySubject struct {
+		Title    string
+		messages chan dispatch.SubjectMessage
+	}
+
+	// inventorySubscriptionParams contains the subscription parameters to an InventorySubject subject
+	inventorySubscriptionParams struct {
+		DispenserID int
+	}
+
+	// InventoryUpdatedPayload contains the payload for an "updated" message
+	InventoryUpdatedPayload struct {
+		DispenserID int             `json:"-"`
+		Inventory   []InventoryItem `json:"inventory"`
+	}
+)
+
+// NewInventorySubject creates a new InventorySubject
+func NewInventorySubject(dispatcher *dispatch.Dispatcher) *InventorySubject {
+	subject := &InventorySubject{
+		Title:    "inventory",
+		messages: make(chan dispatch.SubjectMessage, 10),
+	}
+
+	dispatcher.RegisterSubject(subject)
+
+	return subject
+}
+
+func (isp *inventorySubscriptionParams) IsEqualTo(params dispatch.SubscriptionParams) bool {
+	if isp2, ok := params.(*inventorySubscriptionParams); ok {
+		return isp.DispenserID == isp2.DispenserID
+	}
+
+	return false
+}
+
+func (is *InventorySubject) GetTitle() string {
+	return is.Title
+}
+
+func (is *InventorySubject) CreateSubscriptionParams(params map[string]interface{}) (dispatch.SubscriptionParams, error) {
+	dID, ok := params["dispenserId"]
+	if !ok {
+		return nil, dispatch.BadRequestErrorMessage("Missing field 'dispenserId' in subscription parameters for subject 'inventory'")
+	}
+
+	dispenserID, ok := dID.(float64)
+	if !ok {
+		return nil, dispatch.BadRequestErrorMessage(fmt.Sprintf("Expected field 'dispenserId' to be of type number, got %s", reflect.TypeOf(dID).Name()))
+	}
+
+	return &inventorySubscriptionParams{
+		DispenserID: int(dispenserID),
+	}, nil
+}
+
+func (is *InventorySubject) MessageShouldBeSentToSubscription(message dispatch.SubjectMessage, sp dispatch.SubscriptionParams) bool {
+	subscriptionParams, ok := sp.(*inventorySubscriptionParams)
+	if !ok {
+		return false
+	}
+
+	payload, ok := message.Payload.(InventoryUpdatedPayload)
+	if !ok {
+		return false
+	}
+
+	return payload.DispenserID == subscriptionParams.DispenserID
+}
+
+func (is *InventorySubject) GetMessageChan() <-chan dispatch.SubjectMessage {
+	return is.messages
+}
+
+func (is *InventorySubject) AuthorizeSubscription(principal dispatch.Principal, sp dispatch.SubscriptionParams) error {
+	subscriptionParams, ok := sp.(*inventorySubscriptionParams)
+	if !ok {
+		return dispatch.BadRequestErrorMessage("Invalid subscription parameters")
+	}
+
+	err := authorizeSubscription(principal, InventoryReadPermission, 0)
+	if err != nil {
+		return err
+	}
+
+	session, ok := principal.(Session)
+	if !ok {
+		return dispatch.UnauthorizedErrorMessage("The client has not authenticated")
+	}
+
+	return toDispatcherError(AuthorizeInventoryAccess(session, subscriptionParams.DispenserID))
+}
+
+// InventoryUpdated notifies subscribers of the subject that the inventory of a dispenser has been updated
+func (is *InventorySubject) InventoryUpdated(dispenserID int, inventory []InventoryItem) {
+	is.messages <- dispatch.SubjectMessage{
+		Action: dispatch.CollectionEntityUpdatedAction,
+		Payload: InventoryUpdatedPayload{
+			DispenserID: dispenserID,
+			Inventory:   inventory,
+		},
+	}
+}
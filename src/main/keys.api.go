@@ -0,0 +1,11 @@
+package main
+
+import (
+	"main/utils"
+	"net/http"
+)
+
+// HandleListJSONWebKeys handles the listing of the public keys with which tokens can be verified
+func HandleListJSONWebKeys(w http.ResponseWriter, r *http.Request) {
+	utils.WriteJSON(w, ListJSONWebKeys())
+}
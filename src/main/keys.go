@@ -0,0 +1,311 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"io/ioutil"
+	"log"
+	"main/utils"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type (
+	// SigningKey contains a key that is used to sign or verify JSON web tokens
+	SigningKey struct {
+		ID         string
+		Method     jwt.SigningMethod
+		PrivateKey crypto.PrivateKey
+		PublicKey  crypto.PublicKey
+	}
+
+	// JSONWebKey contains the public part of a signing key, as described in RFC 7517
+	JSONWebKey struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		Alg string `json:"alg"`
+		N   string `json:"n,omitempty"`
+		E   string `json:"e,omitempty"`
+		Crv string `json:"crv,omitempty"`
+		X   string `json:"x,omitempty"`
+		Y   string `json:"y,omitempty"`
+	}
+
+	// JSONWebKeySet contains the public parts of all signing keys
+	JSONWebKeySet struct {
+		Keys []JSONWebKey `json:"keys"`
+	}
+)
+
+const (
+	// Environment variables from which signing keys can be loaded
+	SigningKeysEnv   = "JWT_SIGNING_KEYS"
+	ActiveKeyIDEnv   = "JWT_ACTIVE_KEY_ID"
+	privateKeySuffix = ".pem"
+	publicKeySuffix  = ".pub.pem"
+)
+
+var (
+	signingKeys      map[string]*SigningKey
+	activeSigningKey *SigningKey
+)
+
+// loadSigningKeys loads all signing keys from the key directory and environment, and selects the key used for signing
+func loadSigningKeys() {
+	signingKeys = make(map[string]*SigningKey)
+	privateKeyIDs := []string{}
+
+	// Read keys from the key directory, where the file name is the key ID. Public keys can only be used for verification,
+	// which allows retired keys to be kept until all tokens signed with them have expired
+	if len(config.JWT.KeyDirectory) > 0 {
+		files, err := filepath.Glob(filepath.Join(config.JWT.KeyDirectory, "*"+privateKeySuffix))
+		if err != nil {
+			utils.LogErrorMessageFatal(fmt.Sprintf("Error reading key directory: %s", err.Error()))
+		}
+
+		for _, file := range files {
+			keyID := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(file), publicKeySuffix), privateKeySuffix)
+
+			// The glob also matches public keys, which are skipped if the private key of the same ID is present
+			if strings.HasSuffix(file, publicKeySuffix) {
+				if _, err := os.Stat(filepath.Join(config.JWT.KeyDirectory, keyID+privateKeySuffix)); err == nil {
+					continue
+				}
+			}
+
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				utils.LogErrorMessageFatal(fmt.Sprintf("Error reading key file %s: %s", file, err.Error()))
+			}
+
+			key, err := parseSigningKey(keyID, data)
+			if err != nil {
+				utils.LogErrorMessageFatal(fmt.Sprintf("Error parsing key file %s: %s", file, err.Error()))
+			}
+
+			signingKeys[key.ID] = key
+			if key.PrivateKey != nil {
+				privateKeyIDs = append(privateKeyIDs, key.ID)
+			}
+		}
+	}
+
+	sort.Strings(privateKeyIDs)
+
+	// Read PEM encoded keys from the environment, their key ID is their RFC 7638 thumbprint
+	rest := []byte(os.Getenv(SigningKeysEnv))
+	envKeyIDs := []string{}
+
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		key, err := parseSigningKey("", pem.EncodeToMemory(block))
+		if err != nil {
+			utils.LogErrorMessageFatal(fmt.Sprintf("Error parsing key from %s: %s", SigningKeysEnv, err.Error()))
+		}
+
+		signingKeys[key.ID] = key
+		if key.PrivateKey != nil {
+			envKeyIDs = append(envKeyIDs, key.ID)
+		}
+	}
+
+	// Select the active key, by default the first key from the environment or the last key in the key directory
+	activeKeyID := config.JWT.ActiveKeyID
+	if envActiveKeyID := os.Getenv(ActiveKeyIDEnv); len(envActiveKeyID) > 0 {
+		activeKeyID = envActiveKeyID
+	}
+
+	if len(activeKeyID) == 0 {
+		if len(envKeyIDs) > 0 {
+			activeKeyID = envKeyIDs[0]
+		} else if len(privateKeyIDs) > 0 {
+			activeKeyID = privateKeyIDs[len(privateKeyIDs)-1]
+		}
+	}
+
+	if len(activeKeyID) == 0 {
+		// Without any keys, refuse to start unless an ephemeral key is explicitly allowed, e.g. in development
+		if !config.JWT.AllowEphemeralKey {
+			utils.LogErrorMessageFatal(fmt.Sprintf("No JWT signing keys configured, add a key to the key directory or to %s", SigningKeysEnv))
+		}
+
+		// Generate a key that is only valid as long as the process runs
+		log.Println("No JWT signing keys configured, generating an ephemeral key. Tokens will be invalid after a restart")
+
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			utils.LogErrorMessageFatal(fmt.Sprintf("Error generating JWT signing key: %s", err.Error()))
+		}
+
+		activeSigningKey = &SigningKey{Method: jwt.SigningMethodES256, PrivateKey: privateKey, PublicKey: &privateKey.PublicKey}
+		activeSigningKey.ID = activeSigningKey.Thumbprint()
+		signingKeys[activeSigningKey.ID] = activeSigningKey
+		return
+	}
+
+	key, ok := signingKeys[activeKeyID]
+	if !ok || key.PrivateKey == nil {
+		utils.LogErrorMessageFatal(fmt.Sprintf("Active JWT signing key '%s' not found or not a private key", activeKeyID))
+	}
+
+	activeSigningKey = key
+	log.Printf("Signing JWTs with key '%s' (%s), %d key(s) accepted for verification", key.ID, key.Method.Alg(), len(signingKeys))
+}
+
+// parseSigningKey parses a PEM encoded RSA or ECDSA key. If no ID is given, the thumbprint of the key is used
+func parseSigningKey(keyID string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("No PEM data found")
+	}
+
+	key := &SigningKey{ID: keyID}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key.PrivateKey = privateKey
+	case "EC PRIVATE KEY":
+		privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key.PrivateKey = privateKey
+	case "PRIVATE KEY":
+		privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key.PrivateKey = privateKey
+	case "PUBLIC KEY":
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key.PublicKey = publicKey
+	default:
+		return nil, fmt.Errorf("Unsupported PEM block type '%s'", block.Type)
+	}
+
+	// Derive the public key and signing method from the key type
+	switch privateKey := key.PrivateKey.(type) {
+	case *rsa.PrivateKey:
+		key.PublicKey = &privateKey.PublicKey
+	case *ecdsa.PrivateKey:
+		key.PublicKey = &privateKey.PublicKey
+	case nil:
+	default:
+		return nil, fmt.Errorf("Unsupported private key type %T", privateKey)
+	}
+
+	switch publicKey := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		switch publicKey.Curve {
+		case elliptic.P256():
+			key.Method = jwt.SigningMethodES256
+		case elliptic.P384():
+			key.Method = jwt.SigningMethodES384
+		case elliptic.P521():
+			key.Method = jwt.SigningMethodES512
+		default:
+			return nil, fmt.Errorf("Unsupported elliptic curve %s", publicKey.Curve.Params().Name)
+		}
+	default:
+		return nil, fmt.Errorf("Unsupported public key type %T", publicKey)
+	}
+
+	if len(key.ID) == 0 {
+		key.ID = key.Thumbprint()
+	}
+
+	return key, nil
+}
+
+// JWK returns the public part of the key as a JSON web key
+func (sk *SigningKey) JWK() JSONWebKey {
+	jwk := JSONWebKey{
+		Kid: sk.ID,
+		Use: "sig",
+		Alg: sk.Method.Alg(),
+	}
+
+	switch publicKey := sk.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+
+		jwk.Kty = "EC"
+		jwk.Crv = publicKey.Curve.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(padBytes(publicKey.X.Bytes(), size))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(padBytes(publicKey.Y.Bytes(), size))
+	}
+
+	return jwk
+}
+
+// Thumbprint returns the RFC 7638 thumbprint of the key
+func (sk *SigningKey) Thumbprint() string {
+	jwk := sk.JWK()
+
+	var canonical string
+	switch jwk.Kty {
+	case "RSA":
+		canonical = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, jwk.E, jwk.N)
+	case "EC":
+		canonical = fmt.Sprintf(`{"crv":"%s","kty":"EC","x":"%s","y":"%s"}`, jwk.Crv, jwk.X, jwk.Y)
+	}
+
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// padBytes left-pads a big-endian byte slice with zeroes to the given size
+func padBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+
+	return append(make([]byte, size-len(b)), b...)
+}
+
+// ListJSONWebKeys returns the public parts of all keys accepted for verification
+func ListJSONWebKeys() JSONWebKeySet {
+	keyIDs := []string{}
+	for keyID := range signingKeys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+
+	keySet := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, keyID := range keyIDs {
+		keySet.Keys = append(keySet.Keys, signingKeys[keyID].JWK())
+	}
+
+	return keySet
+}
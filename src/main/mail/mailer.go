@@ -0,0 +1,99 @@
+package mail
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type (
+	// Mailer contains the methods a mail transport must implement
+	Mailer interface {
+		// SendMail sends a plain text e-mail to a single recipient
+		SendMail(to, subject, body string) error
+	}
+
+	// FileMailer is a Mailer that writes e-mails to files in a directory instead of sending them, for use in development
+	FileMailer struct {
+		Directory string
+		From      string
+	}
+
+	// SMTPMailer is a Mailer that sends e-mails through an SMTP server
+	SMTPMailer struct {
+		Host     string
+		Port     string
+		Username string
+		Password string
+		From     string
+	}
+)
+
+// NewFileMailer creates a new FileMailer writing to the given directory
+func NewFileMailer(directory, from string) *FileMailer {
+	return &FileMailer{
+		Directory: directory,
+		From:      from,
+	}
+}
+
+// NewSMTPMailer creates a new SMTPMailer
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     from,
+	}
+}
+
+// SendMail writes the e-mail to a new .eml file in the directory of the mailer
+func (fm *FileMailer) SendMail(to, subject, body string) error {
+	err := os.MkdirAll(fm.Directory, 0700)
+	if err != nil {
+		return err
+	}
+
+	fileName := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), sanitizeFileName(to))
+
+	return ioutil.WriteFile(filepath.Join(fm.Directory, fileName), composeMessage(fm.From, to, subject, body), 0600)
+}
+
+// SendMail sends the e-mail through the SMTP server of the mailer
+func (sm *SMTPMailer) SendMail(to, subject, body string) error {
+	var auth smtp.Auth
+	if len(sm.Username) > 0 {
+		auth = smtp.PlainAuth("", sm.Username, sm.Password, sm.Host)
+	}
+
+	return smtp.SendMail(fmt.Sprintf("%s:%s", sm.Host, sm.Port), auth, sm.From, []string{to}, composeMessage(sm.From, to, subject, body))
+}
+
+// composeMessage creates the raw message of a plain text e-mail
+func composeMessage(from, to, subject, body string) []byte {
+	headers := []string{
+		fmt.Sprintf("From: %s", from),
+		fmt.Sprintf("To: %s", to),
+		fmt.Sprintf("Subject: %s", subject),
+		fmt.Sprintf("Date: %s", time.Now().Format(time.RFC1123Z)),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}
+
+	return []byte(strings.Join(headers, "\r\n") + "\r\n\r\n" + body)
+}
+
+// sanitizeFileName replaces all characters that may not be safe in a file name
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, s)
+}
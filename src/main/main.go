@@ -22,42 +22,127 @@ func main() {
 	// Initialize router
 	r := mux.NewRouter()
 
+	r.HandleFunc("/.well-known/jwks.json", HandleListJSONWebKeys).Methods("GET")
+	r.HandleFunc("/api/jwks", HandleListJSONWebKeys).Methods("GET")
+
 	r.HandleFunc("/api/authenticate", HandleAuthenticate).Methods("POST")
+	r.HandleFunc("/api/authenticate/refresh", HandleRefreshSession).Methods("POST")
+	r.HandleFunc("/api/authenticate/twofactor", HandleCompleteTwoFactorAuthentication).Methods("POST")
+	r.HandleFunc("/api/authenticate/twofactor/enroll", HandleBeginChallengeEnrollment).Methods("POST")
 	r.HandleFunc("/api/authenticatedispenser", HandleAuthenticateDispenser).Methods("POST")
-
-	r.HandleFunc("/api/medications", CheckJWT(CheckRole(DoctorOrPharmacist, HandleCreateMedication))).Methods("POST")
-	r.HandleFunc("/api/medications", CheckJWT(CheckRole(DoctorOrPharmacist, HandleListMedications))).Methods("GET")
-	r.HandleFunc("/api/medications/{medicationId}", CheckJWT(CheckRole(DoctorOrPharmacist, HandleReadMedication))).Methods("GET")
-	r.HandleFunc("/api/medications/{medicationId}", CheckJWT(CheckRole(DoctorOrPharmacist, HandleUpdateMedication))).Methods("PUT")
-	r.HandleFunc("/api/medications/{medicationId}", CheckJWT(CheckRole(DoctorOrPharmacist, HandleDeleteMedication))).Methods("DELETE")
-
-	r.HandleFunc("/api/users", CheckJWT(CheckRole(Doctor, HandleCreateUser))).Methods("POST")
-	r.HandleFunc("/api/users", CheckJWT(CheckRole(Doctor, HandleListUsers))).Methods("GET")
-	r.HandleFunc("/api/users/{userId}", CheckJWT(CheckRole(Doctor, HandleReadUser))).Methods("GET")
-	r.HandleFunc("/api/users/{userId}", CheckJWT(CheckRole(Doctor, HandleUpdateUser))).Methods("PUT")
-	r.HandleFunc("/api/users/{userId}", CheckJWT(CheckRole(Doctor, HandleDeleteUser))).Methods("DELETE")
-
-	r.HandleFunc("/api/users/{userId}/doses", CheckJWT(CheckRole(Doctor, HandleCreateDose))).Methods("POST")
-	r.HandleFunc("/api/users/{userId}/doses", CheckJWT(HandleListDoses)).Methods("GET")
-	r.HandleFunc("/api/users/{userId}/doses/{doseId}", CheckJWT(HandleReadDose)).Methods("GET")
-	r.HandleFunc("/api/users/{userId}/doses/{doseId}", CheckJWT(CheckRole(Doctor, HandleUpdateDose))).Methods("PUT")
-	r.HandleFunc("/api/users/{userId}/doses/{doseId}", CheckJWT(CheckRole(Doctor, HandleDeleteDose))).Methods("DELETE")
-
-	r.HandleFunc("/api/users/{userId}/dosehistory", CheckJWT(CheckRole(Dispenser, HandleCreateDoseHistoryEntry))).Methods("POST")
-	r.HandleFunc("/api/users/{userId}/dosehistory", CheckJWT(CheckRole(Doctor, HandleListDoseHistoryEntries))).Methods("GET")
-	r.HandleFunc("/api/users/{userId}/dosehistory/{doseHistoryEntryId}", CheckJWT(CheckRole(Doctor, HandleReadDoseHistoryEntry))).Methods("GET")
-
-	r.HandleFunc("/api/users/{userId}/prnmedications", CheckJWT(HandleListPRNMedications)).Methods("GET")
-	r.HandleFunc("/api/users/{userId}/prnmedications", CheckJWT(CheckRole(Doctor, HandleCreatePRNMedication))).Methods("POST")
-	r.HandleFunc("/api/users/{userId}/prnmedications/{prnMedicationId}", CheckJWT(HandleReadPRNMedication)).Methods("GET")
-	r.HandleFunc("/api/users/{userId}/prnmedications/{prnMedicationId}", CheckJWT(CheckRole(Doctor, HandleUpdatePRNMedication))).Methods("PUT")
-	r.HandleFunc("/api/users/{userId}/prnmedications/{prnMedicationId}", CheckJWT(CheckRole(Doctor, HandleDeletePRNMedication))).Methods("DELETE")
-
-	r.HandleFunc("/api/users/{userId}/dosesummaries", CheckJWT(CheckRole(Doctor, HandleListDoseSummaries))).Methods("GET")
-	r.HandleFunc("/api/users/{userId}/dosesummaries/{date}", CheckJWT(CheckRole(Doctor, HandleReadDoseSummary))).Methods("GET")
-	r.HandleFunc("/api/users/{userId}/prnsummaries/{date}", CheckJWT(CheckRole(Doctor, HandleReadPRNSummary))).Methods("GET")
-
-	r.HandleFunc("/api/users/{userId}/prnhistory", CheckJWT(CheckRole(Dispenser, HandleCreatePRNHistoryEntry))).Methods("POST")
+	r.HandleFunc("/api/authenticatedispenser/refresh", HandleRefreshDispenserSession).Methods("POST")
+	r.HandleFunc("/api/logout", CheckJWT(HandleLogout)).Methods("POST")
+
+	r.HandleFunc("/api/sessions", CheckJWT(HandleListOwnSessions)).Methods("GET")
+	r.HandleFunc("/api/sessions", CheckJWT(HandleRevokeOwnSessions)).Methods("DELETE")
+	r.HandleFunc("/api/sessions/{sessionId}", CheckJWT(HandleRevokeOwnSession)).Methods("DELETE")
+
+	r.HandleFunc("/api/me", CheckJWT(HandleReadMe)).Methods("GET")
+	r.HandleFunc("/api/me/contact", CheckJWT(HandleUpdateMyContactDetails)).Methods("PUT")
+	r.HandleFunc("/api/me/password", CheckJWT(HandleChangePassword)).Methods("PUT")
+	r.HandleFunc("/api/me/dosestatuses", CheckJWT(CheckPermission(SummariesReadPermission, HandleReadMyDoseStatuses))).Methods("GET")
+	r.HandleFunc("/api/me/dosehistory", CheckJWT(CheckPermission(HistoryReadPermission, HandleListMyDoseHistoryEntries))).Methods("GET")
+	r.HandleFunc("/api/me/prneligibility", CheckJWT(CheckPermission(PRNMedicationsReadPermission, HandleListMyPRNEligibility))).Methods("GET")
+
+	r.HandleFunc("/api/password", CheckJWT(HandleChangePassword)).Methods("PUT")
+
+	r.HandleFunc("/api/twofactor", CheckJWT(HandleBeginTwoFactorEnrollment)).Methods("POST")
+	r.HandleFunc("/api/twofactor", CheckJWT(HandleDisableTwoFactor)).Methods("DELETE")
+	r.HandleFunc("/api/twofactor/confirm", CheckJWT(HandleConfirmTwoFactorEnrollment)).Methods("POST")
+	r.HandleFunc("/api/twofactor/recoverycodes", CheckJWT(HandleRegenerateRecoveryCodes)).Methods("POST")
+	r.HandleFunc("/api/passwordreset", HandleRequestPasswordReset).Methods("POST")
+	r.HandleFunc("/api/passwordreset/complete", HandleResetPassword).Methods("POST")
+
+	r.HandleFunc("/api/lockouts", CheckJWT(CheckPermission(LockoutsManagePermission, HandleListLockouts))).Methods("GET")
+	r.HandleFunc("/api/lockouts/{lockoutId}", CheckJWT(CheckPermission(LockoutsManagePermission, HandleUnlock))).Methods("DELETE")
+
+	r.HandleFunc("/api/permissions", CheckJWT(CheckPermission(RolesManagePermission, HandleListPermissions))).Methods("GET")
+	r.HandleFunc("/api/roles", CheckJWT(CheckPermission(RolesManagePermission, HandleListRoles))).Methods("GET")
+	r.HandleFunc("/api/roles/{role}", CheckJWT(CheckPermission(RolesManagePermission, HandleReadRole))).Methods("GET")
+	r.HandleFunc("/api/roles/{role}", CheckJWT(CheckPermission(RolesManagePermission, HandleSaveRole))).Methods("PUT")
+	r.HandleFunc("/api/roles/{role}", CheckJWT(CheckPermission(RolesManagePermission, HandleDeleteRole))).Methods("DELETE")
+
+	r.HandleFunc("/api/audit", CheckJWT(CheckPermission(AuditReadPermission, HandleListAuditEntries))).Methods("GET")
+
+	r.HandleFunc("/api/apikeys", CheckJWT(CheckPermission(APIKeysManagePermission, HandleCreateAPIKey))).Methods("POST")
+	r.HandleFunc("/api/apikeys", CheckJWT(CheckPermission(APIKeysManagePermission, HandleListAPIKeys))).Methods("GET")
+	r.HandleFunc("/api/apikeys/{apiKeyId}", CheckJWT(CheckPermission(APIKeysManagePermission, HandleReadAPIKey))).Methods("GET")
+	r.HandleFunc("/api/apikeys/{apiKeyId}", CheckJWT(CheckPermission(APIKeysManagePermission, HandleRevokeAPIKey))).Methods("DELETE")
+	r.HandleFunc("/api/apikeys/{apiKeyId}/usage", CheckJWT(CheckPermission(APIKeysManagePermission, HandleListAPIKeyUsage))).Methods("GET")
+
+	r.HandleFunc("/api/interactions", CheckJWT(CheckPermission(MedicationsReadPermission, HandleListDrugInteractions))).Methods("GET")
+	r.HandleFunc("/api/interactions/import", CheckJWT(CheckPermission(InteractionsManagePermission, HandleImportDrugInteractions))).Methods("POST")
+	r.HandleFunc("/api/interactions/{interactionId}", CheckJWT(CheckPermission(InteractionsManagePermission, HandleDeleteDrugInteraction))).Methods("DELETE")
+
+	r.HandleFunc("/api/medications", CheckJWTOrAPIKey(CheckPermission(MedicationsWritePermission, HandleCreateMedication))).Methods("POST")
+	r.HandleFunc("/api/medications", CheckJWTOrAPIKey(CheckPermission(MedicationsReadPermission, HandleListMedications))).Methods("GET")
+	r.HandleFunc("/api/medications/{medicationId}", CheckJWTOrAPIKey(CheckPermission(MedicationsReadPermission, HandleReadMedication))).Methods("GET")
+	r.HandleFunc("/api/medications/{medicationId}", CheckJWTOrAPIKey(CheckPermission(MedicationsWritePermission, HandleUpdateMedication))).Methods("PUT")
+	r.HandleFunc("/api/medications/{medicationId}", CheckJWTOrAPIKey(CheckPermission(MedicationsWritePermission, HandleDeleteMedication))).Methods("DELETE")
+
+	r.HandleFunc("/api/users", CheckJWT(CheckPermission(UsersWritePermission, HandleCreateUser))).Methods("POST")
+	r.HandleFunc("/api/users", CheckJWT(CheckPermission(UsersReadPermission, HandleListUsers))).Methods("GET")
+	r.HandleFunc("/api/users/import", CheckJWT(CheckPermission(UsersWritePermission, HandleImportUsers))).Methods("POST")
+	r.HandleFunc("/api/users/{userId}", CheckJWT(CheckPermission(UsersReadPermission, HandleReadUser))).Methods("GET")
+	r.HandleFunc("/api/users/{userId}", CheckJWT(CheckPermission(UsersWritePermission, HandleUpdateUser))).Methods("PUT")
+	r.HandleFunc("/api/users/{userId}", CheckJWT(CheckPermission(UsersWritePermission, HandleDeleteUser))).Methods("DELETE")
+	r.HandleFunc("/api/users/{userId}/restore", CheckJWT(CheckPermission(UsersWritePermission, HandleRestoreUser))).Methods("POST")
+	r.HandleFunc("/api/users/{userId}/purge", CheckJWT(CheckPermission(UsersPurgePermission, HandlePurgeUser))).Methods("DELETE")
+	r.HandleFunc("/api/users/{userId}/audit", CheckJWT(CheckPermission(AuditReadPermission, HandleListPatientAuditEntries))).Methods("GET")
+	r.HandleFunc("/api/users/{userId}/sessions", CheckJWT(CheckPermission(SessionsManagePermission, HandleListUserSessions))).Methods("GET")
+	r.HandleFunc("/api/users/{userId}/sessions", CheckJWT(CheckPermission(SessionsManagePermission, HandleRevokeUserSessions))).Methods("DELETE")
+	r.HandleFunc("/api/users/{userId}/sessions/{sessionId}", CheckJWT(CheckPermission(SessionsManagePermission, HandleRevokeUserSession))).Methods("DELETE")
+	r.HandleFunc("/api/users/{userId}/passwordreset", CheckJWT(CheckPermission(PasswordsResetPermission, HandleSendPasswordReset))).Methods("POST")
+
+	r.HandleFunc("/api/users/{userId}/profile", CheckJWT(CheckPermission(ProfilesReadPermission, CheckPatientAccess(HandleReadClinicalProfile)))).Methods("GET")
+	r.HandleFunc("/api/users/{userId}/profile", CheckJWT(CheckPermission(ProfilesWritePermission, CheckPatientAccess(HandleUpdateClinicalProfile)))).Methods("PUT")
+	r.HandleFunc("/api/users/{userId}/profile/measurements", CheckJWT(CheckPermission(ProfilesWritePermission, CheckPatientAccess(HandleCreateMeasurement)))).Methods("POST")
+
+	r.HandleFunc("/api/users/{userId}/careteam", CheckJWT(CheckPermission(CareTeamsReadPermission, CheckPatientAccess(HandleListCareTeam)))).Methods("GET")
+	r.HandleFunc("/api/users/{userId}/careteam/{memberId:[0-9]+}", CheckJWT(CheckPermission(CareTeamsManagePermission, CheckPatientAccess(HandleEndCareTeamMembership)))).Methods("DELETE")
+	r.HandleFunc("/api/users/{userId}/careteam/invitations", CheckJWT(CheckPermission(CareTeamsReadPermission, CheckPatientAccess(HandleListCareTeamInvitations)))).Methods("GET")
+	r.HandleFunc("/api/users/{userId}/careteam/invitations", CheckJWT(CheckPermission(CareTeamsManagePermission, CheckPatientAccess(HandleCreateCareTeamInvitation)))).Methods("POST")
+	r.HandleFunc("/api/users/{userId}/careteam/invitations/{invitationId}", CheckJWT(CheckPermission(CareTeamsManagePermission, CheckPatientAccess(HandleCancelCareTeamInvitation)))).Methods("DELETE")
+
+	r.HandleFunc("/api/invitations", CheckJWT(HandleListOwnInvitations)).Methods("GET")
+	r.HandleFunc("/api/invitations/{invitationId}/accept", CheckJWT(HandleAcceptInvitation)).Methods("POST")
+	r.HandleFunc("/api/invitations/{invitationId}/decline", CheckJWT(HandleDeclineInvitation)).Methods("POST")
+
+	r.HandleFunc("/api/users/{userId}/doses", CheckJWT(CheckPermission(DosesWritePermission, CheckPatientAccess(HandleCreateDose)))).Methods("POST")
+	r.HandleFunc("/api/users/{userId}/doses", CheckJWT(CheckPermission(DosesReadPermission, CheckPatientAccess(HandleListDoses)))).Methods("GET")
+	r.HandleFunc("/api/users/{userId}/doses/{doseId}", CheckJWT(CheckPermission(DosesReadPermission, CheckPatientAccess(HandleReadDose)))).Methods("GET")
+	r.HandleFunc("/api/users/{userId}/doses/{doseId}", CheckJWT(CheckPermission(DosesWritePermission, CheckPatientAccess(HandleUpdateDose)))).Methods("PUT")
+	r.HandleFunc("/api/users/{userId}/doses/{doseId}", CheckJWT(CheckPermission(DosesWritePermission, CheckPatientAccess(HandleDeleteDose)))).Methods("DELETE")
+
+	r.HandleFunc("/api/users/{userId}/interactions/check", CheckJWT(CheckPermission(DosesReadPermission, CheckPatientAccess(HandleCheckInteractions)))).Methods("POST")
+
+	r.HandleFunc("/api/users/{userId}/dosehistory", CheckJWT(CheckPermission(HistoryWritePermission, CheckPatientAccess(HandleCreateDoseHistoryEntry)))).Methods("POST")
+	r.HandleFunc("/api/users/{userId}/dosehistory", CheckJWT(CheckPermission(HistoryReadPermission, CheckPatientAccess(HandleListDoseHistoryEntries)))).Methods("GET")
+	r.HandleFunc("/api/users/{userId}/dosehistory/{doseHistoryEntryId}", CheckJWT(CheckPermission(HistoryReadPermission, CheckPatientAccess(HandleReadDoseHistoryEntry)))).Methods("GET")
+
+	r.HandleFunc("/api/users/{userId}/prnmedications", CheckJWTOrAPIKey(CheckPermission(PRNMedicationsReadPermission, CheckPatientAccess(HandleListPRNMedications)))).Methods("GET")
+	r.HandleFunc("/api/users/{userId}/prnmedications", CheckJWTOrAPIKey(CheckPermission(PRNMedicationsWritePermission, CheckPatientAccess(HandleCreatePRNMedication)))).Methods("POST")
+	r.HandleFunc("/api/users/{userId}/prnmedications/{prnMedicationId}", CheckJWTOrAPIKey(CheckPermission(PRNMedicationsReadPermission, CheckPatientAccess(HandleReadPRNMedication)))).Methods("GET")
+	r.HandleFunc("/api/users/{userId}/prnmedications/{prnMedicationId}", CheckJWTOrAPIKey(CheckPermission(PRNMedicationsWritePermission, CheckPatientAccess(HandleUpdatePRNMedication)))).Methods("PUT")
+	r.HandleFunc("/api/users/{userId}/prnmedications/{prnMedicationId}", CheckJWTOrAPIKey(CheckPermission(PRNMedicationsWritePermission, CheckPatientAccess(HandleDeletePRNMedication)))).Methods("DELETE")
+
+	r.HandleFunc("/api/users/{userId}/dosesummaries", CheckJWT(CheckPermission(SummariesReadPermission, CheckPatientAccess(HandleListDoseSummaries)))).Methods("GET")
+	r.HandleFunc("/api/users/{userId}/dosesummaries/{date}", CheckJWT(CheckPermission(SummariesReadPermission, CheckPatientAccess(HandleReadDoseSummary)))).Methods("GET")
+	r.HandleFunc("/api/users/{userId}/prnsummaries/{date}", CheckJWT(CheckPermission(SummariesReadPermission, CheckPatientAccess(HandleReadPRNSummary)))).Methods("GET")
+
+	r.HandleFunc("/api/users/{userId}/prnhistory", CheckJWT(CheckPermission(HistoryWritePermission, CheckPatientAccess(HandleCreatePRNHistoryEntry)))).Methods("POST")
+
+	r.HandleFunc("/api/dispensers", CheckJWT(CheckPermission(DispensersManagePermission, HandleCreateDispenser))).Methods("POST")
+	r.HandleFunc("/api/dispensers", CheckJWT(CheckPermission(DispensersManagePermission, HandleListDispensers))).Methods("GET")
+	r.HandleFunc("/api/dispensers/{dispenserId}", CheckJWT(CheckPermission(DispensersManagePermission, HandleReadDispenser))).Methods("GET")
+	r.HandleFunc("/api/dispensers/{dispenserId}", CheckJWT(CheckPermission(DispensersManagePermission, HandleDecommissionDispenser))).Methods("DELETE")
+	r.HandleFunc("/api/dispensers/{dispenserId}/credentials", CheckJWT(CheckPermission(DispensersManagePermission, HandleRotateDispenserCredentials))).Methods("POST")
+	r.HandleFunc("/api/dispensers/{dispenserId}/assignment", CheckJWT(CheckPermission(DispensersAssignPermission, HandleReadDispenserAssignment))).Methods("GET")
+	r.HandleFunc("/api/dispensers/{dispenserId}/assignment", CheckJWT(CheckPermission(DispensersAssignPermission, HandleAssignDispenser))).Methods("PUT")
+	r.HandleFunc("/api/dispensers/{dispenserId}/assignment", CheckJWT(CheckPermission(DispensersAssignPermission, HandleUnassignDispenser))).Methods("DELETE")
+	r.HandleFunc("/api/dispensers/{dispenserId}/inventory", CheckJWT(CheckPermission(InventoryReadPermission, HandleListDispenserInventory))).Methods("GET")
+	r.HandleFunc("/api/dispensers/{dispenserId}/inventory/refills", CheckJWT(CheckPermission(InventoryReadPermission, HandleListDispenserRefills))).Methods("GET")
+	r.HandleFunc("/api/dispensers/{dispenserId}/inventory/refills", CheckJWT(CheckPermission(InventoryWritePermission, HandleRefillDispenser))).Methods("POST")
 
 	r.HandleFunc("/api/dispatcher", dispatch.CreateDispatchHandler(dispatcher)).Methods("GET")
 
@@ -68,7 +153,7 @@ func main() {
 
 	// Start web server
 	log.Printf("Listening on %s:%s", config.Host.Host, config.Host.Port)
-	err := http.ListenAndServe(fmt.Sprintf("%s:%s", config.Host.Host, config.Host.Port), r)
+	err := http.ListenAndServe(fmt.Sprintf("%s:%s", config.Host.Host, config.Host.Port), WithRequestID(WithCORS(r)))
 
 	if err != nil {
 		utils.LogErrorMessageFatal(err.Error())
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"main/utils"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// readCurrentPatientSession reads the session of the current request, which must belong to a patient
+func readCurrentPatientSession(r *http.Request) (Session, error) {
+	session, err := readCurrentUserSession(r)
+	if err != nil {
+		return Session{}, err
+	}
+
+	if session.Role != PatientRole {
+		return Session{}, utils.ForbiddenErrorMessage("Only patients have doses and PRN medications")
+	}
+
+	return session, nil
+}
+
+// HandleReadMe returns the profile of the current user to the client
+func HandleReadMe(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := readCurrentUserSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read the user from the database and write to the client
+	user, err := ReadUser(session.UserID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, user)
+}
+
+// HandleUpdateMyContactDetails handles an update of the contact details of the current user
+func HandleUpdateMyContactDetails(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := readCurrentUserSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read the contact details from the request body
+	var contactDetails ContactDetails
+
+	err = utils.ReadJSONFromRequest(r, &contactDetails)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Update the contact details and write to the client
+	user, err := UpdateContactDetails(session.UserID, contactDetails, actor)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, user)
+}
+
+// HandleReadMyDoseStatuses returns the statuses of today's doses of the current patient to the client
+func HandleReadMyDoseStatuses(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := readCurrentPatientSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read the dose statuses from the database and write to the client
+	statuses, err := ReadDoseSummary(session.UserID, time.Now().Format(DateFormat))
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, statuses)
+}
+
+// HandleListMyDoseHistoryEntries returns the dose history of the current patient to the client
+func HandleListMyDoseHistoryEntries(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := readCurrentPatientSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read dose history entries from database
+	entries, err := ListDoseHistoryEntries(session.UserID, url.Values{
+		"dose":         r.URL.Query()["dose"],
+		"dispensedday": r.URL.Query()["dispensedday"],
+	})
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, entries)
+}
+
+// HandleListMyPRNEligibility returns whether the current patient may take each of their PRN medications now to the client
+func HandleListMyPRNEligibility(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := readCurrentPatientSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Determine the eligibility and write to the client
+	eligibilities, err := ListPRNEligibility(session.UserID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, eligibilities)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/gorilla/mux"
 	"net/http"
+	"net/url"
 	"strconv"
 	"main/utils"
 )
@@ -19,8 +20,15 @@ func HandleCreateMedication(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
 	// Create and return the medication
-	medication, err := CreateMedication(newMedication)
+	medication, err := CreateMedication(newMedication, actor)
 
 	if err != nil {
 		utils.WriteError(w, err)
@@ -30,10 +38,20 @@ func HandleCreateMedication(w http.ResponseWriter, r *http.Request) {
 	utils.WriteJSON(w, medication)
 }
 
-// HandleListMedications handles a read of all medications
+// HandleListMedications handles a read of all medications matching the search in the query parameters
 func HandleListMedications(w http.ResponseWriter, r *http.Request) {
-	// Read and return medications
-	medications, err := ListMedications()
+	// Read and return the medications matching the search
+	query := r.URL.Query()
+
+	medications, err := ListMedications(url.Values{
+		"q":            query["q"],
+		"ingredient":   query["ingredient"],
+		"atc":          query["atc"],
+		"dosageForm":   query["dosageForm"],
+		"route":        query["route"],
+		"manufacturer": query["manufacturer"],
+		"gtin":         query["gtin"],
+	})
 
 	if err != nil {
 		utils.WriteError(w, err)
@@ -84,8 +102,15 @@ func HandleUpdateMedication(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
 	// Update the medication and respond
-	medication, err := UpdateMedication(medicationID, updatedMedication)
+	medication, err := UpdateMedication(medicationID, updatedMedication, actor)
 
 	if err != nil {
 		utils.WriteError(w, err)
@@ -106,8 +131,15 @@ func HandleDeleteMedication(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
 	// Delete the medication and respond
-	err = DeleteMedication(medicationID)
+	err = DeleteMedication(medicationID, actor)
 
 	if err != nil {
 		utils.WriteError(w, err)
@@ -4,6 +4,10 @@ import (
 	"database/sql"
 	"fmt"
 	"main/utils"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 )
 
 type (
@@ -16,24 +20,113 @@ type (
 
 	// MedicationDetails contains all information on a medication
 	MedicationDetails struct {
-		ID          int    `json:"id"`
-		Title       string `json:"title"`
-		Description string `json:"description"`
+		ID           int                `json:"id"`
+		Title        string             `json:"title"`
+		Description  string             `json:"description"`
+		Ingredients  []ActiveIngredient `json:"ingredients"`
+		DosageForm   string             `json:"dosageForm"`
+		Route        string             `json:"route"`
+		ATCCode      string             `json:"atcCode"`
+		Manufacturer string             `json:"manufacturer"`
+		GTIN         string             `json:"gtin"`
 	}
 
-	// NewMedication contains all information on a to-be inserted medication
+	// ActiveIngredient contains an active ingredient of a medication and its strength per unit of the dosage form
+	ActiveIngredient struct {
+		Name     string  `json:"name"`
+		Strength float64 `json:"strength"`
+		Unit     string  `json:"unit"`
+	}
+
+	// NewMedication contains all information on a to-be inserted medication. An empty title is composed of the
+	// ingredients, their strengths and the dosage form
 	NewMedication struct {
-		Title       string `json:"title"`
-		Description string `json:"description"`
+		Title        string             `json:"title"`
+		Description  string             `json:"description"`
+		Ingredients  []ActiveIngredient `json:"ingredients"`
+		DosageForm   string             `json:"dosageForm"`
+		Route        string             `json:"route"`
+		ATCCode      string             `json:"atcCode"`
+		Manufacturer string             `json:"manufacturer"`
+		GTIN         string             `json:"gtin"`
 	}
 
 	// UpdatedMedication contains all information on a to-be updated medication
 	UpdatedMedication struct {
-		Title       string `json:"title"`
-		Description string `json:"description"`
+		Title        string             `json:"title"`
+		Description  string             `json:"description"`
+		Ingredients  []ActiveIngredient `json:"ingredients"`
+		DosageForm   string             `json:"dosageForm"`
+		Route        string             `json:"route"`
+		ATCCode      string             `json:"atcCode"`
+		Manufacturer string             `json:"manufacturer"`
+		GTIN         string             `json:"gtin"`
 	}
 )
 
+var (
+	medicationsSearchMapping SearchMapping
+
+	// Units in which the strength of an ingredient can be expressed
+	StrengthUnits = []string{"mg", "g", "mcg", "ng", "IU", "mmol", "ml", "mg/ml", "mcg/ml", "IU/ml", "mg/g", "%"}
+
+	// Dosage forms and routes of administration of medications
+	DosageForms = []string{"tablet", "capsule", "solution", "suspension", "syrup", "powder", "granules", "injection", "infusion",
+		"cream", "ointment", "gel", "patch", "inhaler", "spray", "drops", "suppository", "lozenge", "other"}
+	Routes = []string{"oral", "sublingual", "buccal", "rectal", "vaginal", "topical", "transdermal", "inhalation", "nasal",
+		"ophthalmic", "otic", "intravenous", "intramuscular", "subcutaneous", "other"}
+
+	// ATC codes of the fifth level, such as N02BE01
+	atcCodePattern = regexp.MustCompile(`^[A-Z][0-9]{2}[A-Z]{2}[0-9]{2}$`)
+	gtinPattern    = regexp.MustCompile(`^([0-9]{8}|[0-9]{12,14})$`)
+)
+
+const (
+	MaxIngredientNameLength = 255
+
+	// Names of all ingredients of the medication M, which contains searches can match
+	medicationIngredientNames = "(SELECT STRING_AGG(MI.Name, ' ') FROM MedicationIngredients MI WHERE MI.MedicationID = M.ID)"
+)
+
+func init() {
+	// Initialize field mappings
+	medicationsSearchMapping = NewMapping()
+	medicationsSearchMapping.DefineFieldMapping("q", FieldMapping{
+		SearchType: SearchTypeContains,
+		DBFields:   []string{"M.Title", "M.Description", "M.Manufacturer", medicationIngredientNames},
+	})
+
+	medicationsSearchMapping.DefineFieldMapping("ingredient", FieldMapping{
+		SearchType: SearchTypeContains,
+		DBFields:   []string{medicationIngredientNames},
+	})
+
+	medicationsSearchMapping.DefineFieldMapping("atc", FieldMapping{
+		SearchType: SearchTypePrefix,
+		DBField:    "M.ATCCode",
+	})
+
+	medicationsSearchMapping.DefineFieldMapping("dosageForm", FieldMapping{
+		SearchType: SearchTypeEqual,
+		DBField:    "M.DosageForm",
+	})
+
+	medicationsSearchMapping.DefineFieldMapping("route", FieldMapping{
+		SearchType: SearchTypeEqual,
+		DBField:    "M.Route",
+	})
+
+	medicationsSearchMapping.DefineFieldMapping("manufacturer", FieldMapping{
+		SearchType: SearchTypeContains,
+		DBFields:   []string{"M.Manufacturer"},
+	})
+
+	medicationsSearchMapping.DefineFieldMapping("gtin", FieldMapping{
+		SearchType: SearchTypeEqual,
+		DBField:    "M.GTIN",
+	})
+}
+
 // ToSummary transforms a MedicationDetails into its MedicationSummary counterpart
 func (md MedicationDetails) ToSummary() MedicationSummary {
 	return MedicationSummary{
@@ -43,62 +136,272 @@ func (md MedicationDetails) ToSummary() MedicationSummary {
 	}
 }
 
+// String returns the ingredient with its strength, such as "Paracetamol 500 mg"
+func (ai ActiveIngredient) String() string {
+	return fmt.Sprintf("%s %s %s", ai.Name, strconv.FormatFloat(ai.Strength, 'f', -1, 64), ai.Unit)
+}
+
+// isValidGTIN returns whether a GTIN has a valid length and check digit
+func isValidGTIN(gtin string) bool {
+	if !gtinPattern.MatchString(gtin) {
+		return false
+	}
+
+	// The digits before the check digit are weighted 3 and 1 alternately, starting with 3 from the right
+	sum := 0
+	for i := len(gtin) - 2; i >= 0; i-- {
+		digit := int(gtin[i] - '0')
+		if (len(gtin)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	return (10-sum%10)%10 == int(gtin[len(gtin)-1]-'0')
+}
+
+// validateMedication normalizes and validates a new or updated medication
+func validateMedication(medication *NewMedication) error {
+	medication.Title = strings.TrimSpace(medication.Title)
+	medication.ATCCode = strings.ToUpper(strings.TrimSpace(medication.ATCCode))
+	medication.GTIN = strings.TrimSpace(medication.GTIN)
+	medication.Manufacturer = strings.TrimSpace(medication.Manufacturer)
+
+	// Check the ingredients, which are optional so medications can still be created with only a title and description
+	seen := make(map[string]bool)
+	for i := range medication.Ingredients {
+		ingredient := &medication.Ingredients[i]
+		ingredient.Name = strings.TrimSpace(ingredient.Name)
+
+		if len(ingredient.Name) == 0 || len(ingredient.Name) > MaxIngredientNameLength {
+			return utils.BadRequestErrorMessage(fmt.Sprintf("The name of ingredient %d must be between 1 and %d characters", i+1, MaxIngredientNameLength))
+		}
+		if seen[strings.ToLower(ingredient.Name)] {
+			return utils.BadRequestErrorMessage(fmt.Sprintf("Ingredient '%s' is listed more than once", ingredient.Name))
+		}
+		if ingredient.Strength <= 0 {
+			return utils.BadRequestErrorMessage(fmt.Sprintf("The strength of ingredient '%s' must be positive", ingredient.Name))
+		}
+		if !containsString(StrengthUnits, ingredient.Unit) {
+			return utils.BadRequestErrorMessage(fmt.Sprintf("Unit '%s' of ingredient '%s' must be one of %s", ingredient.Unit, ingredient.Name, strings.Join(StrengthUnits, ", ")))
+		}
+
+		seen[strings.ToLower(ingredient.Name)] = true
+	}
+
+	// Check the dosage form and route, which are optional as well
+	if len(medication.DosageForm) > 0 && !containsString(DosageForms, medication.DosageForm) {
+		return utils.BadRequestErrorMessage(fmt.Sprintf("Dosage form '%s' must be one of %s", medication.DosageForm, strings.Join(DosageForms, ", ")))
+	}
+	if len(medication.Route) > 0 && !containsString(Routes, medication.Route) {
+		return utils.BadRequestErrorMessage(fmt.Sprintf("Route '%s' must be one of %s", medication.Route, strings.Join(Routes, ", ")))
+	}
+
+	// Check the codes, which are optional
+	if len(medication.ATCCode) > 0 && !atcCodePattern.MatchString(medication.ATCCode) {
+		return utils.BadRequestErrorMessage(fmt.Sprintf("'%s' isn't a valid ATC code, expected a code such as N02BE01", medication.ATCCode))
+	}
+	if len(medication.GTIN) > 0 && !isValidGTIN(medication.GTIN) {
+		return utils.BadRequestErrorMessage(fmt.Sprintf("'%s' isn't a valid GTIN, expected 8, 12, 13 or 14 digits with a valid check digit", medication.GTIN))
+	}
+
+	// Compose a title if none was given, which requires the ingredients
+	if len(medication.Title) == 0 {
+		if len(medication.Ingredients) == 0 {
+			return utils.BadRequestErrorMessage("A medication without active ingredients must have a title")
+		}
+
+		ingredients := []string{}
+		for _, ingredient := range medication.Ingredients {
+			ingredients = append(ingredients, ingredient.String())
+		}
+
+		medication.Title = strings.TrimSpace(fmt.Sprintf("%s %s", strings.Join(ingredients, " / "), medication.DosageForm))
+	}
+
+	return nil
+}
+
+// checkGTINIsUnique returns an error if another medication than the one with the given ID has the GTIN
+func checkGTINIsUnique(gtin string, medicationID int) error {
+	if len(gtin) == 0 {
+		return nil
+	}
+
+	var existingID int
+
+	err := db.QueryRow(`SELECT ID FROM Medications WHERE GTIN = $1 AND ID <> $2`, gtin, medicationID).Scan(&existingID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	return utils.BadRequestErrorMessage(fmt.Sprintf("GTIN %s already belongs to medication %d", gtin, existingID))
+}
+
+// nullableString returns nil for an empty string, so it is stored as NULL
+func nullableString(s string) interface{} {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return s
+}
+
+// insertMedicationIngredients inserts the ingredients of a medication in a transaction
+func insertMedicationIngredients(tx *sql.Tx, medicationID int, ingredients []ActiveIngredient) error {
+	for i, ingredient := range ingredients {
+		_, err := tx.Exec(`INSERT INTO MedicationIngredients (MedicationID, Position, Name, Strength, Unit)
+		VALUES ($1, $2, $3, $4, $5)`, medicationID, i, ingredient.Name, ingredient.Strength, ingredient.Unit)
+
+		if err != nil {
+			return utils.InternalServerError(err)
+		}
+	}
+
+	return nil
+}
+
 // CreateMedication creates a new medication
-func CreateMedication(newMedication NewMedication) (MedicationDetails, error) {
+func CreateMedication(newMedication NewMedication, actor AuditActor) (MedicationDetails, error) {
+	err := validateMedication(&newMedication)
+	if err != nil {
+		return MedicationDetails{}, err
+	}
+
+	err = checkGTINIsUnique(newMedication.GTIN, 0)
+	if err != nil {
+		return MedicationDetails{}, err
+	}
+
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return MedicationDetails{}, utils.InternalServerError(err)
+	}
+
 	// Insert the medication into the database
 	var medicationID int
-	err := db.QueryRow(`INSERT INTO Medications (Title, Description)
-  VALUES ($1, $2) RETURNING id`, newMedication.Title, newMedication.Description).Scan(&medicationID)
+	err = tx.QueryRow(`INSERT INTO Medications (Title, Description, DosageForm, Route, ATCCode, Manufacturer, GTIN)
+  VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`, newMedication.Title, newMedication.Description, newMedication.DosageForm,
+		newMedication.Route, newMedication.ATCCode, newMedication.Manufacturer, nullableString(newMedication.GTIN)).Scan(&medicationID)
 
 	if err != nil {
+		utils.RollbackOrLog(tx)
 		return MedicationDetails{}, utils.InternalServerError(err)
 	}
 
-	// Notify the dispatcher a new entry has been inserted and return
-	medication, err := ReadMedication(int(medicationID))
+	err = insertMedicationIngredients(tx, medicationID, newMedication.Ingredients)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return MedicationDetails{}, err
+	}
+
+	// Audit the created medication
+	medication, err := readMedication(tx, medicationID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return MedicationDetails{}, err
+	}
+
+	err = RecordAudit(tx, actor, AuditActionCreate, AuditEntityMedication, medicationID, 0, nil, medication)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return MedicationDetails{}, err
+	}
 
+	// Commit the transaction
+	err = tx.Commit()
 	if err != nil {
-		return medication, err
+		utils.RollbackOrLog(tx)
+		return MedicationDetails{}, utils.InternalServerError(err)
 	}
 
+	// Notify the dispatcher a new entry has been inserted and return
 	medicationsSubject.EntityAdded(int(medicationID), medication)
 
 	return medication, err
 }
 
-// ListMedications returns a list of all medications
-func ListMedications() ([]MedicationSummary, error) {
+// ListMedications returns a list of all medications matching a search
+func ListMedications(search url.Values) ([]MedicationDetails, error) {
 	// Read medications from the database
-	rows, err := db.Query(`SELECT ID, Title, Description FROM Medications`)
+	query, queryParams := medicationsSearchMapping.CreateQuery(`SELECT M.ID, M.Title, M.Description, M.DosageForm, M.Route,
+		M.ATCCode, M.Manufacturer, COALESCE(M.GTIN, '')
+	FROM Medications M
+	WHERE %MAPPING_CONDITIONS%
+	ORDER BY M.Title, M.ID`, search)
+
+	rows, err := db.Query(query, queryParams...)
 
 	if err != nil {
-		return []MedicationSummary{}, utils.InternalServerError(err)
+		return []MedicationDetails{}, utils.InternalServerError(err)
 	}
+	defer rows.Close()
 
 	// Iterate over all rows and store in slice
-	medications := []MedicationSummary{}
-	var medication MedicationSummary
+	medications := []MedicationDetails{}
+	indices := make(map[int]int)
+	var medication MedicationDetails
 
 	for rows.Next() {
-		err = rows.Scan(&medication.ID, &medication.Title, &medication.Description)
+		err = rows.Scan(&medication.ID, &medication.Title, &medication.Description, &medication.DosageForm, &medication.Route,
+			&medication.ATCCode, &medication.Manufacturer, &medication.GTIN)
 		if err != nil {
-			return []MedicationSummary{}, utils.InternalServerError(err)
+			return []MedicationDetails{}, utils.InternalServerError(err)
 		}
 
+		medication.Ingredients = []ActiveIngredient{}
+		indices[medication.ID] = len(medications)
 		medications = append(medications, medication)
 	}
 
+	// Read the ingredients of the same medications
+	query, queryParams = medicationsSearchMapping.CreateQuery(`SELECT MI.MedicationID, MI.Name, MI.Strength, MI.Unit
+	FROM MedicationIngredients MI
+	WHERE MI.MedicationID IN (SELECT M.ID FROM Medications M WHERE %MAPPING_CONDITIONS%)
+	ORDER BY MI.MedicationID, MI.Position`, search)
+
+	ingredientRows, err := db.Query(query, queryParams...)
+
+	if err != nil {
+		return []MedicationDetails{}, utils.InternalServerError(err)
+	}
+	defer ingredientRows.Close()
+
+	var medicationID int
+	var ingredient ActiveIngredient
+
+	for ingredientRows.Next() {
+		err = ingredientRows.Scan(&medicationID, &ingredient.Name, &ingredient.Strength, &ingredient.Unit)
+		if err != nil {
+			return []MedicationDetails{}, utils.InternalServerError(err)
+		}
+
+		if i, ok := indices[medicationID]; ok {
+			medications[i].Ingredients = append(medications[i].Ingredients, ingredient)
+		}
+	}
+
 	// Return list
 	return medications, nil
 }
 
 // ReadMedication returns a single medication
 func ReadMedication(id int) (MedicationDetails, error) {
-	// Read medication from the database and return
+	return readMedication(db, id)
+}
+
+// readMedication reads a single medication inside or outside a transaction
+func readMedication(q querier, id int) (MedicationDetails, error) {
+	// Read medication from the database
 	var medication MedicationDetails
 
-	err := db.QueryRow(`SELECT ID, Title, Description FROM Medications
-  WHERE ID = $1`, id).Scan(&medication.ID, &medication.Title, &medication.Description)
+	err := q.QueryRow(`SELECT ID, Title, Description, DosageForm, Route, ATCCode, Manufacturer, COALESCE(GTIN, '') FROM Medications
+  WHERE ID = $1`, id).Scan(&medication.ID, &medication.Title, &medication.Description, &medication.DosageForm, &medication.Route,
+		&medication.ATCCode, &medication.Manufacturer, &medication.GTIN)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -107,40 +410,160 @@ func ReadMedication(id int) (MedicationDetails, error) {
 		return MedicationDetails{}, utils.InternalServerError(err)
 	}
 
-	return medication, err
+	// Read the ingredients and return
+	rows, err := q.Query(`SELECT Name, Strength, Unit FROM MedicationIngredients
+	WHERE MedicationID = $1
+	ORDER BY Position`, id)
+
+	if err != nil {
+		return MedicationDetails{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	medication.Ingredients = []ActiveIngredient{}
+	var ingredient ActiveIngredient
+
+	for rows.Next() {
+		err = rows.Scan(&ingredient.Name, &ingredient.Strength, &ingredient.Unit)
+		if err != nil {
+			return MedicationDetails{}, utils.InternalServerError(err)
+		}
+
+		medication.Ingredients = append(medication.Ingredients, ingredient)
+	}
+
+	return medication, nil
 }
 
 // UpdateMedication updates a medication with a given ID
-func UpdateMedication(id int, updatedMedication UpdatedMedication) (MedicationDetails, error) {
+func UpdateMedication(id int, updatedMedication UpdatedMedication, actor AuditActor) (MedicationDetails, error) {
+	validated := NewMedication(updatedMedication)
+
+	err := validateMedication(&validated)
+	if err != nil {
+		return MedicationDetails{}, err
+	}
+
+	err = checkGTINIsUnique(validated.GTIN, id)
+	if err != nil {
+		return MedicationDetails{}, err
+	}
+
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return MedicationDetails{}, utils.InternalServerError(err)
+	}
+
+	// Lock the medication and get its current state for the audit log
+	_, err = tx.Exec(`SELECT ID FROM Medications WHERE ID = $1 FOR UPDATE`, id)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return MedicationDetails{}, utils.InternalServerError(err)
+	}
+
+	oldMedication, err := readMedication(tx, id)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return MedicationDetails{}, err
+	}
+
 	// Update the medication in the database
-	_, err := db.Exec(`UPDATE Medications
+	_, err = tx.Exec(`UPDATE Medications
 	SET
 		Title = $1,
-		Description = $2
-	WHERE ID = $3`, updatedMedication.Title, updatedMedication.Description, id)
+		Description = $2,
+		DosageForm = $3,
+		Route = $4,
+		ATCCode = $5,
+		Manufacturer = $6,
+		GTIN = $7
+	WHERE ID = $8`, validated.Title, validated.Description, validated.DosageForm, validated.Route, validated.ATCCode,
+		validated.Manufacturer, nullableString(validated.GTIN), id)
 
 	if err != nil {
+		utils.RollbackOrLog(tx)
 		return MedicationDetails{}, utils.InternalServerError(err)
 	}
 
-	// Notify the dispatcher and return
-	medication, err := ReadMedication(id)
+	// Replace the ingredients
+	_, err = tx.Exec(`DELETE FROM MedicationIngredients WHERE MedicationID = $1`, id)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return MedicationDetails{}, utils.InternalServerError(err)
+	}
 
+	err = insertMedicationIngredients(tx, id, validated.Ingredients)
 	if err != nil {
-		return medication, err
+		utils.RollbackOrLog(tx)
+		return MedicationDetails{}, err
 	}
 
+	// Audit the change
+	medication, err := readMedication(tx, id)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return MedicationDetails{}, err
+	}
+
+	err = RecordAudit(tx, actor, AuditActionUpdate, AuditEntityMedication, id, 0, oldMedication, medication)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return MedicationDetails{}, err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return MedicationDetails{}, utils.InternalServerError(err)
+	}
+
+	// Notify the dispatcher and return
 	medicationsSubject.EntityUpdated(id, medication.ToSummary())
 
 	return medication, err
 }
 
 // DeleteMedication deletes a medication with a given ID
-func DeleteMedication(id int) error {
+func DeleteMedication(id int, actor AuditActor) error {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	// Lock the medication and get its current state for the audit log
+	_, err = tx.Exec(`SELECT ID FROM Medications WHERE ID = $1 FOR UPDATE`, id)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	medication, err := readMedication(tx, id)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
 	// Delete the entity in the database
-	_, err := db.Exec(`DELETE FROM Medications WHERE ID = $1`, id)
+	_, err = tx.Exec(`DELETE FROM Medications WHERE ID = $1`, id)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	err = RecordAudit(tx, actor, AuditActionDelete, AuditEntityMedication, id, 0, medication, nil)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
 
+	// Commit the transaction
+	err = tx.Commit()
 	if err != nil {
+		utils.RollbackOrLog(tx)
 		return utils.InternalServerError(err)
 	}
 
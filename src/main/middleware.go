@@ -2,30 +2,122 @@ package main
 
 import (
 	"fmt"
-	"github.com/dgrijalva/jwt-go"
 	"net/http"
-	"strings"
+	"regexp"
 	"main/utils"
 )
 
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	// Headers and methods cross-origin web applications may use, and the time browsers may cache a preflight response
+	corsAllowedHeaders = "Content-Type, X-API-Key, X-JWT, X-Request-ID"
+	corsAllowedMethods = "GET, POST, PUT, DELETE"
+	corsExposedHeaders = "X-Request-ID, X-Total-Count, X-Next-Cursor"
+	corsMaxAge         = "600"
+)
+
+var (
+	requestIDRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+)
+
+// WithRequestID makes sure every request has an ID, which is also returned in the response headers
+func WithRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Use the ID set by a proxy or client if it is well-formed, otherwise generate one
+		requestID := r.Header.Get(RequestIDHeader)
+
+		if !requestIDRegexp.MatchString(requestID) {
+			var err error
+			requestID, err = utils.RandomToken(16)
+			if err != nil {
+				utils.WriteError(w, utils.InternalServerError(err))
+				return
+			}
+
+			r.Header.Set(RequestIDHeader, requestID)
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WithCORS adds CORS headers to responses to web applications with an allowed origin, and answers preflight requests
+func WithCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if len(origin) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+		allowed := originPolicy.IsAllowed(origin)
+
+		// Answer preflight requests without passing them to the router
+		if r.Method == "OPTIONS" && len(r.Header.Get("Access-Control-Request-Method")) > 0 {
+			if !allowed {
+				utils.WriteError(w, utils.ForbiddenErrorMessage(fmt.Sprintf("Origin %s is not allowed", origin)))
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", corsAllowedMethods)
+			w.Header().Set("Access-Control-Allow-Headers", corsAllowedHeaders)
+			w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if allowed {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Expose-Headers", corsExposedHeaders)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequestID returns the ID of a request
+func RequestID(r *http.Request) string {
+	return r.Header.Get(RequestIDHeader)
+}
+
 // CheckJWT checks whether a valid JSON web token is present in the request headers
 func CheckJWT(next func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Check whether the token string is present
-		tokStr := r.Header.Get("X-JWT")
-		if len(tokStr) == 0 {
-			utils.WriteError(w, utils.UnauthorizedErrorMessage("No X-JWT header was present"))
+		// Check whether a valid, unrevoked token is present
+		claims, err := parseJWT(r)
+		if err != nil {
+			utils.WriteError(w, err)
+			return
+		}
+
+		// Later checks read the claims from the request instead of validating the token again
+		next(w, withClaims(r, claims))
+	}
+}
+
+// CheckJWTOrAPIKey checks whether a valid JSON web token or API key is present in the request headers
+func CheckJWTOrAPIKey(next func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Requests with a token are checked like any other request
+		if len(r.Header.Get("X-JWT")) > 0 || len(r.Header.Get(APIKeyHeader)) == 0 {
+			CheckJWT(next)(w, r)
 			return
 		}
 
-		// Check whether the token string is valid
-		_, err := jwt.Parse(tokStr, JWTKeyFunc)
+		// Check the API key and record its usage
+		session, err := readAPIKeySession(r)
 		if err != nil {
-			if err == jwt.ErrSignatureInvalid {
-				utils.WriteError(w, utils.UnauthorizedError(err))
-			} else {
-				utils.WriteError(w, utils.InternalServerError(err))
-			}
+			utils.WriteError(w, err)
+			return
+		}
+
+		err = recordAPIKeyUsage(session.UserID, utils.ClientIP(r, config.Host.TrustProxyHeaders))
+		if err != nil {
+			utils.WriteError(w, err)
 			return
 		}
 
@@ -33,22 +125,32 @@ func CheckJWT(next func(http.ResponseWriter, *http.Request)) func(http.ResponseW
 	}
 }
 
-// CheckRole checks whether the current user has the appropriate role to acces the resource
-func CheckRole(roles string, next func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+// CheckPermission checks whether the role of the current user has been granted the permission required to access the resource
+func CheckPermission(permission string, next func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Read token from request
 		session, err := ReadJWTSession(r)
 		if err != nil {
 			utils.WriteError(w, err)
+			return
+		}
+
+		allowed, err := SessionHasPermission(session, permission)
+		if err != nil {
+			utils.WriteError(w, err)
+			return
 		}
 
-		for _, role := range strings.Split(roles, ",") {
-			if session.Role == role {
-				next(w, r)
+		if !allowed {
+			if session.ActorType == APIKeyActor {
+				utils.WriteError(w, utils.ForbiddenErrorMessage(fmt.Sprintf("Your API key lacks the %s scope required to access %s %s.", permission, r.Method, r.URL.String())))
 				return
 			}
+
+			utils.WriteError(w, utils.ForbiddenErrorMessage(fmt.Sprintf("Your role (%s) lacks the %s permission required to access %s %s.", session.Role, permission, r.Method, r.URL.String())))
+			return
 		}
 
-		utils.WriteError(w, utils.UnauthorizedErrorMessage(fmt.Sprintf("Your role (%s) is not authorized to access %s %s.", session.Role, r.Method, r.URL.String())))
+		next(w, r)
 	}
 }
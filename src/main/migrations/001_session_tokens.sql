@@ -0,0 +1,18 @@
+-- Refresh tokens for users and dispensers, only the SHA-256 hash of a token is stored
+CREATE TABLE RefreshTokens (
+  ID          SERIAL PRIMARY KEY,
+  TokenHash   VARCHAR(64) NOT NULL UNIQUE,
+  SubjectType VARCHAR(16) NOT NULL,
+  SubjectID   INTEGER     NOT NULL,
+  ExpiresOn   TIMESTAMPTZ NOT NULL,
+  Revoked     BOOLEAN     NOT NULL DEFAULT FALSE,
+  CreatedOn   TIMESTAMP   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX RefreshTokens_Subject ON RefreshTokens (SubjectType, SubjectID);
+
+-- Revocation list of access tokens that have not expired yet
+CREATE TABLE RevokedTokens (
+  TokenID   VARCHAR(64) PRIMARY KEY,
+  ExpiresOn TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,6 @@
+-- Binds a dispenser to the patient it dispenses medication for
+CREATE TABLE DispenserAssignments (
+  DispenserID INTEGER   PRIMARY KEY REFERENCES Dispensers (ID) ON DELETE CASCADE,
+  PatientID   INTEGER   NOT NULL REFERENCES Users (ID),
+  AssignedOn  TIMESTAMP NOT NULL DEFAULT NOW()
+);
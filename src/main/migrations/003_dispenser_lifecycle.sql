@@ -0,0 +1,6 @@
+-- Provisioning and lifecycle information of dispensers
+ALTER TABLE Dispensers
+  ADD COLUMN Label            VARCHAR(255) NOT NULL DEFAULT '',
+  ADD COLUMN RegisteredOn     TIMESTAMP    NOT NULL DEFAULT NOW(),
+  ADD COLUMN LastSeenOn       TIMESTAMP    NULL,
+  ADD COLUMN DecommissionedOn TIMESTAMP    NULL;
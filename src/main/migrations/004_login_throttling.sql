@@ -0,0 +1,19 @@
+-- Failed login counters per account or IP address
+CREATE TABLE LoginThrottles (
+  ThrottleKey   VARCHAR(255) PRIMARY KEY,
+  Failures      INTEGER      NOT NULL DEFAULT 0,
+  LastFailureOn TIMESTAMP    NOT NULL DEFAULT NOW(),
+  LockedUntil   TIMESTAMPTZ  NULL
+);
+
+-- Lockout events, which can be lifted by an admin
+CREATE TABLE Lockouts (
+  ID          SERIAL PRIMARY KEY,
+  ThrottleKey VARCHAR(255) NOT NULL,
+  IPAddress   VARCHAR(64)  NOT NULL,
+  Failures    INTEGER      NOT NULL,
+  LockedUntil TIMESTAMPTZ  NOT NULL,
+  CreatedOn   TIMESTAMP    NOT NULL DEFAULT NOW(),
+  UnlockedOn  TIMESTAMP    NULL,
+  UnlockedBy  INTEGER      NULL REFERENCES Users (ID)
+);
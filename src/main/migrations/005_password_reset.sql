@@ -0,0 +1,8 @@
+-- Single-use password reset tokens, only the SHA-256 hash of a token is stored
+CREATE TABLE PasswordResetTokens (
+  TokenHash VARCHAR(64) PRIMARY KEY,
+  UserID    INTEGER     NOT NULL REFERENCES Users (ID) ON DELETE CASCADE,
+  ExpiresOn TIMESTAMPTZ NOT NULL,
+  UsedOn    TIMESTAMP   NULL,
+  CreatedOn TIMESTAMP   NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,17 @@
+-- TOTP two-factor authentication settings of users
+CREATE TABLE UserTwoFactor (
+  UserID   INTEGER     PRIMARY KEY REFERENCES Users (ID) ON DELETE CASCADE,
+  Secret   VARCHAR(64) NOT NULL,
+  Enabled  BOOLEAN     NOT NULL DEFAULT FALSE,
+  LastStep BIGINT      NOT NULL DEFAULT 0
+);
+
+-- Single-use recovery codes, only the SHA-256 hash of a code is stored
+CREATE TABLE TwoFactorRecoveryCodes (
+  ID       SERIAL PRIMARY KEY,
+  UserID   INTEGER     NOT NULL REFERENCES Users (ID) ON DELETE CASCADE,
+  CodeHash VARCHAR(64) NOT NULL,
+  UsedOn   TIMESTAMP   NULL
+);
+
+CREATE INDEX TwoFactorRecoveryCodes_User ON TwoFactorRecoveryCodes (UserID);
@@ -0,0 +1,66 @@
+-- Roles users can have, built-in roles can't be deleted
+CREATE TABLE Roles (
+  Name        VARCHAR(64) PRIMARY KEY,
+  Description TEXT        NOT NULL DEFAULT '',
+  BuiltIn     BOOLEAN     NOT NULL DEFAULT FALSE
+);
+
+-- Permissions granted to each role
+CREATE TABLE RolePermissions (
+  Role       VARCHAR(64) NOT NULL REFERENCES Roles (Name) ON UPDATE CASCADE ON DELETE CASCADE,
+  Permission VARCHAR(64) NOT NULL,
+  PRIMARY KEY (Role, Permission)
+);
+
+-- Migrate the existing roles with the permissions they had through the route role lists
+INSERT INTO Roles (Name, Description, BuiltIn) VALUES
+  ('admin', 'Administrator', TRUE),
+  ('doctor', 'Doctor', TRUE),
+  ('pharmacist', 'Pharmacist', TRUE),
+  ('patient', 'Patient', TRUE),
+  ('dispenser', 'Medication dispenser', TRUE);
+
+INSERT INTO RolePermissions (Role, Permission) VALUES
+  ('admin', 'users:read'),
+  ('admin', 'users:write'),
+  ('admin', 'passwords:reset'),
+  ('admin', 'medications:read'),
+  ('admin', 'medications:write'),
+  ('admin', 'doses:read'),
+  ('admin', 'doses:write'),
+  ('admin', 'history:read'),
+  ('admin', 'prnmedications:read'),
+  ('admin', 'prnmedications:write'),
+  ('admin', 'summaries:read'),
+  ('admin', 'patients:all'),
+  ('admin', 'dispensers:manage'),
+  ('admin', 'dispensers:assign'),
+  ('admin', 'lockouts:manage'),
+  ('admin', 'roles:manage'),
+
+  ('doctor', 'users:read'),
+  ('doctor', 'users:write'),
+  ('doctor', 'medications:read'),
+  ('doctor', 'medications:write'),
+  ('doctor', 'doses:read'),
+  ('doctor', 'doses:write'),
+  ('doctor', 'history:read'),
+  ('doctor', 'prnmedications:read'),
+  ('doctor', 'prnmedications:write'),
+  ('doctor', 'summaries:read'),
+
+  ('pharmacist', 'medications:read'),
+  ('pharmacist', 'medications:write'),
+  ('pharmacist', 'doses:read'),
+  ('pharmacist', 'prnmedications:read'),
+  ('pharmacist', 'dispensers:assign'),
+
+  ('patient', 'doses:read'),
+  ('patient', 'prnmedications:read'),
+
+  ('dispenser', 'doses:read'),
+  ('dispenser', 'prnmedications:read'),
+  ('dispenser', 'history:write');
+
+-- Users can only have existing roles
+ALTER TABLE Users ADD CONSTRAINT FK_Users_Role FOREIGN KEY (Role) REFERENCES Roles (Name) ON UPDATE CASCADE;
@@ -0,0 +1,35 @@
+-- Append-only log of changes to clinical data
+CREATE TABLE AuditLog (
+  ID         BIGSERIAL PRIMARY KEY,
+  ActorType  VARCHAR(32)  NOT NULL,
+  ActorID    INTEGER      NOT NULL,
+  ActorName  VARCHAR(255) NOT NULL,
+  Action     VARCHAR(32)  NOT NULL,
+  EntityType VARCHAR(64)  NOT NULL,
+  EntityID   INTEGER      NOT NULL,
+  PatientID  INTEGER      NULL,
+  Before     JSONB        NULL,
+  After      JSONB        NULL,
+  RequestID  VARCHAR(64)  NOT NULL,
+  CreatedOn  TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IX_AuditLog_PatientID ON AuditLog (PatientID, ID);
+CREATE INDEX IX_AuditLog_Actor ON AuditLog (ActorType, ActorID, ID);
+
+-- Entries can't be changed or removed once written
+CREATE FUNCTION PreventAuditLogChanges() RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'The audit log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER TR_AuditLog_AppendOnly
+  BEFORE UPDATE OR DELETE ON AuditLog
+  FOR EACH ROW EXECUTE PROCEDURE PreventAuditLogChanges();
+
+CREATE TRIGGER TR_AuditLog_NoTruncate
+  BEFORE TRUNCATE ON AuditLog
+  FOR EACH STATEMENT EXECUTE PROCEDURE PreventAuditLogChanges();
+
+INSERT INTO RolePermissions (Role, Permission) VALUES ('admin', 'audit:read');
@@ -0,0 +1,25 @@
+-- API keys for third-party integrations, only the SHA-256 hash of a key is stored
+CREATE TABLE APIKeys (
+  ID         SERIAL PRIMARY KEY,
+  Name       VARCHAR(255) NOT NULL,
+  KeyHash    VARCHAR(64)  NOT NULL UNIQUE,
+  Prefix     VARCHAR(16)  NOT NULL,
+  Scopes     TEXT         NOT NULL,
+  CreatedBy  INTEGER      NULL REFERENCES Users (ID) ON DELETE SET NULL,
+  CreatedOn  TIMESTAMP    NOT NULL DEFAULT NOW(),
+  ExpiresOn  TIMESTAMPTZ  NOT NULL,
+  RevokedOn  TIMESTAMP    NULL,
+  LastUsedOn TIMESTAMP    NULL,
+  LastUsedIP VARCHAR(64)  NULL,
+  UsageCount INTEGER      NOT NULL DEFAULT 0
+);
+
+-- Number of requests made with an API key per day
+CREATE TABLE APIKeyUsage (
+  APIKeyID INTEGER NOT NULL REFERENCES APIKeys (ID) ON DELETE CASCADE,
+  Day      DATE    NOT NULL,
+  Requests INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (APIKeyID, Day)
+);
+
+INSERT INTO RolePermissions (Role, Permission) VALUES ('admin', 'apikeys:manage');
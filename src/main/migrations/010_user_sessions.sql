@@ -0,0 +1,17 @@
+-- Sessions of users, started by logging in and continued by refreshing the access token
+CREATE TABLE UserSessions (
+  ID         SERIAL PRIMARY KEY,
+  UserID     INTEGER      NOT NULL REFERENCES Users (ID) ON DELETE CASCADE,
+  Device     VARCHAR(255) NOT NULL,
+  IPAddress  VARCHAR(64)  NOT NULL,
+  CreatedOn  TIMESTAMP    NOT NULL DEFAULT NOW(),
+  LastUsedOn TIMESTAMP    NOT NULL DEFAULT NOW(),
+  RevokedOn  TIMESTAMP    NULL
+);
+
+CREATE INDEX UserSessions_UserID ON UserSessions (UserID);
+
+-- Refresh tokens of users belong to a session, and are revoked along with it
+ALTER TABLE RefreshTokens ADD COLUMN SessionID INTEGER NULL REFERENCES UserSessions (ID) ON DELETE CASCADE;
+
+INSERT INTO RolePermissions (Role, Permission) VALUES ('admin', 'sessions:manage');
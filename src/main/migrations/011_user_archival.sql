@@ -0,0 +1,7 @@
+-- Users are archived instead of deleted, so their medical history is kept
+ALTER TABLE Users ADD COLUMN ArchivedOn TIMESTAMP NULL;
+ALTER TABLE Users ADD COLUMN ArchivedBy INTEGER   NULL REFERENCES Users (ID) ON DELETE SET NULL;
+
+CREATE INDEX Users_ArchivedOn ON Users (ArchivedOn);
+
+INSERT INTO RolePermissions (Role, Permission) VALUES ('admin', 'users:purge');
@@ -0,0 +1,47 @@
+-- Clinical profile of a patient, of which the allergies, conditions and measurements are stored separately
+CREATE TABLE ClinicalProfiles (
+  PatientID       INTEGER     PRIMARY KEY REFERENCES Users (ID) ON DELETE CASCADE,
+  RenalImpairment VARCHAR(16) NOT NULL DEFAULT 'unknown',
+  Dialysis        BOOLEAN     NOT NULL DEFAULT FALSE,
+  UpdatedOn       TIMESTAMP   NOT NULL DEFAULT NOW(),
+  UpdatedBy       INTEGER     NULL REFERENCES Users (ID) ON DELETE SET NULL
+);
+
+CREATE TABLE PatientAllergies (
+  ID        SERIAL PRIMARY KEY,
+  PatientID INTEGER      NOT NULL REFERENCES Users (ID) ON DELETE CASCADE,
+  Substance VARCHAR(255) NOT NULL,
+  Reaction  TEXT         NOT NULL DEFAULT '',
+  Severity  VARCHAR(16)  NOT NULL
+);
+
+CREATE INDEX PatientAllergies_PatientID ON PatientAllergies (PatientID);
+
+CREATE TABLE PatientConditions (
+  ID        SERIAL PRIMARY KEY,
+  PatientID INTEGER      NOT NULL REFERENCES Users (ID) ON DELETE CASCADE,
+  Name      VARCHAR(255) NOT NULL,
+  Since     DATE         NULL,
+  Notes     TEXT         NOT NULL DEFAULT ''
+);
+
+CREATE INDEX PatientConditions_PatientID ON PatientConditions (PatientID);
+
+-- Weight and height history, a measurement may contain only one of both
+CREATE TABLE PatientMeasurements (
+  ID         SERIAL PRIMARY KEY,
+  PatientID  INTEGER      NOT NULL REFERENCES Users (ID) ON DELETE CASCADE,
+  MeasuredOn DATE         NOT NULL,
+  WeightKg   NUMERIC(5,1) NULL,
+  HeightCm   NUMERIC(4,1) NULL,
+  RecordedBy INTEGER      NULL REFERENCES Users (ID) ON DELETE SET NULL,
+  RecordedOn TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX PatientMeasurements_PatientID ON PatientMeasurements (PatientID, MeasuredOn);
+
+INSERT INTO RolePermissions (Role, Permission) VALUES
+  ('admin', 'profiles:read'),
+  ('doctor', 'profiles:read'),
+  ('doctor', 'profiles:write'),
+  ('pharmacist', 'profiles:read');
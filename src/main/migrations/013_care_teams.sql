@@ -0,0 +1,44 @@
+-- Patient relations become care-team memberships with a relation type and a period
+DELETE FROM PatientRelations A USING PatientRelations B
+WHERE A.ctid < B.ctid AND A.PatientID = B.PatientID AND A.RelationID = B.RelationID;
+
+ALTER TABLE PatientRelations ADD COLUMN RelationType VARCHAR(32) NULL;
+ALTER TABLE PatientRelations ADD COLUMN StartsOn     DATE        NOT NULL DEFAULT CURRENT_DATE;
+ALTER TABLE PatientRelations ADD COLUMN EndsOn       DATE        NULL;
+
+-- Ended memberships are kept as history, so a user has at most one open-ended membership of a care team
+CREATE UNIQUE INDEX IF NOT EXISTS PatientRelations_OpenMember ON PatientRelations (PatientID, RelationID) WHERE EndsOn IS NULL;
+
+UPDATE PatientRelations PR
+SET RelationType = CASE U.Role WHEN 'doctor' THEN 'primarydoctor' ELSE U.Role END
+FROM Users U
+WHERE U.ID = PR.RelationID;
+
+ALTER TABLE PatientRelations ALTER COLUMN RelationType SET NOT NULL;
+
+-- Invitations to join the care team of a patient, which the invited user must accept
+CREATE TABLE CareTeamInvitations (
+  ID           SERIAL PRIMARY KEY,
+  PatientID    INTEGER     NOT NULL REFERENCES Users (ID) ON DELETE CASCADE,
+  InviteeID    INTEGER     NOT NULL REFERENCES Users (ID) ON DELETE CASCADE,
+  RelationType VARCHAR(32) NOT NULL,
+  StartsOn     DATE        NOT NULL,
+  EndsOn       DATE        NULL,
+  InvitedBy    INTEGER     NULL REFERENCES Users (ID) ON DELETE SET NULL,
+  Status       VARCHAR(16) NOT NULL DEFAULT 'pending',
+  CreatedOn    TIMESTAMP   NOT NULL DEFAULT NOW(),
+  ExpiresOn    TIMESTAMPTZ NOT NULL,
+  RespondedOn  TIMESTAMP   NULL
+);
+
+CREATE INDEX CareTeamInvitations_PatientID ON CareTeamInvitations (PatientID);
+CREATE INDEX CareTeamInvitations_InviteeID ON CareTeamInvitations (InviteeID);
+
+INSERT INTO RolePermissions (Role, Permission) VALUES
+  ('admin', 'careteams:read'),
+  ('admin', 'careteams:manage'),
+  ('doctor', 'careteams:read'),
+  ('doctor', 'careteams:manage'),
+  ('pharmacist', 'careteams:read'),
+  ('patient', 'careteams:read'),
+  ('patient', 'careteams:manage');
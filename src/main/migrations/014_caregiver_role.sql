@@ -0,0 +1,9 @@
+-- Caregivers, such as family members and home nurses, follow whether the patients whose care team they are a member of
+-- take their medication, but can't change anything
+INSERT INTO Roles (Name, Description, BuiltIn) VALUES
+  ('caregiver', 'Caregiver', TRUE);
+
+INSERT INTO RolePermissions (Role, Permission) VALUES
+  ('caregiver', 'doses:read'),
+  ('caregiver', 'summaries:read'),
+  ('caregiver', 'careteams:read');
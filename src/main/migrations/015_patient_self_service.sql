@@ -0,0 +1,5 @@
+-- Patients can follow their own dose statuses and history through the /api/me endpoints
+INSERT INTO RolePermissions (Role, Permission) VALUES
+  ('patient', 'summaries:read'),
+  ('patient', 'history:read')
+ON CONFLICT DO NOTHING;
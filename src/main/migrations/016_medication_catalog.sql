@@ -0,0 +1,23 @@
+-- Structured medication data, so doses and interaction checks don't depend on free text
+ALTER TABLE Medications ADD COLUMN DosageForm   VARCHAR(32)  NOT NULL DEFAULT '';
+ALTER TABLE Medications ADD COLUMN Route        VARCHAR(32)  NOT NULL DEFAULT '';
+ALTER TABLE Medications ADD COLUMN ATCCode      VARCHAR(7)   NOT NULL DEFAULT '';
+ALTER TABLE Medications ADD COLUMN Manufacturer VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE Medications ADD COLUMN GTIN         VARCHAR(14)  NULL;
+
+-- A product barcode identifies a single medication
+CREATE UNIQUE INDEX Medications_GTIN ON Medications (GTIN) WHERE GTIN IS NOT NULL;
+CREATE INDEX Medications_ATCCode ON Medications (ATCCode);
+
+-- Active ingredients of a medication with their strength per unit of the dosage form, in the order they are listed
+CREATE TABLE MedicationIngredients (
+  ID           SERIAL PRIMARY KEY,
+  MedicationID INTEGER        NOT NULL REFERENCES Medications (ID) ON DELETE CASCADE,
+  Position     INTEGER        NOT NULL,
+  Name         VARCHAR(255)   NOT NULL,
+  Strength     NUMERIC(12, 4) NOT NULL,
+  Unit         VARCHAR(16)    NOT NULL
+);
+
+CREATE INDEX MedicationIngredients_MedicationID ON MedicationIngredients (MedicationID);
+CREATE INDEX MedicationIngredients_Name ON MedicationIngredients (LOWER(Name));
@@ -0,0 +1,23 @@
+-- Interactions between two subjects, which are ingredients ("ingredient:paracetamol"), ATC codes or groups
+-- ("atc:N02A") or medications ("medication:12"). Subject A always sorts before subject B, so each pair is stored once.
+-- The subjects are compared by their bytes, like the application orders them, whatever the collation of the database
+CREATE TABLE DrugInteractions (
+  ID          SERIAL PRIMARY KEY,
+  SubjectA    VARCHAR(255) COLLATE "C" NOT NULL,
+  SubjectB    VARCHAR(255) COLLATE "C" NOT NULL,
+  Severity    VARCHAR(16)  NOT NULL,
+  Description TEXT         NOT NULL,
+  Source      VARCHAR(255) NOT NULL DEFAULT '',
+  ImportedOn  TIMESTAMP    NOT NULL DEFAULT NOW(),
+  CHECK (SubjectA < SubjectB)
+);
+
+CREATE UNIQUE INDEX DrugInteractions_Pair ON DrugInteractions (SubjectA, SubjectB);
+CREATE INDEX DrugInteractions_SubjectB ON DrugInteractions (SubjectB);
+
+-- Doses that were saved despite a contraindication keep the reason the prescriber gave
+ALTER TABLE Doses ADD COLUMN InteractionOverrideReason TEXT NOT NULL DEFAULT '';
+
+INSERT INTO RolePermissions (Role, Permission) VALUES
+  ('admin', 'interactions:manage'),
+  ('pharmacist', 'interactions:manage');
@@ -0,0 +1,31 @@
+-- Tracks how many units of each medication are loaded in a dispenser. Units that were dispensed while the stock was
+-- already used up are counted as the shortfall, until the next refill
+CREATE TABLE DispenserInventory (
+  DispenserID  INTEGER   NOT NULL REFERENCES Dispensers (ID) ON DELETE CASCADE,
+  MedicationID INTEGER   NOT NULL REFERENCES Medications (ID) ON DELETE CASCADE,
+  Stock        INTEGER   NOT NULL DEFAULT 0 CHECK (Stock >= 0),
+  Shortfall    INTEGER   NOT NULL DEFAULT 0 CHECK (Shortfall >= 0),
+  UpdatedOn    TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (DispenserID, MedicationID)
+);
+
+-- Refills of a dispenser by a pharmacist. Negative amounts correct the stock, e.g. after removing expired medication
+CREATE TABLE DispenserRefills (
+  ID           SERIAL    PRIMARY KEY,
+  DispenserID  INTEGER   NOT NULL REFERENCES Dispensers (ID) ON DELETE CASCADE,
+  MedicationID INTEGER   NOT NULL REFERENCES Medications (ID) ON DELETE CASCADE,
+  Amount       INTEGER   NOT NULL CHECK (Amount <> 0),
+  RefilledBy   INTEGER   REFERENCES Users (ID) ON DELETE SET NULL,
+  RefilledOn   TIMESTAMP NOT NULL DEFAULT NOW(),
+  Notes        TEXT      NOT NULL DEFAULT ''
+);
+
+CREATE INDEX DispenserRefills_Dispenser ON DispenserRefills (DispenserID, RefilledOn);
+
+INSERT INTO RolePermissions (Role, Permission) VALUES
+  ('admin', 'inventory:read'),
+  ('admin', 'inventory:write'),
+  ('pharmacist', 'inventory:read'),
+  ('pharmacist', 'inventory:write'),
+  ('doctor', 'inventory:read'),
+  ('dispenser', 'inventory:read');
@@ -0,0 +1,2 @@
+-- Only administrators may create, import and edit staff accounts, patients and caregivers only need users:write
+INSERT INTO RolePermissions (Role, Permission) VALUES ('admin', 'users:staff');
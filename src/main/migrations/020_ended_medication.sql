@@ -0,0 +1,3 @@
+-- Doses and PRN medications of users that are no longer patients are ended instead of deleted, so their history is kept
+ALTER TABLE Doses ADD COLUMN EndedOn TIMESTAMP NULL;
+ALTER TABLE prnmedications ADD COLUMN endedon TIMESTAMP NULL;
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"main/utils"
+	"net/http"
+	"strconv"
+)
+
+// HandleChangePassword handles a password change of the current user
+func HandleChangePassword(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := readCurrentUserSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read password change from request body
+	var passwordChange PasswordChange
+
+	err = utils.ReadJSONFromRequest(r, &passwordChange)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Change the password and respond
+	err = ChangePassword(session.UserID, passwordChange)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleRequestPasswordReset handles a password reset request by e-mail address
+func HandleRequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	// Read reset request from request body
+	var request PasswordResetRequest
+
+	err := utils.ReadJSONFromRequest(r, &request)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Send the reset e-mail and respond
+	err = RequestPasswordReset(request, utils.ClientIP(r, config.Host.TrustProxyHeaders))
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleSendPasswordReset handles an admin-initiated password reset of a user
+func HandleSendPasswordReset(w http.ResponseWriter, r *http.Request) {
+	// Read user ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	// Send the reset e-mail and respond
+	err = SendPasswordReset(userID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleResetPassword handles the completion of a password reset
+func HandleResetPassword(w http.ResponseWriter, r *http.Request) {
+	// Read reset from request body
+	var reset PasswordReset
+
+	err := utils.ReadJSONFromRequest(r, &reset)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Reset the password and respond
+	err = ResetPassword(reset)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"gopkg.in/hlandau/passlib.v1"
+	"main/utils"
+	"time"
+)
+
+type (
+	// PasswordChange contains the current and new password of a user
+	PasswordChange struct {
+		OldPassword string `json:"oldPassword"`
+		NewPassword string `json:"newPassword"`
+	}
+
+	// PasswordResetRequest contains the e-mail address of a user that forgot their password
+	PasswordResetRequest struct {
+		Email string `json:"email"`
+	}
+
+	// PasswordReset contains a password reset token and the new password
+	PasswordReset struct {
+		Token       string `json:"token"`
+		NewPassword string `json:"newPassword"`
+	}
+)
+
+const (
+	MinPasswordLength      = 8
+	PasswordResetTokenTTL  = time.Hour
+	passwordResetMailTitle = "Reset your MySMDS password"
+)
+
+// validatePassword returns an error if a password does not meet the password requirements
+func validatePassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return utils.BadRequestErrorMessage(fmt.Sprintf("Passwords must be at least %d characters long", MinPasswordLength))
+	}
+
+	return nil
+}
+
+// setPassword hashes and stores a new password, and revokes the sessions of the user
+func setPassword(userID int, password string) error {
+	passHash, err := passlib.Hash(password)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	_, err = tx.Exec(`UPDATE Users
+	SET PasswordHash = $1
+	WHERE ID = $2`, passHash, userID)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	// Sessions started with the old password end together with it
+	err = revokeAllUserSessions(tx, userID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	closeUserDispatcherClients(userID, 0)
+
+	return nil
+}
+
+// ChangePassword changes the password of a user after verifying the current password
+func ChangePassword(userID int, passwordChange PasswordChange) error {
+	// Read the current password hash
+	var passwordHash string
+
+	err := db.QueryRow(`SELECT PasswordHash FROM Users WHERE ID = $1`, userID).Scan(&passwordHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return utils.NotFoundErrorMessage(fmt.Sprintf("No user with ID %d found", userID))
+		}
+		return utils.InternalServerError(err)
+	}
+
+	// Check the current password
+	_, err = passlib.Verify(passwordChange.OldPassword, passwordHash)
+	if err != nil {
+		return utils.BadRequestErrorMessage("The current password is incorrect")
+	}
+
+	err = validatePassword(passwordChange.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	return setPassword(userID, passwordChange.NewPassword)
+}
+
+// RequestPasswordReset sends a password reset e-mail to the user with the given e-mail address, if any
+func RequestPasswordReset(request PasswordResetRequest, ipAddress string) error {
+	err := CheckPasswordResetThrottle(request.Email, ipAddress)
+	if err != nil {
+		return err
+	}
+
+	var userID int
+
+	err = db.QueryRow(`SELECT ID FROM Users WHERE Email = $1 AND ArchivedOn IS NULL`, request.Email).Scan(&userID)
+	if err != nil {
+		// Don't reveal whether an account exists for the e-mail address
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return utils.InternalServerError(err)
+	}
+
+	// The e-mail is sent in the background, so neither the response time nor mail errors reveal that the account exists
+	go func() {
+		err := SendPasswordReset(userID)
+		if err != nil {
+			utils.LogError(err)
+		}
+	}()
+
+	return nil
+}
+
+// SendPasswordReset creates a single-use password reset token for a user and e-mails it to them
+func SendPasswordReset(userID int) error {
+	user, err := ReadUser(userID)
+	if err != nil {
+		return err
+	}
+
+	// Create the reset token, only its hash is stored
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	_, err = db.Exec(`INSERT INTO PasswordResetTokens (TokenHash, UserID, ExpiresOn)
+	VALUES ($1, $2, $3)`, utils.HashSHA256(token), userID, time.Now().Add(PasswordResetTokenTTL))
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	// Send the reset link
+	body := fmt.Sprintf("Hello %s,\r\n\r\nA password reset was requested for your account %s. Use the following link to choose a new password:\r\n\r\n%s?token=%s\r\n\r\nThis link expires in %d minutes. If you did not request a password reset, you can ignore this e-mail.\r\n",
+		user.FullName, user.Username, config.Mail.PasswordResetURL, token, int(PasswordResetTokenTTL.Minutes()))
+
+	err = mailer.SendMail(user.Email, passwordResetMailTitle, body)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password using a password reset token
+func ResetPassword(reset PasswordReset) error {
+	err := validatePassword(reset.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	// Consume the token, so it can only be used once
+	var userID int
+
+	err = db.QueryRow(`UPDATE PasswordResetTokens
+	SET UsedOn = NOW()
+	WHERE TokenHash = $1 AND UsedOn IS NULL AND ExpiresOn > NOW()
+	RETURNING UserID`, utils.HashSHA256(reset.Token)).Scan(&userID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return utils.BadRequestErrorMessage("Invalid or expired password reset token")
+		}
+		return utils.InternalServerError(err)
+	}
+
+	return setPassword(userID, reset.NewPassword)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"main/policy"
+	"main/utils"
+	"net/http"
+	"strconv"
+)
+
+// patientRelations looks up the relations between actors and patients in the database
+type patientRelations struct{}
+
+func (patientRelations) IsRelatedToPatient(userID, patientID int) (bool, error) {
+	return IsRelatedToPatient(userID, patientID)
+}
+
+func (patientRelations) IsDispenserAssignedTo(dispenserID, patientID int) (bool, error) {
+	return IsDispenserAssignedTo(dispenserID, patientID)
+}
+
+// AuthorizePatientAccess returns an error if the session is not allowed to access the data of the given patient
+func AuthorizePatientAccess(session Session, patientID int) error {
+	allPatients, err := SessionHasPermission(session, AllPatientsPermission)
+	if err != nil {
+		return err
+	}
+
+	actor := policy.Actor{
+		ID:          session.UserID,
+		Role:        session.Role,
+		Kind:        policy.RelatedUser,
+		AllPatients: allPatients,
+	}
+
+	switch {
+	case session.ActorType == APIKeyActor:
+		actor.Kind = policy.APIKey
+	case session.Role == PatientRole:
+		actor.Kind = policy.Patient
+	case session.ActorType == DispenserActor:
+		actor.Kind = policy.Dispenser
+	}
+
+	return policy.PatientAccess(actor, patientID, patientRelations{}, AllPatientsPermission)
+}
+
+// CheckPatientAccess checks whether the current user may access the data of the patient in the 'userId' URL parameter
+func CheckPatientAccess(next func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Read patient ID from URL
+		vars := mux.Vars(r)
+
+		patientID, err := strconv.Atoi(vars["userId"])
+		if err != nil {
+			utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+			return
+		}
+
+		// Read token from request
+		session, err := ReadJWTSession(r)
+		if err != nil {
+			utils.WriteError(w, err)
+			return
+		}
+
+		// Check the access policy
+		err = AuthorizePatientAccess(session, patientID)
+		if err != nil {
+			utils.WriteError(w, err)
+			return
+		}
+
+		next(w, r)
+	}
+}
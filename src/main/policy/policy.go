@@ -0,0 +1,81 @@
+package policy
+
+import (
+	"fmt"
+	"main/utils"
+)
+
+type (
+	// Kind describes how an actor relates to patients
+	Kind int
+
+	// Actor contains who is requesting access to the data of a patient
+	Actor struct {
+		ID   int
+		Role string
+		Kind Kind
+
+		// AllPatients is set when the actor may access the data of every patient, e.g. admins
+		AllPatients bool
+	}
+
+	// Relations looks up the relations between actors and patients
+	Relations interface {
+		IsRelatedToPatient(userID, patientID int) (bool, error)
+		IsDispenserAssignedTo(dispenserID, patientID int) (bool, error)
+	}
+)
+
+const (
+	// RelatedUser is a user that may access the data of the patients they are related to, like a doctor or caregiver
+	RelatedUser Kind = iota
+
+	// Patient is a user that may only access their own data
+	Patient
+
+	// Dispenser is a dispenser that may only access the data of the patient it is bound to
+	Dispenser
+
+	// APIKey is an API key, which isn't related to any patient
+	APIKey
+)
+
+// PatientAccess returns an error if an actor is not allowed to access the data of the given patient
+func PatientAccess(actor Actor, patientID int, relations Relations, allPatientsPermission string) error {
+	// Some actors may access the data of all patients
+	if actor.AllPatients {
+		return nil
+	}
+
+	switch actor.Kind {
+	case APIKey:
+		return utils.ForbiddenErrorMessage(fmt.Sprintf("Your API key lacks the %s scope required to access patient data.", allPatientsPermission))
+
+	case Patient:
+		if actor.ID != patientID {
+			return utils.ForbiddenErrorMessage("Patients can only access their own data.")
+		}
+		return nil
+
+	case Dispenser:
+		assigned, err := relations.IsDispenserAssignedTo(actor.ID, patientID)
+		if err != nil {
+			return err
+		}
+		if !assigned {
+			return utils.ForbiddenErrorMessage(fmt.Sprintf("This dispenser is not bound to the patient with ID %d.", patientID))
+		}
+		return nil
+	}
+
+	// Other roles, such as doctors, pharmacists and caregivers, may only access the data of patients they are related to
+	related, err := relations.IsRelatedToPatient(actor.ID, patientID)
+	if err != nil {
+		return err
+	}
+	if !related {
+		return utils.ForbiddenErrorMessage(fmt.Sprintf("You are not a %s of the patient with ID %d.", actor.Role, patientID))
+	}
+
+	return nil
+}
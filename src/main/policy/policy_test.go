@@ -0,0 +1,60 @@
+package policy
+
+import (
+	"errors"
+	"main/utils"
+	"net/http"
+	"testing"
+)
+
+// testRelations relates doctor 10 and caregiver 11 to patient 1, and binds dispenser 20 to patient 1
+type testRelations struct{}
+
+func (testRelations) IsRelatedToPatient(userID, patientID int) (bool, error) {
+	if patientID == 3 {
+		return false, errors.New("lookup failed")
+	}
+	return (userID == 10 || userID == 11) && patientID == 1, nil
+}
+
+func (testRelations) IsDispenserAssignedTo(dispenserID, patientID int) (bool, error) {
+	return dispenserID == 20 && patientID == 1, nil
+}
+
+func TestPatientAccess(t *testing.T) {
+	cases := []struct {
+		name      string
+		actor     Actor
+		patientID int
+		status    int
+	}{
+		{"admin", Actor{ID: 99, Role: "admin", AllPatients: true}, 2, 0},
+		{"patient self", Actor{ID: 1, Role: "patient", Kind: Patient}, 1, 0},
+		{"patient other", Actor{ID: 1, Role: "patient", Kind: Patient}, 2, http.StatusForbidden},
+		{"related doctor", Actor{ID: 10, Role: "doctor"}, 1, 0},
+		{"unrelated doctor", Actor{ID: 10, Role: "doctor"}, 2, http.StatusForbidden},
+		{"unrelated pharmacist", Actor{ID: 12, Role: "pharmacist"}, 1, http.StatusForbidden},
+		{"related caregiver", Actor{ID: 11, Role: "caregiver"}, 1, 0},
+		{"bound dispenser", Actor{ID: 20, Role: "dispenser", Kind: Dispenser}, 1, 0},
+		{"unbound dispenser", Actor{ID: 20, Role: "dispenser", Kind: Dispenser}, 2, http.StatusForbidden},
+		{"dispenser with a user's ID", Actor{ID: 10, Role: "dispenser", Kind: Dispenser}, 1, http.StatusForbidden},
+		{"API key with scope", Actor{ID: 30, Role: "doctor", Kind: APIKey, AllPatients: true}, 2, 0},
+		{"API key without scope", Actor{ID: 10, Role: "doctor", Kind: APIKey}, 1, http.StatusForbidden},
+		{"failed lookup", Actor{ID: 10, Role: "doctor"}, 3, http.StatusInternalServerError},
+	}
+
+	for _, c := range cases {
+		err := PatientAccess(c.actor, c.patientID, testRelations{}, "patients:all")
+
+		status := 0
+		if httpErr, ok := err.(*utils.HttpError); ok {
+			status = httpErr.StatusCode
+		} else if err != nil {
+			status = http.StatusInternalServerError
+		}
+
+		if status != c.status {
+			t.Errorf("%s: expected status %d, got %d (%v)", c.name, c.status, status, err)
+		}
+	}
+}
@@ -10,6 +10,13 @@ import (
 
 // HandleCreatePRNHistoryEntry handles the creation of a new PRN history entry
 func HandleCreatePRNHistoryEntry(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
 	// Read user ID from the URL parameters
 	vars := mux.Vars(r)
 
@@ -29,7 +36,7 @@ func HandleCreatePRNHistoryEntry(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create the new dose history entry and respond
-	err = CreatePRNHistoryEntry(userID, newPRNHistoryEntry)
+	err = CreatePRNHistoryEntry(userID, dispensingDispenserID(session), newPRNHistoryEntry)
 
 	if err != nil {
 		utils.WriteError(w, err)
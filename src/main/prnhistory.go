@@ -1,6 +1,9 @@
 package main
 
-import "main/utils"
+import (
+	"fmt"
+	"main/utils"
+)
 
 type (
 	// NewPRNHistoryEntry represents a to-be inserted PRN history entry
@@ -12,14 +15,48 @@ type (
 )
 
 // CreatePRNHistoryEntry creates a new PORN history entry
-func CreatePRNHistoryEntry(userID int, newPRNHistoryEntry NewPRNHistoryEntry) error {
+func CreatePRNHistoryEntry(userID, dispenserID int, newPRNHistoryEntry NewPRNHistoryEntry) error {
+	// Check whether the PRN medication belongs to the user
+	belongs, err := PRNMedicationBelongsToUser(userID, newPRNHistoryEntry.PRNMedicationID)
+	if err != nil {
+		return err
+	}
+	if !belongs {
+		return utils.BadRequestErrorMessage(fmt.Sprintf("PRN medication with ID %d does not belong to the user with ID %d.", newPRNHistoryEntry.PRNMedicationID, userID))
+	}
+
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
 	// Insert the new PRN history entry
 	var prnHistoryEntryID int
-	err := db.QueryRow(`INSERT INTO prnhistory (prnmedicationid, dispensedday, dispensedtime)
+	err = tx.QueryRow(`INSERT INTO prnhistory (prnmedicationid, dispensedday, dispensedtime)
 	values ($1, $2, $3) RETURNING id`, newPRNHistoryEntry.PRNMedicationID, newPRNHistoryEntry.DispensedDay, newPRNHistoryEntry.DispensedTime).Scan(&prnHistoryEntryID)
 
 	if err != nil {
-		utils.InternalServerError(err)
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	// Take the PRN medication from the stock of the dispenser along with the history entry
+	inventoryDispenserID, err := DecrementPRNInventory(tx, dispenserID, userID, newPRNHistoryEntry.PRNMedicationID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	if inventoryDispenserID != 0 {
+		notifyInventoryUpdated(inventoryDispenserID)
 	}
 
 	return nil
@@ -28,8 +28,15 @@ func HandleCreatePRNMedication(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
 	// Create the new medication and respond
-	medication, err := CreatePRNMedication(userID, newMedication)
+	medication, err := CreatePRNMedication(userID, newMedication, actor)
 
 	if err != nil {
 		utils.WriteError(w, err)
@@ -114,8 +121,15 @@ func HandleUpdatePRNMedication(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
 	// Update and return the updated medication
-	medication, err := UpdatePRNMedication(userID, medicationId, updatedMedication)
+	medication, err := UpdatePRNMedication(userID, medicationId, updatedMedication, actor)
 
 	if err != nil {
 		utils.WriteError(w, err)
@@ -142,8 +156,15 @@ func HandleDeletePRNMedication(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
 	// Delete the dose and respond
-	err = DeletePRNMedication(userID, medicationId)
+	err = DeletePRNMedication(userID, medicationId, actor)
 
 	if err != nil {
 		utils.WriteError(w, err)
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"main/utils"
+	"time"
 )
 
 type (
@@ -35,6 +36,16 @@ type (
 		MedicationID int    `json:"medication"`
 	}
 
+	// PRNEligibility contains whether a PRN medication may be taken now, and otherwise from when it may be taken again
+	PRNEligibility struct {
+		PRNMedication   PRNMedicationSummary `json:"prnMedication"`
+		NDispensedToday int                  `json:"nDispensedToday"`
+		LastDispensedAt string               `json:"lastDispensedAt"`
+		Eligible        bool                 `json:"eligible"`
+		EligibleFrom    string               `json:"eligibleFrom"`
+		Reason          string               `json:"reason,omitempty"`
+	}
+
 	// UpdatedPRNMedication contains data of a to-be updated PRN medication
 	UpdatedPRNMedication struct {
 		Description  string `json:"description"`
@@ -57,23 +68,45 @@ func (m PRNMedicationDetails) ToSummary() PRNMedicationSummary {
 }
 
 // CreatePRNMedication creates a new PRN medication
-func CreatePRNMedication(userID int, newMedication NewPRNMedication) (PRNMedicationDetails, error) {
+func CreatePRNMedication(userID int, newMedication NewPRNMedication, actor AuditActor) (PRNMedicationDetails, error) {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return PRNMedicationDetails{}, utils.InternalServerError(err)
+	}
+
 	// Insert the medication into the database
 	var medicationID int
-	err := db.QueryRow(`INSERT INTO prnmedications (description, maxdaily, mininterval, medicationid)
-	VALUES ($1, $2, $3, $4) RETURNING id`, newMedication.Description, newMedication.MaxDaily, newMedication.MinInterval, newMedication.MedicationID).Scan(&medicationID)
+	err = tx.QueryRow(`INSERT INTO prnmedications (description, userid, maxdaily, mininterval, medicationid)
+	VALUES ($1, $2, $3, $4, $5) RETURNING id`, newMedication.Description, userID, newMedication.MaxDaily, newMedication.MinInterval,
+		newMedication.MedicationID).Scan(&medicationID)
 
 	if err != nil {
+		utils.RollbackOrLog(tx)
 		return PRNMedicationDetails{}, utils.InternalServerError(err)
 	}
 
-	// Notify the dispatcher
-	medication, err := ReadPRNMedication(userID, medicationID)
+	// Audit the created medication
+	medication, err := readPRNMedication(tx, userID, medicationID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return PRNMedicationDetails{}, err
+	}
 
+	err = RecordAudit(tx, actor, AuditActionCreate, AuditEntityPRNMedication, medicationID, userID, nil, medication)
 	if err != nil {
-		return medication, utils.InternalServerError(err)
+		utils.RollbackOrLog(tx)
+		return PRNMedicationDetails{}, err
 	}
 
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return PRNMedicationDetails{}, utils.InternalServerError(err)
+	}
+
+	// Notify the dispatcher
 	prnSubject.PRNMedicationAdded(userID, medication.ToSummary())
 
 	return medication, nil
@@ -84,7 +117,7 @@ func ListPRNMedications(userID int) ([]PRNMedicationSummary, error) {
 	// Read the PRN medications from the database
 	rows, err := db.Query(`SELECT p.id, p.description, p.userid, p.maxdaily, p.mininterval, m.id, m.title, m.description FROM prnmedications p
 	LEFT JOIN medications m on p.medicationid = m.id
-	WHERE p.userid = $1`, userID)
+	WHERE p.userid = $1 AND p.endedon IS NULL`, userID)
 
 	if err != nil {
 		return []PRNMedicationSummary{}, utils.InternalServerError(err)
@@ -109,12 +142,17 @@ func ListPRNMedications(userID int) ([]PRNMedicationSummary, error) {
 
 // ReadPRNMedication returns a PRN medication for a user by its ID
 func ReadPRNMedication(userID, prnMedicationID int) (PRNMedicationDetails, error) {
+	return readPRNMedication(db, userID, prnMedicationID)
+}
+
+// readPRNMedication reads a PRN medication of a user inside or outside a transaction
+func readPRNMedication(q querier, userID, prnMedicationID int) (PRNMedicationDetails, error) {
 	// Read the PRN medication from the database
 	var m PRNMedicationDetails
 
-	err := db.QueryRow(`SELECT p.id, p.description, p.userid, p.maxdaily, p.mininterval, m.id, m.title, m.description FROM prnmedications p
+	err := q.QueryRow(`SELECT p.id, p.description, p.userid, p.maxdaily, p.mininterval, m.id, m.title, m.description FROM prnmedications p
 	LEFT JOIN medications m on p.medicationid = m.id
-	WHERE p.userid = $1`, userID, prnMedicationID).Scan(&m.ID, &m.Description, &m.UserID, &m.MaxDaily, &m.MinInterval, &m.Medication.ID, &m.Medication.Title, &m.Medication.Description)
+	WHERE p.userid = $1 AND p.id = $2 AND p.endedon IS NULL`, userID, prnMedicationID).Scan(&m.ID, &m.Description, &m.UserID, &m.MaxDaily, &m.MinInterval, &m.Medication.ID, &m.Medication.Title, &m.Medication.Description)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -126,10 +164,41 @@ func ReadPRNMedication(userID, prnMedicationID int) (PRNMedicationDetails, error
 	return m, nil
 }
 
+// PRNMedicationBelongsToUser returns whether the PRN medication with the given ID is prescribed to the given user
+func PRNMedicationBelongsToUser(userID, prnMedicationID int) (bool, error) {
+	var belongs bool
+
+	err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM prnmedications WHERE id = $1 AND userid = $2 AND endedon IS NULL)`, prnMedicationID, userID).Scan(&belongs)
+	if err != nil {
+		return false, utils.InternalServerError(err)
+	}
+
+	return belongs, nil
+}
+
 // UpdatePRNMedication updates an existing PRN medication
-func UpdatePRNMedication(userID, prnMedicationID int, updatedMedication UpdatedPRNMedication) (PRNMedicationDetails, error) {
+func UpdatePRNMedication(userID, prnMedicationID int, updatedMedication UpdatedPRNMedication, actor AuditActor) (PRNMedicationDetails, error) {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return PRNMedicationDetails{}, utils.InternalServerError(err)
+	}
+
+	// Lock the medication and get its current state for the audit log
+	_, err = tx.Exec(`SELECT id FROM prnmedications WHERE id = $1 AND userid = $2 FOR UPDATE`, prnMedicationID, userID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return PRNMedicationDetails{}, utils.InternalServerError(err)
+	}
+
+	oldMedication, err := readPRNMedication(tx, userID, prnMedicationID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return PRNMedicationDetails{}, err
+	}
+
 	// Update the medication
-	_, err := db.Exec(`UPDATE prnmedications
+	_, err = tx.Exec(`UPDATE prnmedications
 	SET
 		description = $1,
 		maxdaily = $2,
@@ -138,26 +207,75 @@ func UpdatePRNMedication(userID, prnMedicationID int, updatedMedication UpdatedP
 	WHERE id = $5 AND userid = $6`, updatedMedication.Description, updatedMedication.MaxDaily, updatedMedication.MinInterval, updatedMedication.MedicationID, prnMedicationID, userID)
 
 	if err != nil {
+		utils.RollbackOrLog(tx)
 		return PRNMedicationDetails{}, utils.InternalServerError(err)
 	}
 
-	// Notify the dispatcher
-	medication, err := ReadPRNMedication(userID, prnMedicationID)
+	// Audit the change
+	medication, err := readPRNMedication(tx, userID, prnMedicationID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return PRNMedicationDetails{}, err
+	}
+
+	err = RecordAudit(tx, actor, AuditActionUpdate, AuditEntityPRNMedication, prnMedicationID, userID, oldMedication, medication)
 	if err != nil {
+		utils.RollbackOrLog(tx)
+		return PRNMedicationDetails{}, err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
 		return PRNMedicationDetails{}, utils.InternalServerError(err)
 	}
 
+	// Notify the dispatcher
 	prnSubject.PRNMedicationUpdated(userID, medication.ToSummary())
 
 	return medication, nil
 }
 
 // DeletePRNMedication deletes a PRN medication
-func DeletePRNMedication(userID, prnMedicationID int) error {
+func DeletePRNMedication(userID, prnMedicationID int, actor AuditActor) error {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	// Lock the medication and get its current state for the audit log
+	_, err = tx.Exec(`SELECT id FROM prnmedications WHERE id = $1 AND userid = $2 FOR UPDATE`, prnMedicationID, userID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	medication, err := readPRNMedication(tx, userID, prnMedicationID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
 	// Delete the entity in the database
-	_, err := db.Exec(`DELETE FROM prnmedications WHERE id = $1 AND userid = $2`, prnMedicationID, userID)
+	_, err = tx.Exec(`DELETE FROM prnmedications WHERE id = $1 AND userid = $2`, prnMedicationID, userID)
 
 	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	err = RecordAudit(tx, actor, AuditActionDelete, AuditEntityPRNMedication, prnMedicationID, userID, medication, nil)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
 		return utils.InternalServerError(err)
 	}
 
@@ -165,3 +283,65 @@ func DeletePRNMedication(userID, prnMedicationID int) error {
 	prnSubject.PRNMedicationDeleted(userID, prnMedicationID)
 	return nil
 }
+
+// ListPRNEligibility returns for each PRN medication of a user whether it may be taken now, based on the maximum daily
+// amount and the minimum interval in hours between two intakes
+func ListPRNEligibility(userID int) ([]PRNEligibility, error) {
+	// The current time is read from the database, as the history is stored in its local time
+	rows, err := db.Query(`SELECT p.id, p.description, p.userid, p.maxdaily, p.mininterval, m.id, m.title, m.description,
+		(SELECT COUNT(*) FROM prnhistory ph WHERE ph.prnmedicationid = p.id AND ph.dispensedday = CURRENT_DATE),
+		(SELECT MAX(ph.dispensedday + ph.dispensedtime) FROM prnhistory ph WHERE ph.prnmedicationid = p.id),
+		LOCALTIMESTAMP
+	FROM prnmedications p
+	LEFT JOIN medications m on p.medicationid = m.id
+	WHERE p.userid = $1 AND p.endedon IS NULL
+	ORDER BY p.id`, userID)
+
+	if err != nil {
+		return []PRNEligibility{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over all rows and determine the eligibility of each medication
+	eligibilities := []PRNEligibility{}
+	var lastDispensed *time.Time
+	var now time.Time
+
+	for rows.Next() {
+		var e PRNEligibility
+		m := &e.PRNMedication
+
+		err = rows.Scan(&m.ID, &m.Description, &m.UserID, &m.MaxDaily, &m.MinInterval, &m.Medication.ID, &m.Medication.Title,
+			&m.Medication.Description, &e.NDispensedToday, &lastDispensed, &now)
+
+		if err != nil {
+			return []PRNEligibility{}, utils.InternalServerError(err)
+		}
+
+		eligibleFrom := now
+
+		// A maximum of 0 means there is no daily limit
+		if m.MaxDaily > 0 && e.NDispensedToday >= m.MaxDaily {
+			year, month, day := now.Date()
+			eligibleFrom = time.Date(year, month, day+1, 0, 0, 0, 0, now.Location())
+			e.Reason = fmt.Sprintf("The maximum of %d per day has been reached", m.MaxDaily)
+		}
+
+		if lastDispensed != nil {
+			e.LastDispensedAt = lastDispensed.Format(time.RFC3339)
+
+			next := lastDispensed.Add(time.Duration(m.MinInterval) * time.Hour)
+			if next.After(now) && next.After(eligibleFrom) {
+				eligibleFrom = next
+				e.Reason = fmt.Sprintf("At least %d hours must pass between two intakes", m.MinInterval)
+			}
+		}
+
+		e.Eligible = !eligibleFrom.After(now)
+		e.EligibleFrom = eligibleFrom.Format(time.RFC3339)
+
+		eligibilities = append(eligibilities, e)
+	}
+
+	return eligibilities, nil
+}
@@ -101,6 +101,15 @@ func (prns *PRNSubject) GetMessageChan() <-chan dispatch.SubjectMessage {
 	return prns.messages
 }
 
+func (prns *PRNSubject) AuthorizeSubscription(principal dispatch.Principal, sp dispatch.SubscriptionParams) error {
+	subscriptionParams, ok := sp.(*prnSubjectSubscriptionParams)
+	if !ok {
+		return dispatch.BadRequestErrorMessage("Invalid subscription parameters")
+	}
+
+	return authorizeSubscription(principal, PRNMedicationsReadPermission, subscriptionParams.UserID)
+}
+
 // PRNMedicationAdded notifies subscribers of the subject that a PRN medication has been added
 func (prns *PRNSubject) PRNMedicationAdded(userID int, prnMedicationSummary PRNMedicationSummary) {
 	prns.messages <- dispatch.SubjectMessage{
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"github.com/gorilla/mux"
+	"main/utils"
+	"net/http"
+)
+
+// HandleListPermissions returns a list of all permissions that can be granted to the client
+func HandleListPermissions(w http.ResponseWriter, r *http.Request) {
+	utils.WriteJSON(w, Permissions)
+}
+
+// HandleListRoles returns a list of all roles to the client
+func HandleListRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := ListRoles()
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, roles)
+}
+
+// HandleReadRole returns a single role to the client
+func HandleReadRole(w http.ResponseWriter, r *http.Request) {
+	// Read role name from URL
+	vars := mux.Vars(r)
+
+	// Read the role from the database and write to the client
+	role, err := ReadRole(vars["role"])
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, role)
+}
+
+// HandleSaveRole handles the creation or update of a role
+func HandleSaveRole(w http.ResponseWriter, r *http.Request) {
+	// Read role name from URL
+	vars := mux.Vars(r)
+
+	// Read updated role from request body
+	var updatedRole UpdatedRole
+
+	err := utils.ReadJSONFromRequest(r, &updatedRole)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Save the role and write it to the client
+	role, err := SaveRole(vars["role"], updatedRole)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, role)
+}
+
+// HandleDeleteRole handles the deletion of a role
+func HandleDeleteRole(w http.ResponseWriter, r *http.Request) {
+	// Read role name from URL
+	vars := mux.Vars(r)
+
+	// Delete the role
+	err := DeleteRole(vars["role"])
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
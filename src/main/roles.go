@@ -0,0 +1,309 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"main/utils"
+	"regexp"
+	"sync"
+	"time"
+)
+
+type (
+	// Role contains a role and the permissions granted to it
+	Role struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		BuiltIn     bool     `json:"builtIn"`
+		Permissions []string `json:"permissions"`
+	}
+
+	// UpdatedRole contains the description and permissions of a to-be created or updated role
+	UpdatedRole struct {
+		Description string   `json:"description"`
+		Permissions []string `json:"permissions"`
+	}
+)
+
+const (
+	// Duration for which role permissions are cached, so changes made by other instances are picked up eventually
+	RolePermissionsCacheTTL = time.Minute
+)
+
+var (
+	roleNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,63}$`)
+
+	rolePermissions         map[string]map[string]bool
+	rolePermissionsLoadedOn time.Time
+	rolePermissionsMutex    sync.RWMutex
+)
+
+// loadRolePermissions reads the permissions of all roles from the database into the cache
+func loadRolePermissions() error {
+	rows, err := db.Query(`SELECT Role, Permission FROM RolePermissions`)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	permissions := make(map[string]map[string]bool)
+	var role, permission string
+
+	for rows.Next() {
+		err = rows.Scan(&role, &permission)
+		if err != nil {
+			return utils.InternalServerError(err)
+		}
+
+		if _, ok := permissions[role]; !ok {
+			permissions[role] = make(map[string]bool)
+		}
+		permissions[role][permission] = true
+	}
+
+	rolePermissionsMutex.Lock()
+	rolePermissions = permissions
+	rolePermissionsLoadedOn = time.Now()
+	rolePermissionsMutex.Unlock()
+
+	return nil
+}
+
+// invalidateRolePermissions makes sure the role permissions are read from the database on the next check
+func invalidateRolePermissions() {
+	rolePermissionsMutex.Lock()
+	rolePermissions = nil
+	rolePermissionsMutex.Unlock()
+}
+
+// HasPermission returns whether a role has been granted a permission
+func HasPermission(role, permission string) (bool, error) {
+	rolePermissionsMutex.RLock()
+	stale := rolePermissions == nil || time.Since(rolePermissionsLoadedOn) > RolePermissionsCacheTTL
+	rolePermissionsMutex.RUnlock()
+
+	if stale {
+		err := loadRolePermissions()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	rolePermissionsMutex.RLock()
+	defer rolePermissionsMutex.RUnlock()
+
+	return rolePermissions[role][permission], nil
+}
+
+// SessionHasPermission returns whether a session has a permission, either through its role or the scopes of its API key
+func SessionHasPermission(session Session, permission string) (bool, error) {
+	if session.ActorType == APIKeyActor {
+		for _, scope := range session.Scopes {
+			if scope == permission {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	return HasPermission(session.Role, permission)
+}
+
+// isKnownPermission returns whether a permission exists
+func isKnownPermission(permission string) bool {
+	for _, knownPermission := range Permissions {
+		if knownPermission == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RoleExists returns whether a role with the given name exists
+func RoleExists(name string) (bool, error) {
+	var exists bool
+
+	err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM Roles WHERE Name = $1)`, name).Scan(&exists)
+	if err != nil {
+		return false, utils.InternalServerError(err)
+	}
+
+	return exists, nil
+}
+
+// IsStaffRole returns whether users with a role are staff. Every role except patient and caregiver is a staff role,
+// including custom roles, as these may be granted any permission
+func IsStaffRole(role string) bool {
+	return role != PatientRole && role != CaregiverRole
+}
+
+// ListRoles returns a list of all roles and their permissions
+func ListRoles() ([]Role, error) {
+	rows, err := db.Query(`SELECT R.Name, R.Description, R.BuiltIn, RP.Permission
+	FROM Roles R
+	LEFT JOIN RolePermissions RP ON RP.Role = R.Name
+	ORDER BY R.Name, RP.Permission`)
+
+	if err != nil {
+		return []Role{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over all rows, which contain a role once for every permission
+	roles := []Role{}
+	var role Role
+	var permission *string
+
+	for rows.Next() {
+		err = rows.Scan(&role.Name, &role.Description, &role.BuiltIn, &permission)
+		if err != nil {
+			return []Role{}, utils.InternalServerError(err)
+		}
+
+		if len(roles) == 0 || roles[len(roles)-1].Name != role.Name {
+			role.Permissions = []string{}
+			roles = append(roles, role)
+		}
+
+		if permission != nil {
+			last := &roles[len(roles)-1]
+			last.Permissions = append(last.Permissions, *permission)
+		}
+	}
+
+	return roles, nil
+}
+
+// ReadRole returns a single role and its permissions
+func ReadRole(name string) (Role, error) {
+	role := Role{Permissions: []string{}}
+
+	err := db.QueryRow(`SELECT Name, Description, BuiltIn FROM Roles WHERE Name = $1`, name).Scan(&role.Name, &role.Description, &role.BuiltIn)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Role{}, utils.NotFoundErrorMessage(fmt.Sprintf("No role with name '%s' found", name))
+		}
+		return Role{}, utils.InternalServerError(err)
+	}
+
+	rows, err := db.Query(`SELECT Permission FROM RolePermissions WHERE Role = $1 ORDER BY Permission`, name)
+	if err != nil {
+		return Role{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	var permission string
+	for rows.Next() {
+		err = rows.Scan(&permission)
+		if err != nil {
+			return Role{}, utils.InternalServerError(err)
+		}
+
+		role.Permissions = append(role.Permissions, permission)
+	}
+
+	return role, nil
+}
+
+// SaveRole creates a role or replaces the description and permissions of an existing role
+func SaveRole(name string, updatedRole UpdatedRole) (Role, error) {
+	// Validate the role
+	if !roleNameRegexp.MatchString(name) {
+		return Role{}, utils.BadRequestErrorMessage("Role names must start with a lowercase letter and may only contain lowercase letters, digits, '-' and '_'")
+	}
+
+	for _, permission := range updatedRole.Permissions {
+		if !isKnownPermission(permission) {
+			return Role{}, utils.BadRequestErrorMessage(fmt.Sprintf("Unknown permission '%s'", permission))
+		}
+	}
+
+	// Prevent admins from locking themselves out of role management
+	if name == AdminRole {
+		canManageRoles := false
+		for _, permission := range updatedRole.Permissions {
+			if permission == RolesManagePermission {
+				canManageRoles = true
+			}
+		}
+
+		if !canManageRoles {
+			return Role{}, utils.BadRequestErrorMessage(fmt.Sprintf("The %s role must keep the %s permission", AdminRole, RolesManagePermission))
+		}
+	}
+
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return Role{}, utils.InternalServerError(err)
+	}
+
+	_, err = tx.Exec(`INSERT INTO Roles (Name, Description) VALUES ($1, $2)
+	ON CONFLICT (Name) DO UPDATE SET Description = EXCLUDED.Description`, name, updatedRole.Description)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return Role{}, utils.InternalServerError(err)
+	}
+
+	// Replace the permissions of the role
+	_, err = tx.Exec(`DELETE FROM RolePermissions WHERE Role = $1`, name)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return Role{}, utils.InternalServerError(err)
+	}
+
+	for _, permission := range updatedRole.Permissions {
+		_, err = tx.Exec(`INSERT INTO RolePermissions (Role, Permission) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`, name, permission)
+
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return Role{}, utils.InternalServerError(err)
+		}
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return Role{}, utils.InternalServerError(err)
+	}
+
+	invalidateRolePermissions()
+
+	return ReadRole(name)
+}
+
+// DeleteRole deletes a role that is not built-in and not assigned to any user
+func DeleteRole(name string) error {
+	role, err := ReadRole(name)
+	if err != nil {
+		return err
+	}
+
+	if role.BuiltIn {
+		return utils.BadRequestErrorMessage(fmt.Sprintf("The built-in role '%s' can't be deleted", name))
+	}
+
+	var inUse bool
+
+	err = db.QueryRow(`SELECT EXISTS(SELECT 1 FROM Users WHERE Role = $1)`, name).Scan(&inUse)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+	if inUse {
+		return utils.BadRequestErrorMessage(fmt.Sprintf("The role '%s' is still assigned to one or more users", name))
+	}
+
+	_, err = db.Exec(`DELETE FROM Roles WHERE Name = $1`, name)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	invalidateRolePermissions()
+
+	return nil
+}
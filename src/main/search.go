@@ -3,6 +3,9 @@ package main
 import (
 	"strings"
 	"fmt"
+	"main/utils"
+	"strconv"
+	"net/url"
 )
 
 type (
@@ -15,11 +18,22 @@ type (
 	FieldMapping struct {
 		DBField string
 		SearchType int
+
+		// Fields that are searched by a contains search, a value matches if any of these fields contains it
+		DBFields []string
 	}
 )
 
 const (
 	SearchTypeEqual = iota
+	SearchTypeContains
+	SearchTypePrefix
+)
+
+const (
+	// Response headers containing the total number of results of a paginated list, and the cursor of the next page
+	TotalCountHeader = "X-Total-Count"
+	NextCursorHeader = "X-Next-Cursor"
 )
 
 // NewMapping creates a new search mapping
@@ -32,22 +46,35 @@ func (sm SearchMapping) DefineFieldMapping(field string, mapping FieldMapping) {
 	sm.mapping[field] = mapping
 }
 
-// CreateQuery changes a search query based on the search mapping, and returns the changed query and its parameters
-func (sm SearchMapping) CreateQuery(query string, search map[string]string, params... interface{}) (string, []interface{}) {
+// CreateQuery changes a search query based on the search mapping, and returns the changed query and its parameters.
+// A parameter that is given multiple times matches if any of its values matches
+func (sm SearchMapping) CreateQuery(query string, search url.Values, params... interface{}) (string, []interface{}) {
 	conditions := []string{}
 
 	for param, fieldMapping := range sm.mapping {
-		if searchValue, ok := search[param]; ok && len(searchValue) > 0 {
-			clauses := []string{}
+		clauses := []string{}
 
-			for _, val := range strings.Split(searchValue, "|") {
-				switch(fieldMapping.SearchType) {
-				case SearchTypeEqual:
-					clauses = append(clauses, fmt.Sprintf("%s = $%d", fieldMapping.DBField, len(params)+1))
-				}
+		for _, val := range search[param] {
+			if len(val) == 0 {
+				continue
+			}
+
+			switch(fieldMapping.SearchType) {
+			case SearchTypeEqual:
+				clauses = append(clauses, fmt.Sprintf("%s = $%d", fieldMapping.DBField, len(params)+1))
 				params = append(params, val)
+			case SearchTypeContains:
+				for _, dbField := range fieldMapping.DBFields {
+					clauses = append(clauses, fmt.Sprintf("%s ILIKE $%d", dbField, len(params)+1))
+				}
+				params = append(params, "%"+escapeLikePattern(val)+"%")
+			case SearchTypePrefix:
+				clauses = append(clauses, fmt.Sprintf("%s ILIKE $%d", fieldMapping.DBField, len(params)+1))
+				params = append(params, escapeLikePattern(val)+"%")
 			}
+		}
 
+		if len(clauses) > 0 {
 			conditions = append(conditions, fmt.Sprintf("(%s)", strings.Join(clauses, " OR ")))
 		}
 	}
@@ -59,4 +86,34 @@ func (sm SearchMapping) CreateQuery(query string, search map[string]string, para
 	}
 
 	return query, params
-}
\ No newline at end of file
+}
+
+// escapeLikePattern escapes the wildcards of a LIKE pattern, so a search value is matched literally
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// readPageParams reads the limit and offset of a page of results from a search. A default limit of 0 means that all
+// results are returned when no limit is given
+func readPageParams(search url.Values, defaultLimit, maxLimit int) (int, int, error) {
+	limit := defaultLimit
+	offset := 0
+
+	if limitStr := search.Get("limit"); len(limitStr) > 0 {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxLimit {
+			return 0, 0, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of query parameter 'limit' must be an integer between 1 and %d.", limitStr, maxLimit))
+		}
+	}
+
+	if offsetStr := search.Get("offset"); len(offsetStr) > 0 {
+		var err error
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			return 0, 0, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of query parameter 'offset' isn't a valid non-negative integer.", offsetStr))
+		}
+	}
+
+	return limit, offset, nil
+}
@@ -1,6 +1,6 @@
-webpackJsonp([1],{1205:function(e,t){e.exports=function(e){return e.webpackPolyfill||(e.deprecate=function(){},e.paths=[],e.children||(e.children=[]),Object.defineProperty(e,"loaded",{enumerable:!0,get:function(){return e.l}}),Object.defineProperty(e,"id",{enumerable:!0,get:function(){return e.i}}),e.webpackPolyfill=1),e}},1206:function(e,t,n){"use strict";var a=n(213);n(322);var r=n(640);a.platformBrowserDynamic().bootstrapModule(r.AppModule)},139:function(e,t,n){"use strict";function isURLSearchParams(e){return e instanceof d.URLSearchParams}var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},i=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},o=n(0),d=n(153),u=function(){function AuthHttp(e){this.http=e}return AuthHttp.prototype.postJSON=function(e,t,n){return s(this,void 0,void 0,function(){var a;return i(this,function(r){switch(r.label){case 0:return[4,this.http.post(e,t,this.createRequestOptions(n||{})).toPromise()];case 1:return a=r.sent(),[2,a.json()]}})})},AuthHttp.prototype.getJSON=function(e,t){return s(this,void 0,void 0,function(){var n;return i(this,function(a){switch(a.label){case 0:return[4,this.http.get(e,this.createRequestOptions(t||{})).toPromise()];case 1:return n=a.sent(),[2,n.json()]}})})},AuthHttp.prototype.putJSON=function(e,t,n){return s(this,void 0,void 0,function(){var a;return i(this,function(r){switch(r.label){case 0:return[4,this.http.put(e,t,this.createRequestOptions(n||{})).toPromise()];case 1:return a=r.sent(),[2,a.json()]}})})},AuthHttp.prototype.delete=function(e,t){return s(this,void 0,void 0,function(){return i(this,function(n){switch(n.label){case 0:return[4,this.http.delete(e,this.createRequestOptions(t||{})).toPromise()];case 1:return n.sent(),[2]}})})},AuthHttp.prototype.createRequestOptions=function(e){var t={headers:this.createHeaders(e)};if(e.searchParams)if(isURLSearchParams(e.searchParams))t.search=e.searchParams;else{var n=new d.URLSearchParams;for(var a in e.searchParams)n.append(a,e.searchParams[a]);t.search=n}return new d.RequestOptions(t)},AuthHttp.prototype.createHeaders=function(e){return new d.Headers(Object.assign({},e.headers,{"X-JWT":sessionStorage.getItem("jwt")}))},AuthHttp}();u=a([o.Injectable(),r("design:paramtypes",[d.Http])],u),t.AuthHttp=u},140:function(e,t,n){"use strict";var a=this&&this.__extends||function(e,t){function __(){this.constructor=e}for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n]);e.prototype=null===t?Object.create(t):(__.prototype=t.prototype,new __)},r=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},s=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},i=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},o=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},d=n(0),u=n(765),l=n(192),c=n(89),_=n(139),m=n(193),h=function(e){function DoseMedication(){return null!==e&&e.apply(this,arguments)||this}return a(DoseMedication,e),DoseMedication}(l.Model);r([l.Field(),s("design:type",Number)],h.prototype,"amount",void 0),r([l.ModelField({model:c.Medication}),s("design:type",c.Medication)],h.prototype,"medication",void 0),t.DoseMedication=h;var p=function(e){function Dose(){return null!==e&&e.apply(this,arguments)||this}return a(Dose,e),Dose}(l.Model);r([l.Field(),s("design:type",Number)],p.prototype,"id",void 0),r([l.Field(),s("design:type",String)],p.prototype,"title",void 0),r([l.Field(),s("design:type",String)],p.prototype,"dispenseBefore",void 0),r([l.Field(),s("design:type",String)],p.prototype,"dispenseAfter",void 0),r([l.Field({detail:!0}),s("design:type",String)],p.prototype,"description",void 0),r([l.ModelListField({detail:!0,model:h}),s("design:type",Array)],p.prototype,"medications",void 0),t.Dose=p;var f=function(e){function DoseService(t,n){var a=e.call(this,t,n)||this;return a.baseURL="/users",a.nestedURL="/doses",a.model=p,a.collectionSubject="doses",a.collectionSubjectSuperIdProperty="userId",a}return a(DoseService,e),DoseService.prototype.create=function(t,n){return i(this,void 0,void 0,function(){var a;return o(this,function(r){switch(r.label){case 0:return a=n,a.dispenseAfter=n.dispenseAfter.hour.toString()+":"+n.dispenseBefore.minute.toString()+":00",a.dispenseBefore=n.dispenseBefore.hour.toString()+":"+n.dispenseBefore.minute.toString()+":00",[4,e.prototype.create.call(this,t,a)];case 1:return[2,r.sent()]}})})},DoseService}(u.NestedAPIInterface);f=r([d.Injectable(),s("design:paramtypes",[_.AuthHttp,m.DispatcherService])],f),t.DoseService=f},141:function(e,t,n){"use strict";var a=this&&this.__extends||function(e,t){function __(){this.constructor=e}for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n]);e.prototype=null===t?Object.create(t):(__.prototype=t.prototype,new __)},r=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},s=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},i=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},o=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},d=n(192),u=n(0),l=n(461),c=n(139),_=function(e){function User(){return null!==e&&e.apply(this,arguments)||this}return a(User,e),User}(d.Model);r([d.Field(),s("design:type",Number)],_.prototype,"id",void 0),r([d.Field(),s("design:type",String)],_.prototype,"username",void 0),r([d.Field(),s("design:type",String)],_.prototype,"fullName",void 0),r([d.Field(),s("design:type",String)],_.prototype,"role",void 0),r([d.Field(),s("design:type",String)],_.prototype,"email",void 0),r([d.Field(),s("design:type",String)],_.prototype,"emailMD5",void 0),r([d.Field(),s("design:type",String)],_.prototype,"phone",void 0),r([d.Field({detail:!0}),s("design:type",String)],_.prototype,"gender",void 0),r([d.DateField({detail:!0}),s("design:type",Date)],_.prototype,"birthdate",void 0),r([d.ModelListField({optional:!0,detail:!0,model:_}),s("design:type",Array)],_.prototype,"doctors",void 0),r([d.ModelListField({optional:!0,detail:!0,model:_}),s("design:type",Array)],_.prototype,"pharmacists",void 0),r([d.ModelListField({optional:!0,detail:!0,model:_}),s("design:type",Array)],_.prototype,"patients",void 0),r([d.ModelListField({optional:!0,detail:!0,model:_}),s("design:type",Array)],_.prototype,"customers",void 0),t.User=_;var m=function(e){function UserService(t){var n=e.call(this,t)||this;return n.baseURL="/users",n.model=_,n}return a(UserService,e),UserService.prototype.create=function(t){return i(this,void 0,void 0,function(){return o(this,function(n){switch(n.label){case 0:return[4,e.prototype.create.call(this,t)];case 1:return[2,n.sent()]}})})},UserService.prototype.listUsersWithRole=function(e){return i(this,void 0,void 0,function(){var t,n=this;return o(this,function(a){switch(a.label){case 0:return[4,this.http.getJSON("/api"+this.baseURL,{searchParams:{role:e.join("|")}})];case 1:return t=a.sent(),[2,t.map(function(e){return new n.model(e)})]}})})},UserService}(l.APIInterface);m=r([u.Injectable(),s("design:paramtypes",[c.AuthHttp])],m),t.UserService=m},192:function(e,t,n){"use strict";function Field(e){var t=void 0===e?{detail:!0,optional:!1}:e,n=t.detail,r=t.optional;return function(e,t){e.fields instanceof Array?e.fields.push({name:t,type:a.PRIMITIVE,detail:n,optional:r}):e.fields=[{name:t,type:a.PRIMITIVE,detail:n,optional:r}]}}function DateField(e){var t=void 0===e?{detail:!0,optional:!1}:e,n=t.detail,r=t.optional;return function(e,t){e.fields instanceof Array?e.fields.push({name:t,type:a.DATE,detail:n,optional:r}):e.fields=[{name:t,type:a.DATE,detail:n,optional:r}]}}function ModelField(e){var t=void 0===e?{detail:!0,optional:!1,model:s}:e,n=t.detail,r=t.optional,i=t.model;return function(e,t){e.fields instanceof Array?e.fields.push({name:t,type:a.MODEL,model:i,detail:n,optional:r}):e.fields=[{name:t,type:a.MODEL,model:i,detail:n,optional:r}]}}function ModelListField(e){var t=void 0===e?{detail:!0,optional:!1,model:s}:e,n=t.detail,r=t.optional,i=t.model;return function(e,t){e.fields instanceof Array?e.fields.push({name:t,type:a.MODEL_LIST,model:i,detail:n,optional:r}):e.fields=[{name:t,type:a.MODEL_LIST,model:i,detail:n,optional:r}]}}function ModelDictField(e){var t=void 0===e?{detail:!0,optional:!1,model:s}:e,n=t.detail,r=t.optional,i=t.model;return function(e,t){e.fields instanceof Array?e.fields.push({name:t,type:a.MODEL_DICT,model:i,detail:n,optional:r}):e.fields=[{name:t,type:a.MODEL_DICT,model:i,detail:n,optional:r}]}}var a,r=this&&this.__extends||function(e,t){function __(){this.constructor=e}for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n]);e.prototype=null===t?Object.create(t):(__.prototype=t.prototype,new __)};!function(e){e[e.PRIMITIVE=0]="PRIMITIVE",e[e.DATE=2]="DATE",e[e.MODEL=3]="MODEL",e[e.MODEL_LIST=4]="MODEL_LIST",e[e.MODEL_DICT=5]="MODEL_DICT"}(a||(a={}));(function(e){function ModelError(){return null!==e&&e.apply(this,arguments)||this}return r(ModelError,e),ModelError})(Error);t.Field=Field,t.DateField=DateField,t.ModelField=ModelField,t.ModelListField=ModelListField,t.ModelDictField=ModelDictField;var s=function(){function Model(e,t){if(!t)for(var n=function(t){var n=t.name;if(e.hasOwnProperty(n))switch(t.type){case a.PRIMITIVE:r[n]=e[n];break;case a.DATE:r[n]=new Date(e[n]);break;case a.MODEL:r[n]=new t.model(e[n]);break;case a.MODEL_LIST:r[n]=e[n].map(function(e){return new t.model(e)});break;case a.MODEL_DICT:var s={};for(var i in e[n])s[i]=new t.model(e[n][i]);r[n]=s}else if(!t.optional&&!t.detail)throw console.log(e),console.log("Failed to instantiate "+r.constructor.name+": Non-optional, non-detail field "+n+" was missing"),new Error("Failed to instantiate "+r.constructor.name+": Non-optional, non-detail field "+n+" was missing")},r=this,s=0,i=this.modelFields;s<i.length;s++){var o=i[s];n(o)}}return Object.defineProperty(Model.prototype,"modelFields",{get:function(){return this.constructor.prototype.fields},enumerable:!0,configurable:!0}),Model.prototype.toJSON=function(){for(var e={},t=0,n=this.modelFields;t<n.length;t++){var r=n[t],s=r.name;if(this.hasOwnProperty(r.name))switch(r.type){case a.PRIMITIVE:e[s]=this[s];break;case a.DATE:e[s]=this[s].toString();break;case a.MODEL:e[s]=this[s].toJSON();break;case a.MODEL_LIST:e[s]=this[s].map(function(e){return e.toJSON()});break;case a.MODEL_DICT:for(var i={},o=0,d=this[s];o<d.length;o++){var u=d[o];i[u]=this[s][u].toJSON()}e[s]=i}else{if(!r.optional&&!r.detail)throw console.log("Failed to serialize "+this.constructor.name+": Non-optional, non-detail field "+s+" was missing"),new Error("Failed to serialize "+this.constructor.name+": Non-optional, non-detail field "+s+" was missing");r.type==a.MODEL_LIST?e[s]=[]:r.type==a.MODEL_DICT&&(e[s]={})}}return e},Model}();t.Model=s},193:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},i=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},o=n(0),d=n(321),u=n(924),l=function(){function DispatcherService(){var e=this;this.messages=new d.Subject,this.requestIdCounter=1,this.subscriptions=[],this.connection=new Promise(function(t){e.socket=new WebSocket(("https:"==window.location.protocol?"wss":"ws")+"://"+("localhost"==window.location.host?"localhost:5000":window.location.host)+"/api/dispatcher"),e.socket.addEventListener("open",function(e){t()}),e.socket.addEventListener("message",function(t){e.messages.next(JSON.parse(t.data))})})}return DispatcherService.prototype.subscribeTo=function(e,t){return s(this,void 0,void 0,function(){var n,a,r;return i(this,function(s){switch(s.label){case 0:return n=this.subscriptions.find(function(n){return n.subject==e&&u(n.subscriptionParams,t)}),n?(this.subscriptions.forEach(function(e){e.subscription.subscriptionId==n.subscription.subscriptionId&&e.subscriberCount++}),[2,n.subscription]):[4,this.sendRequest("subscribe",{subject:e,subscriptionParams:t})];case 1:return a=s.sent(),console.log(a),r={updates:this.messages.filter(function(e){return e.subscriptionId==a.payload.subscriptionId}),subscriptionId:a.payload.subscriptionId},this.subscriptions.push({subscription:r,subject:e,subscriptionParams:t,subscriberCount:1}),[2,r]}})})},DispatcherService.prototype.unsubscribeTo=function(e){return s(this,void 0,void 0,function(){var t=this;return i(this,function(n){switch(n.label){case 0:return[4,Promise.all(this.subscriptions.map(function(n){return s(t,void 0,void 0,function(){return i(this,function(t){switch(t.label){case 0:return n.subscription.subscriptionId!=e?[3,2]:(n.subscriberCount--,n.subscriberCount<=0?[4,this.sendRequest("unsubscribe",{subscriptionId:e})]:[3,2]);case 1:t.sent(),t.label=2;case 2:return[2,n]}})})}))];case 1:return n.sent(),this.subscriptions=this.subscriptions.filter(function(e){return e.subscriberCount>0}),[2]}})})},DispatcherService.prototype.sendRequest=function(e,t){return s(this,void 0,void 0,function(){var n,a=this;return i(this,function(r){switch(r.label){case 0:return[4,this.connection];case 1:return r.sent(),this.socket.send(JSON.stringify({requestId:this.requestIdCounter,action:e,payload:t})),n=this.requestIdCounter++,[4,new Promise(function(e){var t=a.messages.filter(function(e){return 0==e.subscriptionId&&e.requestId==n}).subscribe(function(n){t.unsubscribe(),e(n)})})];case 2:return[2,r.sent()]}})})},DispatcherService}();l=a([o.Injectable(),r("design:paramtypes",[])],l),t.DispatcherService=l},194:function(e,t,n){"use strict";var a=this&&this.__extends||function(e,t){function __(){this.constructor=e}for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n]);e.prototype=null===t?Object.create(t):(__.prototype=t.prototype,new __)},r=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},s=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},i=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},o=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},d=n(0),u=n(139),l=n(192),c=n(193),_=function(e){function DoseSummarySummary(){return null!==e&&e.apply(this,arguments)||this}return a(DoseSummarySummary,e),DoseSummarySummary}(l.Model);r([l.Field(),s("design:type",String)],_.prototype,"date",void 0),r([l.Field(),s("design:type",Number)],_.prototype,"dispensedCount",void 0),r([l.Field(),s("design:type",Number)],_.prototype,"pendingCount",void 0),r([l.Field(),s("design:type",Number)],_.prototype,"totalCount",void 0),t.DoseSummarySummary=_;var m=function(e){function DoseStatus(){return null!==e&&e.apply(this,arguments)||this}return a(DoseStatus,e),DoseStatus}(l.Model);r([l.Field(),s("design:type",String)],m.prototype,"dispensedTime",void 0),r([l.Field(),s("design:type",Boolean)],m.prototype,"dispensed",void 0),r([l.Field(),s("design:type",Boolean)],m.prototype,"pending",void 0),r([l.Field(),s("design:type",Boolean)],m.prototype,"beingDispensed",void 0),r([l.Field(),s("design:type",Object)],m.prototype,"dose",void 0),t.DoseStatus=m;var h=function(){function DoseSummaryService(e,t){this.http=e,this.dispatcherService=t}return DoseSummaryService.prototype.listDoseSummaries=function(e){return i(this,void 0,void 0,function(){var t;return o(this,function(n){switch(n.label){case 0:return[4,this.http.getJSON("/api/users/"+e+"/dosesummaries")];case 1:return t=n.sent(),[2,t.map(function(e){return new _(e)})]}})})},DoseSummaryService.prototype.listDoseStatuses=function(e,t){return i(this,void 0,void 0,function(){var n;return o(this,function(a){switch(a.label){case 0:return[4,this.http.getJSON("/api/users/"+e+"/dosesummaries/"+t)];case 1:return n=a.sent(),[2,n.map(function(e){return new m(e)})]}})})},DoseSummaryService.prototype.getDoseSummariesUpdates=function(e){return i(this,void 0,void 0,function(){var t;return o(this,function(n){switch(n.label){case 0:return[4,this.dispatcherService.subscribeTo("dosesummaries",{userId:e})];case 1:return t=n.sent(),[2,{updates:t.updates.map(function(e){return e.payload.updatedSummaries}),subscriptionId:t.subscriptionId}]}})})},DoseSummaryService.prototype.getDoseStatusesUpdates=function(e,t){return i(this,void 0,void 0,function(){var n;return o(this,function(a){switch(a.label){case 0:return[4,this.dispatcherService.subscribeTo("dosestatuses",{userId:e,date:t})];case 1:return n=a.sent(),[2,{updates:n.updates.map(function(e){return e.payload.updatedStatuses}),subscriptionId:n.subscriptionId}]}})})},DoseSummaryService}();h=r([d.Injectable(),s("design:paramtypes",[u.AuthHttp,c.DispatcherService])],h),t.DoseSummaryService=h},2:function(e,t,n){(function(e){!function(t,n){e.exports=n()}(this,function(){"use strict";function hooks(){return t.apply(null,arguments)}function setHookCallback(e){t=e}function isArray(e){return e instanceof Array||"[object Array]"===Object.prototype.toString.call(e)}function isObject(e){return null!=e&&"[object Object]"===Object.prototype.toString.call(e)}function isObjectEmpty(e){var t;for(t in e)return!1;return!0}function isNumber(e){return"number"==typeof e||"[object Number]"===Object.prototype.toString.call(e)}function isDate(e){return e instanceof Date||"[object Date]"===Object.prototype.toString.call(e)}function map(e,t){var n,a=[];for(n=0;n<e.length;++n)a.push(t(e[n],n));return a}function hasOwnProp(e,t){return Object.prototype.hasOwnProperty.call(e,t)}function extend(e,t){for(var n in t)hasOwnProp(t,n)&&(e[n]=t[n]);return hasOwnProp(t,"toString")&&(e.toString=t.toString),hasOwnProp(t,"valueOf")&&(e.valueOf=t.valueOf),e}function createUTC(e,t,n,a){return createLocalOrUTC(e,t,n,a,!0).utc()}function defaultParsingFlags(){return{empty:!1,unusedTokens:[],unusedInput:[],overflow:-2,charsLeftOver:0,nullInput:!1,invalidMonth:null,invalidFormat:!1,userInvalidated:!1,iso:!1,parsedDateParts:[],meridiem:null}}function getParsingFlags(e){return null==e._pf&&(e._pf=defaultParsingFlags()),e._pf}function isValid(e){if(null==e._isValid){var t=getParsingFlags(e),n=r.call(t.parsedDateParts,function(e){return null!=e}),a=!isNaN(e._d.getTime())&&t.overflow<0&&!t.empty&&!t.invalidMonth&&!t.invalidWeekday&&!t.nullInput&&!t.invalidFormat&&!t.userInvalidated&&(!t.meridiem||t.meridiem&&n);if(e._strict&&(a=a&&0===t.charsLeftOver&&0===t.unusedTokens.length&&void 0===t.bigHour),null!=Object.isFrozen&&Object.isFrozen(e))return a;e._isValid=a}return e._isValid}function createInvalid(e){var t=createUTC(NaN);return null!=e?extend(getParsingFlags(t),e):getParsingFlags(t).userInvalidated=!0,t}function isUndefined(e){return void 0===e}function copyConfig(e,t){var n,a,r;if(isUndefined(t._isAMomentObject)||(e._isAMomentObject=t._isAMomentObject),isUndefined(t._i)||(e._i=t._i),isUndefined(t._f)||(e._f=t._f),isUndefined(t._l)||(e._l=t._l),isUndefined(t._strict)||(e._strict=t._strict),isUndefined(t._tzm)||(e._tzm=t._tzm),isUndefined(t._isUTC)||(e._isUTC=t._isUTC),isUndefined(t._offset)||(e._offset=t._offset),isUndefined(t._pf)||(e._pf=getParsingFlags(t)),isUndefined(t._locale)||(e._locale=t._locale),s.length>0)for(n in s)a=s[n],r=t[a],isUndefined(r)||(e[a]=r);return e}function Moment(e){copyConfig(this,e),this._d=new Date(null!=e._d?e._d.getTime():NaN),this.isValid()||(this._d=new Date(NaN)),i===!1&&(i=!0,hooks.updateOffset(this),i=!1)}function isMoment(e){return e instanceof Moment||null!=e&&null!=e._isAMomentObject}function absFloor(e){return e<0?Math.ceil(e)||0:Math.floor(e)}function toInt(e){var t=+e,n=0;return 0!==t&&isFinite(t)&&(n=absFloor(t)),n}function compareArrays(e,t,n){var a,r=Math.min(e.length,t.length),s=Math.abs(e.length-t.length),i=0;for(a=0;a<r;a++)(n&&e[a]!==t[a]||!n&&toInt(e[a])!==toInt(t[a]))&&i++;return i+s}function warn(e){hooks.suppressDeprecationWarnings===!1&&"undefined"!=typeof console&&console.warn&&console.warn("Deprecation warning: "+e)}function deprecate(e,t){var n=!0;return extend(function(){if(null!=hooks.deprecationHandler&&hooks.deprecationHandler(null,e),n){for(var a,r=[],s=0;s<arguments.length;s++){if(a="","object"==typeof arguments[s]){a+="\n["+s+"] ";for(var i in arguments[0])a+=i+": "+arguments[0][i]+", ";a=a.slice(0,-2)}else a=arguments[s];r.push(a)}warn(e+"\nArguments: "+Array.prototype.slice.call(r).join("")+"\n"+(new Error).stack),n=!1}return t.apply(this,arguments)},t)}function deprecateSimple(e,t){null!=hooks.deprecationHandler&&hooks.deprecationHandler(e,t),o[e]||(warn(t),o[e]=!0)}function isFunction(e){return e instanceof Function||"[object Function]"===Object.prototype.toString.call(e)}function set(e){var t,n;for(n in e)t=e[n],isFunction(t)?this[n]=t:this["_"+n]=t;this._config=e,this._ordinalParseLenient=new RegExp(this._ordinalParse.source+"|"+/\d{1,2}/.source)}function mergeConfigs(e,t){var n,a=extend({},e);for(n in t)hasOwnProp(t,n)&&(isObject(e[n])&&isObject(t[n])?(a[n]={},extend(a[n],e[n]),extend(a[n],t[n])):null!=t[n]?a[n]=t[n]:delete a[n]);for(n in e)hasOwnProp(e,n)&&!hasOwnProp(t,n)&&isObject(e[n])&&(a[n]=extend({},a[n]));return a}function Locale(e){null!=e&&this.set(e)}function calendar(e,t,n){var a=this._calendar[e]||this._calendar.sameElse;return isFunction(a)?a.call(t,n):a}function longDateFormat(e){var t=this._longDateFormat[e],n=this._longDateFormat[e.toUpperCase()];return t||!n?t:(this._longDateFormat[e]=n.replace(/MMMM|MM|DD|dddd/g,function(e){return e.slice(1)}),this._longDateFormat[e])}function invalidDate(){return this._invalidDate}function ordinal(e){return this._ordinal.replace("%d",e)}function relativeTime(e,t,n,a){var r=this._relativeTime[n];return isFunction(r)?r(e,t,n,a):r.replace(/%d/i,e)}function pastFuture(e,t){var n=this._relativeTime[e>0?"future":"past"];return isFunction(n)?n(t):n.replace(/%s/i,t)}function addUnitAlias(e,t){var n=e.toLowerCase();M[n]=M[n+"s"]=M[t]=e}function normalizeUnits(e){return"string"==typeof e?M[e]||M[e.toLowerCase()]:void 0}function normalizeObjectUnits(e){var t,n,a={};for(n in e)hasOwnProp(e,n)&&(t=normalizeUnits(n),t&&(a[t]=e[n]));return a}function addUnitPriority(e,t){y[e]=t}function getPrioritizedUnits(e){var t=[];for(var n in e)t.push({unit:n,priority:y[n]});return t.sort(function(e,t){return e.priority-t.priority}),t}function makeGetSet(e,t){return function(n){return null!=n?(set$1(this,e,n),hooks.updateOffset(this,t),this):get(this,e)}}function get(e,t){return e.isValid()?e._d["get"+(e._isUTC?"UTC":"")+t]():NaN}function set$1(e,t,n){e.isValid()&&e._d["set"+(e._isUTC?"UTC":"")+t](n)}function stringGet(e){return e=normalizeUnits(e),isFunction(this[e])?this[e]():this}function stringSet(e,t){if("object"==typeof e){e=normalizeObjectUnits(e);for(var n=getPrioritizedUnits(e),a=0;a<n.length;a++)this[n[a].unit](e[n[a].unit])}else if(e=normalizeUnits(e),isFunction(this[e]))return this[e](t);return this}function zeroFill(e,t,n){var a=""+Math.abs(e),r=t-a.length,s=e>=0;return(s?n?"+":"":"-")+Math.pow(10,Math.max(0,r)).toString().substr(1)+a}function addFormatToken(e,t,n,a){var r=a;"string"==typeof a&&(r=function(){return this[a]()}),e&&(g[e]=r),t&&(g[t[0]]=function(){return zeroFill(r.apply(this,arguments),t[1],t[2])}),n&&(g[n]=function(){return this.localeData().ordinal(r.apply(this,arguments),e)})}function removeFormattingTokens(e){return e.match(/\[[\s\S]/)?e.replace(/^\[|\]$/g,""):e.replace(/\\/g,"")}function makeFormatFunction(e){var t,n,a=e.match(L);for(t=0,n=a.length;t<n;t++)g[a[t]]?a[t]=g[a[t]]:a[t]=removeFormattingTokens(a[t]);return function(t){var r,s="";for(r=0;r<n;r++)s+=a[r]instanceof Function?a[r].call(t,e):a[r];return s}}function formatMoment(e,t){return e.isValid()?(t=expandFormat(t,e.localeData()),v[t]=v[t]||makeFormatFunction(t),v[t](e)):e.localeData().invalidDate()}function expandFormat(e,t){function replaceLongDateFormatTokens(e){return t.longDateFormat(e)||e}var n=5;for(Y.lastIndex=0;n>=0&&Y.test(e);)e=e.replace(Y,replaceLongDateFormatTokens),Y.lastIndex=0,n-=1;return e}function addRegexToken(e,t,n){C[e]=isFunction(t)?t:function(e,a){return e&&n?n:t}}function getParseRegexForToken(e,t){return hasOwnProp(C,e)?C[e](t._strict,t._locale):new RegExp(unescapeFormat(e))}function unescapeFormat(e){return regexEscape(e.replace("\\","").replace(/\\(\[)|\\(\])|\[([^\]\[]*)\]|\\(.)/g,function(e,t,n,a,r){return t||n||a||r}))}function regexEscape(e){return e.replace(/[-\/\\^$*+?.()|[\]{}]/g,"\\$&")}function addParseToken(e,t){var n,a=t;for("string"==typeof e&&(e=[e]),isNumber(t)&&(a=function(e,n){n[t]=toInt(e)}),n=0;n<e.length;n++)U[e[n]]=a;
+webpackJsonp([1],{1205:function(e,t){e.exports=function(e){return e.webpackPolyfill||(e.deprecate=function(){},e.paths=[],e.children||(e.children=[]),Object.defineProperty(e,"loaded",{enumerable:!0,get:function(){return e.l}}),Object.defineProperty(e,"id",{enumerable:!0,get:function(){return e.i}}),e.webpackPolyfill=1),e}},1206:function(e,t,n){"use strict";var a=n(213);n(322);var r=n(640);a.platformBrowserDynamic().bootstrapModule(r.AppModule)},139:function(e,t,n){"use strict";function isURLSearchParams(e){return e instanceof d.URLSearchParams}var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},i=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},o=n(0),d=n(153),c=n(286),u=function(){function AuthHttp(e,t){this.http=e,this.authService=t}return AuthHttp.prototype.postJSON=function(e,t,n){return s(this,void 0,void 0,function(){var a;return i(this,function(r){switch(r.label){case 0:return[4,this.authService.ensureFreshToken()];case 1:return r.sent(),[4,this.http.post(e,t,this.createRequestOptions(n||{})).toPromise()];case 2:return a=r.sent(),[2,a.json()]}})})},AuthHttp.prototype.getJSON=function(e,t){return s(this,void 0,void 0,function(){var n;return i(this,function(a){switch(a.label){case 0:return[4,this.authService.ensureFreshToken()];case 1:return a.sent(),[4,this.http.get(e,this.createRequestOptions(t||{})).toPromise()];case 2:return n=a.sent(),[2,n.json()]}})})},AuthHttp.prototype.putJSON=function(e,t,n){return s(this,void 0,void 0,function(){var a;return i(this,function(r){switch(r.label){case 0:return[4,this.authService.ensureFreshToken()];case 1:return r.sent(),[4,this.http.put(e,t,this.createRequestOptions(n||{})).toPromise()];case 2:return a=r.sent(),[2,a.json()]}})})},AuthHttp.prototype.delete=function(e,t){return s(this,void 0,void 0,function(){return i(this,function(n){switch(n.label){case 0:return[4,this.authService.ensureFreshToken()];case 1:return n.sent(),[4,this.http.delete(e,this.createRequestOptions(t||{})).toPromise()];case 2:return n.sent(),[2]}})})},AuthHttp.prototype.createRequestOptions=function(e){var t={headers:this.createHeaders(e)};if(e.searchParams)if(isURLSearchParams(e.searchParams))t.search=e.searchParams;else{var n=new d.URLSearchParams;for(var a in e.searchParams)for(var l=e.searchParams[a],p=0,f=Array.isArray(l)?l:[l];p<f.length;p++){var h=f[p];n.append(a,h)}t.search=n}return new d.RequestOptions(t)},AuthHttp.prototype.createHeaders=function(e){return new d.Headers(Object.assign({},e.headers,{"X-JWT":sessionStorage.getItem("jwt")}))},AuthHttp}();u=a([o.Injectable(),r("design:paramtypes",[d.Http,c.AuthService])],u),t.AuthHttp=u},140:function(e,t,n){"use strict";var a=this&&this.__extends||function(e,t){function __(){this.constructor=e}for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n]);e.prototype=null===t?Object.create(t):(__.prototype=t.prototype,new __)},r=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},s=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},i=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},o=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},d=n(0),u=n(765),l=n(192),c=n(89),_=n(139),m=n(193),h=function(e){function DoseMedication(){return null!==e&&e.apply(this,arguments)||this}return a(DoseMedication,e),DoseMedication}(l.Model);r([l.Field(),s("design:type",Number)],h.prototype,"amount",void 0),r([l.ModelField({model:c.Medication}),s("design:type",c.Medication)],h.prototype,"medication",void 0),t.DoseMedication=h;var p=function(e){function Dose(){return null!==e&&e.apply(this,arguments)||this}return a(Dose,e),Dose}(l.Model);r([l.Field(),s("design:type",Number)],p.prototype,"id",void 0),r([l.Field(),s("design:type",String)],p.prototype,"title",void 0),r([l.Field(),s("design:type",String)],p.prototype,"dispenseBefore",void 0),r([l.Field(),s("design:type",String)],p.prototype,"dispenseAfter",void 0),r([l.Field({detail:!0}),s("design:type",String)],p.prototype,"description",void 0),r([l.ModelListField({detail:!0,model:h}),s("design:type",Array)],p.prototype,"medications",void 0),t.Dose=p;var f=function(e){function DoseService(t,n){var a=e.call(this,t,n)||this;return a.baseURL="/users",a.nestedURL="/doses",a.model=p,a.collectionSubject="doses",a.collectionSubjectSuperIdProperty="userId",a}return a(DoseService,e),DoseService.prototype.create=function(t,n){return i(this,void 0,void 0,function(){var a;return o(this,function(r){switch(r.label){case 0:return a=n,a.dispenseAfter=n.dispenseAfter.hour.toString()+":"+n.dispenseBefore.minute.toString()+":00",a.dispenseBefore=n.dispenseBefore.hour.toString()+":"+n.dispenseBefore.minute.toString()+":00",[4,e.prototype.create.call(this,t,a)];case 1:return[2,r.sent()]}})})},DoseService}(u.NestedAPIInterface);f=r([d.Injectable(),s("design:paramtypes",[_.AuthHttp,m.DispatcherService])],f),t.DoseService=f},141:function(e,t,n){"use strict";var a=this&&this.__extends||function(e,t){function __(){this.constructor=e}for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n]);e.prototype=null===t?Object.create(t):(__.prototype=t.prototype,new __)},r=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},s=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},i=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},o=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},d=n(192),u=n(0),l=n(461),c=n(139),_=function(e){function User(){return null!==e&&e.apply(this,arguments)||this}return a(User,e),User}(d.Model);r([d.Field(),s("design:type",Number)],_.prototype,"id",void 0),r([d.Field(),s("design:type",String)],_.prototype,"username",void 0),r([d.Field(),s("design:type",String)],_.prototype,"fullName",void 0),r([d.Field(),s("design:type",String)],_.prototype,"role",void 0),r([d.Field(),s("design:type",String)],_.prototype,"email",void 0),r([d.Field(),s("design:type",String)],_.prototype,"emailMD5",void 0),r([d.Field(),s("design:type",String)],_.prototype,"phone",void 0),r([d.Field({detail:!0}),s("design:type",String)],_.prototype,"gender",void 0),r([d.DateField({detail:!0}),s("design:type",Date)],_.prototype,"birthdate",void 0),r([d.ModelListField({optional:!0,detail:!0,model:_}),s("design:type",Array)],_.prototype,"doctors",void 0),r([d.ModelListField({optional:!0,detail:!0,model:_}),s("design:type",Array)],_.prototype,"pharmacists",void 0),r([d.ModelListField({optional:!0,detail:!0,model:_}),s("design:type",Array)],_.prototype,"patients",void 0),r([d.ModelListField({optional:!0,detail:!0,model:_}),s("design:type",Array)],_.prototype,"customers",void 0),t.User=_;var m=function(e){function UserService(t){var n=e.call(this,t)||this;return n.baseURL="/users",n.model=_,n}return a(UserService,e),UserService.prototype.create=function(t){return i(this,void 0,void 0,function(){return o(this,function(n){switch(n.label){case 0:return[4,e.prototype.create.call(this,t)];case 1:return[2,n.sent()]}})})},UserService.prototype.listUsersWithRole=function(e){return i(this,void 0,void 0,function(){var t,n=this;return o(this,function(a){switch(a.label){case 0:return[4,this.http.getJSON("/api"+this.baseURL,{searchParams:{role:e}})];case 1:return t=a.sent(),[2,t.map(function(e){return new n.model(e)})]}})})},UserService}(l.APIInterface);m=r([u.Injectable(),s("design:paramtypes",[c.AuthHttp])],m),t.UserService=m},192:function(e,t,n){"use strict";function Field(e){var t=void 0===e?{detail:!0,optional:!1}:e,n=t.detail,r=t.optional;return function(e,t){e.fields instanceof Array?e.fields.push({name:t,type:a.PRIMITIVE,detail:n,optional:r}):e.fields=[{name:t,type:a.PRIMITIVE,detail:n,optional:r}]}}function DateField(e){var t=void 0===e?{detail:!0,optional:!1}:e,n=t.detail,r=t.optional;return function(e,t){e.fields instanceof Array?e.fields.push({name:t,type:a.DATE,detail:n,optional:r}):e.fields=[{name:t,type:a.DATE,detail:n,optional:r}]}}function ModelField(e){var t=void 0===e?{detail:!0,optional:!1,model:s}:e,n=t.detail,r=t.optional,i=t.model;return function(e,t){e.fields instanceof Array?e.fields.push({name:t,type:a.MODEL,model:i,detail:n,optional:r}):e.fields=[{name:t,type:a.MODEL,model:i,detail:n,optional:r}]}}function ModelListField(e){var t=void 0===e?{detail:!0,optional:!1,model:s}:e,n=t.detail,r=t.optional,i=t.model;return function(e,t){e.fields instanceof Array?e.fields.push({name:t,type:a.MODEL_LIST,model:i,detail:n,optional:r}):e.fields=[{name:t,type:a.MODEL_LIST,model:i,detail:n,optional:r}]}}function ModelDictField(e){var t=void 0===e?{detail:!0,optional:!1,model:s}:e,n=t.detail,r=t.optional,i=t.model;return function(e,t){e.fields instanceof Array?e.fields.push({name:t,type:a.MODEL_DICT,model:i,detail:n,optional:r}):e.fields=[{name:t,type:a.MODEL_DICT,model:i,detail:n,optional:r}]}}var a,r=this&&this.__extends||function(e,t){function __(){this.constructor=e}for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n]);e.prototype=null===t?Object.create(t):(__.prototype=t.prototype,new __)};!function(e){e[e.PRIMITIVE=0]="PRIMITIVE",e[e.DATE=2]="DATE",e[e.MODEL=3]="MODEL",e[e.MODEL_LIST=4]="MODEL_LIST",e[e.MODEL_DICT=5]="MODEL_DICT"}(a||(a={}));(function(e){function ModelError(){return null!==e&&e.apply(this,arguments)||this}return r(ModelError,e),ModelError})(Error);t.Field=Field,t.DateField=DateField,t.ModelField=ModelField,t.ModelListField=ModelListField,t.ModelDictField=ModelDictField;var s=function(){function Model(e,t){if(!t)for(var n=function(t){var n=t.name;if(e.hasOwnProperty(n))switch(t.type){case a.PRIMITIVE:r[n]=e[n];break;case a.DATE:r[n]=new Date(e[n]);break;case a.MODEL:r[n]=new t.model(e[n]);break;case a.MODEL_LIST:r[n]=e[n].map(function(e){return new t.model(e)});break;case a.MODEL_DICT:var s={};for(var i in e[n])s[i]=new t.model(e[n][i]);r[n]=s}else if(!t.optional&&!t.detail)throw console.log(e),console.log("Failed to instantiate "+r.constructor.name+": Non-optional, non-detail field "+n+" was missing"),new Error("Failed to instantiate "+r.constructor.name+": Non-optional, non-detail field "+n+" was missing")},r=this,s=0,i=this.modelFields;s<i.length;s++){var o=i[s];n(o)}}return Object.defineProperty(Model.prototype,"modelFields",{get:function(){return this.constructor.prototype.fields},enumerable:!0,configurable:!0}),Model.prototype.toJSON=function(){for(var e={},t=0,n=this.modelFields;t<n.length;t++){var r=n[t],s=r.name;if(this.hasOwnProperty(r.name))switch(r.type){case a.PRIMITIVE:e[s]=this[s];break;case a.DATE:e[s]=this[s].toString();break;case a.MODEL:e[s]=this[s].toJSON();break;case a.MODEL_LIST:e[s]=this[s].map(function(e){return e.toJSON()});break;case a.MODEL_DICT:for(var i={},o=0,d=this[s];o<d.length;o++){var u=d[o];i[u]=this[s][u].toJSON()}e[s]=i}else{if(!r.optional&&!r.detail)throw console.log("Failed to serialize "+this.constructor.name+": Non-optional, non-detail field "+s+" was missing"),new Error("Failed to serialize "+this.constructor.name+": Non-optional, non-detail field "+s+" was missing");r.type==a.MODEL_LIST?e[s]=[]:r.type==a.MODEL_DICT&&(e[s]={})}}return e},Model}();t.Model=s},193:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},i=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},o=n(0),d=n(321),u=n(924),c=n(286),l=function(){function DispatcherService(e){var t=this;this.authService=e,this.messages=new d.Subject,this.requestIdCounter=1,this.subscriptions=[],this.connection=new Promise(function(e,n){t.socket=new WebSocket(("https:"==window.location.protocol?"wss":"ws")+"://"+("localhost"==window.location.host?"localhost:5000":window.location.host)+"/api/dispatcher"),t.socket.addEventListener("open",function(a){t.authenticate().then(e,n)}),t.socket.addEventListener("message",function(e){t.messages.next(JSON.parse(e.data))})})}return DispatcherService.prototype.authenticate=function(){return s(this,void 0,void 0,function(){var e;return i(this,function(t){switch(t.label){case 0:return[4,this.authService.ensureFreshToken()];case 1:return t.sent(),[4,this.send("authenticate",{token:sessionStorage.getItem("jwt")})];case 2:if(e=t.sent(),"error"==e.action)throw new Error(e.payload.message);return[2]}})})},DispatcherService.prototype.subscribeTo=function(e,t){return s(this,void 0,void 0,function(){var n,a,r;return i(this,function(s){switch(s.label){case 0:return n=this.subscriptions.find(function(n){return n.subject==e&&u(n.subscriptionParams,t)}),n?(this.subscriptions.forEach(function(e){e.subscription.subscriptionId==n.subscription.subscriptionId&&e.subscriberCount++}),[2,n.subscription]):[4,this.sendRequest("subscribe",{subject:e,subscriptionParams:t})];case 1:return a=s.sent(),console.log(a),r={updates:this.messages.filter(function(e){return e.subscriptionId==a.payload.subscriptionId}),subscriptionId:a.payload.subscriptionId},this.subscriptions.push({subscription:r,subject:e,subscriptionParams:t,subscriberCount:1}),[2,r]}})})},DispatcherService.prototype.unsubscribeTo=function(e){return s(this,void 0,void 0,function(){var t=this;return i(this,function(n){switch(n.label){case 0:return[4,Promise.all(this.subscriptions.map(function(n){return s(t,void 0,void 0,function(){return i(this,function(t){switch(t.label){case 0:return n.subscription.subscriptionId!=e?[3,2]:(n.subscriberCount--,n.subscriberCount<=0?[4,this.sendRequest("unsubscribe",{subscriptionId:e})]:[3,2]);case 1:t.sent(),t.label=2;case 2:return[2,n]}})})}))];case 1:return n.sent(),this.subscriptions=this.subscriptions.filter(function(e){return e.subscriberCount>0}),[2]}})})},DispatcherService.prototype.sendRequest=function(e,t){return s(this,void 0,void 0,function(){return i(this,function(n){switch(n.label){case 0:return[4,this.connection];case 1:return n.sent(),[4,this.send(e,t)];case 2:return[2,n.sent()]}})})},DispatcherService.prototype.send=function(e,t){return s(this,void 0,void 0,function(){var n,a=this;return i(this,function(r){switch(r.label){case 0:return this.socket.send(JSON.stringify({requestId:this.requestIdCounter,action:e,payload:t})),n=this.requestIdCounter++,[4,new Promise(function(e){var t=a.messages.filter(function(e){return 0==e.subscriptionId&&e.requestId==n}).subscribe(function(n){t.unsubscribe(),e(n)})})];case 1:return[2,r.sent()]}})})},DispatcherService}();l=a([o.Injectable(),r("design:paramtypes",[c.AuthService])],l),t.DispatcherService=l},194:function(e,t,n){"use strict";var a=this&&this.__extends||function(e,t){function __(){this.constructor=e}for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n]);e.prototype=null===t?Object.create(t):(__.prototype=t.prototype,new __)},r=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},s=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},i=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},o=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},d=n(0),u=n(139),l=n(192),c=n(193),_=function(e){function DoseSummarySummary(){return null!==e&&e.apply(this,arguments)||this}return a(DoseSummarySummary,e),DoseSummarySummary}(l.Model);r([l.Field(),s("design:type",String)],_.prototype,"date",void 0),r([l.Field(),s("design:type",Number)],_.prototype,"dispensedCount",void 0),r([l.Field(),s("design:type",Number)],_.prototype,"pendingCount",void 0),r([l.Field(),s("design:type",Number)],_.prototype,"totalCount",void 0),t.DoseSummarySummary=_;var m=function(e){function DoseStatus(){return null!==e&&e.apply(this,arguments)||this}return a(DoseStatus,e),DoseStatus}(l.Model);r([l.Field(),s("design:type",String)],m.prototype,"dispensedTime",void 0),r([l.Field(),s("design:type",Boolean)],m.prototype,"dispensed",void 0),r([l.Field(),s("design:type",Boolean)],m.prototype,"pending",void 0),r([l.Field(),s("design:type",Boolean)],m.prototype,"beingDispensed",void 0),r([l.Field(),s("design:type",Object)],m.prototype,"dose",void 0),t.DoseStatus=m;var h=function(){function DoseSummaryService(e,t){this.http=e,this.dispatcherService=t}return DoseSummaryService.prototype.listDoseSummaries=function(e){return i(this,void 0,void 0,function(){var t;return o(this,function(n){switch(n.label){case 0:return[4,this.http.getJSON("/api/users/"+e+"/dosesummaries")];case 1:return t=n.sent(),[2,t.map(function(e){return new _(e)})]}})})},DoseSummaryService.prototype.listDoseStatuses=function(e,t){return i(this,void 0,void 0,function(){var n;return o(this,function(a){switch(a.label){case 0:return[4,this.http.getJSON("/api/users/"+e+"/dosesummaries/"+t)];case 1:return n=a.sent(),[2,n.map(function(e){return new m(e)})]}})})},DoseSummaryService.prototype.getDoseSummariesUpdates=function(e){return i(this,void 0,void 0,function(){var t;return o(this,function(n){switch(n.label){case 0:return[4,this.dispatcherService.subscribeTo("dosesummaries",{userId:e})];case 1:return t=n.sent(),[2,{updates:t.updates.map(function(e){return e.payload.updatedSummaries}),subscriptionId:t.subscriptionId}]}})})},DoseSummaryService.prototype.getDoseStatusesUpdates=function(e,t){return i(this,void 0,void 0,function(){var n;return o(this,function(a){switch(a.label){case 0:return[4,this.dispatcherService.subscribeTo("dosestatuses",{userId:e,date:t})];case 1:return n=a.sent(),[2,{updates:n.updates.map(function(e){return e.payload.updatedStatuses}),subscriptionId:n.subscriptionId}]}})})},DoseSummaryService}();h=r([d.Injectable(),s("design:paramtypes",[u.AuthHttp,c.DispatcherService])],h),t.DoseSummaryService=h},2:function(e,t,n){(function(e){!function(t,n){e.exports=n()}(this,function(){"use strict";function hooks(){return t.apply(null,arguments)}function setHookCallback(e){t=e}function isArray(e){return e instanceof Array||"[object Array]"===Object.prototype.toString.call(e)}function isObject(e){return null!=e&&"[object Object]"===Object.prototype.toString.call(e)}function isObjectEmpty(e){var t;for(t in e)return!1;return!0}function isNumber(e){return"number"==typeof e||"[object Number]"===Object.prototype.toString.call(e)}function isDate(e){return e instanceof Date||"[object Date]"===Object.prototype.toString.call(e)}function map(e,t){var n,a=[];for(n=0;n<e.length;++n)a.push(t(e[n],n));return a}function hasOwnProp(e,t){return Object.prototype.hasOwnProperty.call(e,t)}function extend(e,t){for(var n in t)hasOwnProp(t,n)&&(e[n]=t[n]);return hasOwnProp(t,"toString")&&(e.toString=t.toString),hasOwnProp(t,"valueOf")&&(e.valueOf=t.valueOf),e}function createUTC(e,t,n,a){return createLocalOrUTC(e,t,n,a,!0).utc()}function defaultParsingFlags(){return{empty:!1,unusedTokens:[],unusedInput:[],overflow:-2,charsLeftOver:0,nullInput:!1,invalidMonth:null,invalidFormat:!1,userInvalidated:!1,iso:!1,parsedDateParts:[],meridiem:null}}function getParsingFlags(e){return null==e._pf&&(e._pf=defaultParsingFlags()),e._pf}function isValid(e){if(null==e._isValid){var t=getParsingFlags(e),n=r.call(t.parsedDateParts,function(e){return null!=e}),a=!isNaN(e._d.getTime())&&t.overflow<0&&!t.empty&&!t.invalidMonth&&!t.invalidWeekday&&!t.nullInput&&!t.invalidFormat&&!t.userInvalidated&&(!t.meridiem||t.meridiem&&n);if(e._strict&&(a=a&&0===t.charsLeftOver&&0===t.unusedTokens.length&&void 0===t.bigHour),null!=Object.isFrozen&&Object.isFrozen(e))return a;e._isValid=a}return e._isValid}function createInvalid(e){var t=createUTC(NaN);return null!=e?extend(getParsingFlags(t),e):getParsingFlags(t).userInvalidated=!0,t}function isUndefined(e){return void 0===e}function copyConfig(e,t){var n,a,r;if(isUndefined(t._isAMomentObject)||(e._isAMomentObject=t._isAMomentObject),isUndefined(t._i)||(e._i=t._i),isUndefined(t._f)||(e._f=t._f),isUndefined(t._l)||(e._l=t._l),isUndefined(t._strict)||(e._strict=t._strict),isUndefined(t._tzm)||(e._tzm=t._tzm),isUndefined(t._isUTC)||(e._isUTC=t._isUTC),isUndefined(t._offset)||(e._offset=t._offset),isUndefined(t._pf)||(e._pf=getParsingFlags(t)),isUndefined(t._locale)||(e._locale=t._locale),s.length>0)for(n in s)a=s[n],r=t[a],isUndefined(r)||(e[a]=r);return e}function Moment(e){copyConfig(this,e),this._d=new Date(null!=e._d?e._d.getTime():NaN),this.isValid()||(this._d=new Date(NaN)),i===!1&&(i=!0,hooks.updateOffset(this),i=!1)}function isMoment(e){return e instanceof Moment||null!=e&&null!=e._isAMomentObject}function absFloor(e){return e<0?Math.ceil(e)||0:Math.floor(e)}function toInt(e){var t=+e,n=0;return 0!==t&&isFinite(t)&&(n=absFloor(t)),n}function compareArrays(e,t,n){var a,r=Math.min(e.length,t.length),s=Math.abs(e.length-t.length),i=0;for(a=0;a<r;a++)(n&&e[a]!==t[a]||!n&&toInt(e[a])!==toInt(t[a]))&&i++;return i+s}function warn(e){hooks.suppressDeprecationWarnings===!1&&"undefined"!=typeof console&&console.warn&&console.warn("Deprecation warning: "+e)}function deprecate(e,t){var n=!0;return extend(function(){if(null!=hooks.deprecationHandler&&hooks.deprecationHandler(null,e),n){for(var a,r=[],s=0;s<arguments.length;s++){if(a="","object"==typeof arguments[s]){a+="\n["+s+"] ";for(var i in arguments[0])a+=i+": "+arguments[0][i]+", ";a=a.slice(0,-2)}else a=arguments[s];r.push(a)}warn(e+"\nArguments: "+Array.prototype.slice.call(r).join("")+"\n"+(new Error).stack),n=!1}return t.apply(this,arguments)},t)}function deprecateSimple(e,t){null!=hooks.deprecationHandler&&hooks.deprecationHandler(e,t),o[e]||(warn(t),o[e]=!0)}function isFunction(e){return e instanceof Function||"[object Function]"===Object.prototype.toString.call(e)}function set(e){var t,n;for(n in e)t=e[n],isFunction(t)?this[n]=t:this["_"+n]=t;this._config=e,this._ordinalParseLenient=new RegExp(this._ordinalParse.source+"|"+/\d{1,2}/.source)}function mergeConfigs(e,t){var n,a=extend({},e);for(n in t)hasOwnProp(t,n)&&(isObject(e[n])&&isObject(t[n])?(a[n]={},extend(a[n],e[n]),extend(a[n],t[n])):null!=t[n]?a[n]=t[n]:delete a[n]);for(n in e)hasOwnProp(e,n)&&!hasOwnProp(t,n)&&isObject(e[n])&&(a[n]=extend({},a[n]));return a}function Locale(e){null!=e&&this.set(e)}function calendar(e,t,n){var a=this._calendar[e]||this._calendar.sameElse;return isFunction(a)?a.call(t,n):a}function longDateFormat(e){var t=this._longDateFormat[e],n=this._longDateFormat[e.toUpperCase()];return t||!n?t:(this._longDateFormat[e]=n.replace(/MMMM|MM|DD|dddd/g,function(e){return e.slice(1)}),this._longDateFormat[e])}function invalidDate(){return this._invalidDate}function ordinal(e){return this._ordinal.replace("%d",e)}function relativeTime(e,t,n,a){var r=this._relativeTime[n];return isFunction(r)?r(e,t,n,a):r.replace(/%d/i,e)}function pastFuture(e,t){var n=this._relativeTime[e>0?"future":"past"];return isFunction(n)?n(t):n.replace(/%s/i,t)}function addUnitAlias(e,t){var n=e.toLowerCase();M[n]=M[n+"s"]=M[t]=e}function normalizeUnits(e){return"string"==typeof e?M[e]||M[e.toLowerCase()]:void 0}function normalizeObjectUnits(e){var t,n,a={};for(n in e)hasOwnProp(e,n)&&(t=normalizeUnits(n),t&&(a[t]=e[n]));return a}function addUnitPriority(e,t){y[e]=t}function getPrioritizedUnits(e){var t=[];for(var n in e)t.push({unit:n,priority:y[n]});return t.sort(function(e,t){return e.priority-t.priority}),t}function makeGetSet(e,t){return function(n){return null!=n?(set$1(this,e,n),hooks.updateOffset(this,t),this):get(this,e)}}function get(e,t){return e.isValid()?e._d["get"+(e._isUTC?"UTC":"")+t]():NaN}function set$1(e,t,n){e.isValid()&&e._d["set"+(e._isUTC?"UTC":"")+t](n)}function stringGet(e){return e=normalizeUnits(e),isFunction(this[e])?this[e]():this}function stringSet(e,t){if("object"==typeof e){e=normalizeObjectUnits(e);for(var n=getPrioritizedUnits(e),a=0;a<n.length;a++)this[n[a].unit](e[n[a].unit])}else if(e=normalizeUnits(e),isFunction(this[e]))return this[e](t);return this}function zeroFill(e,t,n){var a=""+Math.abs(e),r=t-a.length,s=e>=0;return(s?n?"+":"":"-")+Math.pow(10,Math.max(0,r)).toString().substr(1)+a}function addFormatToken(e,t,n,a){var r=a;"string"==typeof a&&(r=function(){return this[a]()}),e&&(g[e]=r),t&&(g[t[0]]=function(){return zeroFill(r.apply(this,arguments),t[1],t[2])}),n&&(g[n]=function(){return this.localeData().ordinal(r.apply(this,arguments),e)})}function removeFormattingTokens(e){return e.match(/\[[\s\S]/)?e.replace(/^\[|\]$/g,""):e.replace(/\\/g,"")}function makeFormatFunction(e){var t,n,a=e.match(L);for(t=0,n=a.length;t<n;t++)g[a[t]]?a[t]=g[a[t]]:a[t]=removeFormattingTokens(a[t]);return function(t){var r,s="";for(r=0;r<n;r++)s+=a[r]instanceof Function?a[r].call(t,e):a[r];return s}}function formatMoment(e,t){return e.isValid()?(t=expandFormat(t,e.localeData()),v[t]=v[t]||makeFormatFunction(t),v[t](e)):e.localeData().invalidDate()}function expandFormat(e,t){function replaceLongDateFormatTokens(e){return t.longDateFormat(e)||e}var n=5;for(Y.lastIndex=0;n>=0&&Y.test(e);)e=e.replace(Y,replaceLongDateFormatTokens),Y.lastIndex=0,n-=1;return e}function addRegexToken(e,t,n){C[e]=isFunction(t)?t:function(e,a){return e&&n?n:t}}function getParseRegexForToken(e,t){return hasOwnProp(C,e)?C[e](t._strict,t._locale):new RegExp(unescapeFormat(e))}function unescapeFormat(e){return regexEscape(e.replace("\\","").replace(/\\(\[)|\\(\])|\[([^\]\[]*)\]|\\(.)/g,function(e,t,n,a,r){return t||n||a||r}))}function regexEscape(e){return e.replace(/[-\/\\^$*+?.()|[\]{}]/g,"\\$&")}function addParseToken(e,t){var n,a=t;for("string"==typeof e&&(e=[e]),isNumber(t)&&(a=function(e,n){n[t]=toInt(e)}),n=0;n<e.length;n++)U[e[n]]=a;
 }function addWeekParseToken(e,t){addParseToken(e,function(e,n,a,r){a._w=a._w||{},t(e,a._w,a,r)})}function addTimeToArrayFromToken(e,t,n){null!=t&&hasOwnProp(U,e)&&U[e](t,n._a,n,e)}function daysInMonth(e,t){return new Date(Date.UTC(e,t+1,0)).getUTCDate()}function localeMonths(e,t){return e?isArray(this._months)?this._months[e.month()]:this._months[(this._months.isFormat||Q).test(t)?"format":"standalone"][e.month()]:this._months}function localeMonthsShort(e,t){return e?isArray(this._monthsShort)?this._monthsShort[e.month()]:this._monthsShort[Q.test(t)?"format":"standalone"][e.month()]:this._monthsShort}function handleStrictParse(e,t,n){var a,r,s,i=e.toLocaleLowerCase();if(!this._monthsParse)for(this._monthsParse=[],this._longMonthsParse=[],this._shortMonthsParse=[],a=0;a<12;++a)s=createUTC([2e3,a]),this._shortMonthsParse[a]=this.monthsShort(s,"").toLocaleLowerCase(),this._longMonthsParse[a]=this.months(s,"").toLocaleLowerCase();return n?"MMM"===t?(r=K.call(this._shortMonthsParse,i),r!==-1?r:null):(r=K.call(this._longMonthsParse,i),r!==-1?r:null):"MMM"===t?(r=K.call(this._shortMonthsParse,i),r!==-1?r:(r=K.call(this._longMonthsParse,i),r!==-1?r:null)):(r=K.call(this._longMonthsParse,i),r!==-1?r:(r=K.call(this._shortMonthsParse,i),r!==-1?r:null))}function localeMonthsParse(e,t,n){var a,r,s;if(this._monthsParseExact)return handleStrictParse.call(this,e,t,n);for(this._monthsParse||(this._monthsParse=[],this._longMonthsParse=[],this._shortMonthsParse=[]),a=0;a<12;a++){if(r=createUTC([2e3,a]),n&&!this._longMonthsParse[a]&&(this._longMonthsParse[a]=new RegExp("^"+this.months(r,"").replace(".","")+"$","i"),this._shortMonthsParse[a]=new RegExp("^"+this.monthsShort(r,"").replace(".","")+"$","i")),n||this._monthsParse[a]||(s="^"+this.months(r,"")+"|^"+this.monthsShort(r,""),this._monthsParse[a]=new RegExp(s.replace(".",""),"i")),n&&"MMMM"===t&&this._longMonthsParse[a].test(e))return a;if(n&&"MMM"===t&&this._shortMonthsParse[a].test(e))return a;if(!n&&this._monthsParse[a].test(e))return a}}function setMonth(e,t){var n;if(!e.isValid())return e;if("string"==typeof t)if(/^\d+$/.test(t))t=toInt(t);else if(t=e.localeData().monthsParse(t),!isNumber(t))return e;return n=Math.min(e.date(),daysInMonth(e.year(),t)),e._d["set"+(e._isUTC?"UTC":"")+"Month"](t,n),e}function getSetMonth(e){return null!=e?(setMonth(this,e),hooks.updateOffset(this,!0),this):get(this,"Month")}function getDaysInMonth(){return daysInMonth(this.year(),this.month())}function monthsShortRegex(e){return this._monthsParseExact?(hasOwnProp(this,"_monthsRegex")||computeMonthsParse.call(this),e?this._monthsShortStrictRegex:this._monthsShortRegex):(hasOwnProp(this,"_monthsShortRegex")||(this._monthsShortRegex=te),this._monthsShortStrictRegex&&e?this._monthsShortStrictRegex:this._monthsShortRegex)}function monthsRegex(e){return this._monthsParseExact?(hasOwnProp(this,"_monthsRegex")||computeMonthsParse.call(this),e?this._monthsStrictRegex:this._monthsRegex):(hasOwnProp(this,"_monthsRegex")||(this._monthsRegex=ne),this._monthsStrictRegex&&e?this._monthsStrictRegex:this._monthsRegex)}function computeMonthsParse(){function cmpLenRev(e,t){return t.length-e.length}var e,t,n=[],a=[],r=[];for(e=0;e<12;e++)t=createUTC([2e3,e]),n.push(this.monthsShort(t,"")),a.push(this.months(t,"")),r.push(this.months(t,"")),r.push(this.monthsShort(t,""));for(n.sort(cmpLenRev),a.sort(cmpLenRev),r.sort(cmpLenRev),e=0;e<12;e++)n[e]=regexEscape(n[e]),a[e]=regexEscape(a[e]);for(e=0;e<24;e++)r[e]=regexEscape(r[e]);this._monthsRegex=new RegExp("^("+r.join("|")+")","i"),this._monthsShortRegex=this._monthsRegex,this._monthsStrictRegex=new RegExp("^("+a.join("|")+")","i"),this._monthsShortStrictRegex=new RegExp("^("+n.join("|")+")","i")}function daysInYear(e){return isLeapYear(e)?366:365}function isLeapYear(e){return e%4===0&&e%100!==0||e%400===0}function getIsLeapYear(){return isLeapYear(this.year())}function createDate(e,t,n,a,r,s,i){var o=new Date(e,t,n,a,r,s,i);return e<100&&e>=0&&isFinite(o.getFullYear())&&o.setFullYear(e),o}function createUTCDate(e){var t=new Date(Date.UTC.apply(null,arguments));return e<100&&e>=0&&isFinite(t.getUTCFullYear())&&t.setUTCFullYear(e),t}function firstWeekOffset(e,t,n){var a=7+t-n,r=(7+createUTCDate(e,0,a).getUTCDay()-t)%7;return-r+a-1}function dayOfYearFromWeeks(e,t,n,a,r){var s,i,o=(7+n-a)%7,d=firstWeekOffset(e,a,r),u=1+7*(t-1)+o+d;return u<=0?(s=e-1,i=daysInYear(s)+u):u>daysInYear(e)?(s=e+1,i=u-daysInYear(e)):(s=e,i=u),{year:s,dayOfYear:i}}function weekOfYear(e,t,n){var a,r,s=firstWeekOffset(e.year(),t,n),i=Math.floor((e.dayOfYear()-s-1)/7)+1;return i<1?(r=e.year()-1,a=i+weeksInYear(r,t,n)):i>weeksInYear(e.year(),t,n)?(a=i-weeksInYear(e.year(),t,n),r=e.year()+1):(r=e.year(),a=i),{week:a,year:r}}function weeksInYear(e,t,n){var a=firstWeekOffset(e,t,n),r=firstWeekOffset(e+1,t,n);return(daysInYear(e)-a+r)/7}function localeWeek(e){return weekOfYear(e,this._week.dow,this._week.doy).week}function localeFirstDayOfWeek(){return this._week.dow}function localeFirstDayOfYear(){return this._week.doy}function getSetWeek(e){var t=this.localeData().week(this);return null==e?t:this.add(7*(e-t),"d")}function getSetISOWeek(e){var t=weekOfYear(this,1,4).week;return null==e?t:this.add(7*(e-t),"d")}function parseWeekday(e,t){return"string"!=typeof e?e:isNaN(e)?(e=t.weekdaysParse(e),"number"==typeof e?e:null):parseInt(e,10)}function parseIsoWeekday(e,t){return"string"==typeof e?t.weekdaysParse(e)%7||7:isNaN(e)?null:e}function localeWeekdays(e,t){return e?isArray(this._weekdays)?this._weekdays[e.day()]:this._weekdays[this._weekdays.isFormat.test(t)?"format":"standalone"][e.day()]:this._weekdays}function localeWeekdaysShort(e){return e?this._weekdaysShort[e.day()]:this._weekdaysShort}function localeWeekdaysMin(e){return e?this._weekdaysMin[e.day()]:this._weekdaysMin}function handleStrictParse$1(e,t,n){var a,r,s,i=e.toLocaleLowerCase();if(!this._weekdaysParse)for(this._weekdaysParse=[],this._shortWeekdaysParse=[],this._minWeekdaysParse=[],a=0;a<7;++a)s=createUTC([2e3,1]).day(a),this._minWeekdaysParse[a]=this.weekdaysMin(s,"").toLocaleLowerCase(),this._shortWeekdaysParse[a]=this.weekdaysShort(s,"").toLocaleLowerCase(),this._weekdaysParse[a]=this.weekdays(s,"").toLocaleLowerCase();return n?"dddd"===t?(r=K.call(this._weekdaysParse,i),r!==-1?r:null):"ddd"===t?(r=K.call(this._shortWeekdaysParse,i),r!==-1?r:null):(r=K.call(this._minWeekdaysParse,i),r!==-1?r:null):"dddd"===t?(r=K.call(this._weekdaysParse,i),r!==-1?r:(r=K.call(this._shortWeekdaysParse,i),r!==-1?r:(r=K.call(this._minWeekdaysParse,i),r!==-1?r:null))):"ddd"===t?(r=K.call(this._shortWeekdaysParse,i),r!==-1?r:(r=K.call(this._weekdaysParse,i),r!==-1?r:(r=K.call(this._minWeekdaysParse,i),r!==-1?r:null))):(r=K.call(this._minWeekdaysParse,i),r!==-1?r:(r=K.call(this._weekdaysParse,i),r!==-1?r:(r=K.call(this._shortWeekdaysParse,i),r!==-1?r:null)))}function localeWeekdaysParse(e,t,n){var a,r,s;if(this._weekdaysParseExact)return handleStrictParse$1.call(this,e,t,n);for(this._weekdaysParse||(this._weekdaysParse=[],this._minWeekdaysParse=[],this._shortWeekdaysParse=[],this._fullWeekdaysParse=[]),a=0;a<7;a++){if(r=createUTC([2e3,1]).day(a),n&&!this._fullWeekdaysParse[a]&&(this._fullWeekdaysParse[a]=new RegExp("^"+this.weekdays(r,"").replace(".",".?")+"$","i"),this._shortWeekdaysParse[a]=new RegExp("^"+this.weekdaysShort(r,"").replace(".",".?")+"$","i"),this._minWeekdaysParse[a]=new RegExp("^"+this.weekdaysMin(r,"").replace(".",".?")+"$","i")),this._weekdaysParse[a]||(s="^"+this.weekdays(r,"")+"|^"+this.weekdaysShort(r,"")+"|^"+this.weekdaysMin(r,""),this._weekdaysParse[a]=new RegExp(s.replace(".",""),"i")),n&&"dddd"===t&&this._fullWeekdaysParse[a].test(e))return a;if(n&&"ddd"===t&&this._shortWeekdaysParse[a].test(e))return a;if(n&&"dd"===t&&this._minWeekdaysParse[a].test(e))return a;if(!n&&this._weekdaysParse[a].test(e))return a}}function getSetDayOfWeek(e){if(!this.isValid())return null!=e?this:NaN;var t=this._isUTC?this._d.getUTCDay():this._d.getDay();return null!=e?(e=parseWeekday(e,this.localeData()),this.add(e-t,"d")):t}function getSetLocaleDayOfWeek(e){if(!this.isValid())return null!=e?this:NaN;var t=(this.day()+7-this.localeData()._week.dow)%7;return null==e?t:this.add(e-t,"d")}function getSetISODayOfWeek(e){if(!this.isValid())return null!=e?this:NaN;if(null!=e){var t=parseIsoWeekday(e,this.localeData());return this.day(this.day()%7?t:t-7)}return this.day()||7}function weekdaysRegex(e){return this._weekdaysParseExact?(hasOwnProp(this,"_weekdaysRegex")||computeWeekdaysParse.call(this),e?this._weekdaysStrictRegex:this._weekdaysRegex):(hasOwnProp(this,"_weekdaysRegex")||(this._weekdaysRegex=de),this._weekdaysStrictRegex&&e?this._weekdaysStrictRegex:this._weekdaysRegex)}function weekdaysShortRegex(e){return this._weekdaysParseExact?(hasOwnProp(this,"_weekdaysRegex")||computeWeekdaysParse.call(this),e?this._weekdaysShortStrictRegex:this._weekdaysShortRegex):(hasOwnProp(this,"_weekdaysShortRegex")||(this._weekdaysShortRegex=ue),this._weekdaysShortStrictRegex&&e?this._weekdaysShortStrictRegex:this._weekdaysShortRegex)}function weekdaysMinRegex(e){return this._weekdaysParseExact?(hasOwnProp(this,"_weekdaysRegex")||computeWeekdaysParse.call(this),e?this._weekdaysMinStrictRegex:this._weekdaysMinRegex):(hasOwnProp(this,"_weekdaysMinRegex")||(this._weekdaysMinRegex=le),this._weekdaysMinStrictRegex&&e?this._weekdaysMinStrictRegex:this._weekdaysMinRegex)}function computeWeekdaysParse(){function cmpLenRev(e,t){return t.length-e.length}var e,t,n,a,r,s=[],i=[],o=[],d=[];for(e=0;e<7;e++)t=createUTC([2e3,1]).day(e),n=this.weekdaysMin(t,""),a=this.weekdaysShort(t,""),r=this.weekdays(t,""),s.push(n),i.push(a),o.push(r),d.push(n),d.push(a),d.push(r);for(s.sort(cmpLenRev),i.sort(cmpLenRev),o.sort(cmpLenRev),d.sort(cmpLenRev),e=0;e<7;e++)i[e]=regexEscape(i[e]),o[e]=regexEscape(o[e]),d[e]=regexEscape(d[e]);this._weekdaysRegex=new RegExp("^("+d.join("|")+")","i"),this._weekdaysShortRegex=this._weekdaysRegex,this._weekdaysMinRegex=this._weekdaysRegex,this._weekdaysStrictRegex=new RegExp("^("+o.join("|")+")","i"),this._weekdaysShortStrictRegex=new RegExp("^("+i.join("|")+")","i"),this._weekdaysMinStrictRegex=new RegExp("^("+s.join("|")+")","i")}function hFormat(){return this.hours()%12||12}function kFormat(){return this.hours()||24}function meridiem(e,t){addFormatToken(e,0,0,function(){return this.localeData().meridiem(this.hours(),this.minutes(),t)})}function matchMeridiem(e,t){return t._meridiemParse}function localeIsPM(e){return"p"===(e+"").toLowerCase().charAt(0)}function localeMeridiem(e,t,n){return e>11?n?"pm":"PM":n?"am":"AM"}function normalizeLocale(e){return e?e.toLowerCase().replace("_","-"):e}function chooseLocale(e){for(var t,n,a,r,s=0;s<e.length;){for(r=normalizeLocale(e[s]).split("-"),t=r.length,n=normalizeLocale(e[s+1]),n=n?n.split("-"):null;t>0;){if(a=loadLocale(r.slice(0,t).join("-")))return a;if(n&&n.length>=t&&compareArrays(r,n,!0)>=t-1)break;t--}s++}return null}function loadLocale(t){var a=null;if(!pe[t]&&"undefined"!=typeof e&&e&&e.exports)try{a=ce._abbr,n(941)("./"+t),getSetGlobalLocale(a)}catch(e){}return pe[t]}function getSetGlobalLocale(e,t){var n;return e&&(n=isUndefined(t)?getLocale(e):defineLocale(e,t),n&&(ce=n)),ce._abbr}function defineLocale(e,t){if(null!==t){var n=he;if(t.abbr=e,null!=pe[e])deprecateSimple("defineLocaleOverride","use moment.updateLocale(localeName, config) to change an existing locale. moment.defineLocale(localeName, config) should only be used for creating a new locale See http://momentjs.com/guides/#/warnings/define-locale/ for more info."),n=pe[e]._config;else if(null!=t.parentLocale){if(null==pe[t.parentLocale])return fe[t.parentLocale]||(fe[t.parentLocale]=[]),fe[t.parentLocale].push({name:e,config:t}),null;n=pe[t.parentLocale]._config}return pe[e]=new Locale(mergeConfigs(n,t)),fe[e]&&fe[e].forEach(function(e){defineLocale(e.name,e.config)}),getSetGlobalLocale(e),pe[e]}return delete pe[e],null}function updateLocale(e,t){if(null!=t){var n,a=he;null!=pe[e]&&(a=pe[e]._config),t=mergeConfigs(a,t),n=new Locale(t),n.parentLocale=pe[e],pe[e]=n,getSetGlobalLocale(e)}else null!=pe[e]&&(null!=pe[e].parentLocale?pe[e]=pe[e].parentLocale:null!=pe[e]&&delete pe[e]);return pe[e]}function getLocale(e){var t;if(e&&e._locale&&e._locale._abbr&&(e=e._locale._abbr),!e)return ce;if(!isArray(e)){if(t=loadLocale(e))return t;e=[e]}return chooseLocale(e)}function listLocales(){return l(pe)}function checkOverflow(e){var t,n=e._a;return n&&getParsingFlags(e).overflow===-2&&(t=n[N]<0||n[N]>11?N:n[J]<1||n[J]>daysInMonth(n[z],n[N])?J:n[G]<0||n[G]>24||24===n[G]&&(0!==n[V]||0!==n[$]||0!==n[q])?G:n[V]<0||n[V]>59?V:n[$]<0||n[$]>59?$:n[q]<0||n[q]>999?q:-1,getParsingFlags(e)._overflowDayOfYear&&(t<z||t>J)&&(t=J),getParsingFlags(e)._overflowWeeks&&t===-1&&(t=B),getParsingFlags(e)._overflowWeekday&&t===-1&&(t=Z),getParsingFlags(e).overflow=t),e}function configFromISO(e){var t,n,a,r,s,i,o=e._i,d=Me.exec(o)||ye.exec(o);if(d){for(getParsingFlags(e).iso=!0,t=0,n=Ye.length;t<n;t++)if(Ye[t][1].exec(d[1])){r=Ye[t][0],a=Ye[t][2]!==!1;break}if(null==r)return void(e._isValid=!1);if(d[3]){for(t=0,n=ve.length;t<n;t++)if(ve[t][1].exec(d[3])){s=(d[2]||" ")+ve[t][0];break}if(null==s)return void(e._isValid=!1)}if(!a&&null!=s)return void(e._isValid=!1);if(d[4]){if(!Le.exec(d[4]))return void(e._isValid=!1);i="Z"}e._f=r+(s||"")+(i||""),configFromStringAndFormat(e)}else e._isValid=!1}function configFromString(e){var t=ge.exec(e._i);return null!==t?void(e._d=new Date(+t[1])):(configFromISO(e),void(e._isValid===!1&&(delete e._isValid,hooks.createFromInputFallback(e))))}function defaults(e,t,n){return null!=e?e:null!=t?t:n}function currentDateArray(e){var t=new Date(hooks.now());return e._useUTC?[t.getUTCFullYear(),t.getUTCMonth(),t.getUTCDate()]:[t.getFullYear(),t.getMonth(),t.getDate()]}function configFromArray(e){var t,n,a,r,s=[];if(!e._d){for(a=currentDateArray(e),e._w&&null==e._a[J]&&null==e._a[N]&&dayOfYearFromWeekInfo(e),e._dayOfYear&&(r=defaults(e._a[z],a[z]),e._dayOfYear>daysInYear(r)&&(getParsingFlags(e)._overflowDayOfYear=!0),n=createUTCDate(r,0,e._dayOfYear),e._a[N]=n.getUTCMonth(),e._a[J]=n.getUTCDate()),t=0;t<3&&null==e._a[t];++t)e._a[t]=s[t]=a[t];for(;t<7;t++)e._a[t]=s[t]=null==e._a[t]?2===t?1:0:e._a[t];24===e._a[G]&&0===e._a[V]&&0===e._a[$]&&0===e._a[q]&&(e._nextDay=!0,e._a[G]=0),e._d=(e._useUTC?createUTCDate:createDate).apply(null,s),null!=e._tzm&&e._d.setUTCMinutes(e._d.getUTCMinutes()-e._tzm),e._nextDay&&(e._a[G]=24)}}function dayOfYearFromWeekInfo(e){var t,n,a,r,s,i,o,d;if(t=e._w,null!=t.GG||null!=t.W||null!=t.E)s=1,i=4,n=defaults(t.GG,e._a[z],weekOfYear(createLocal(),1,4).year),a=defaults(t.W,1),r=defaults(t.E,1),(r<1||r>7)&&(d=!0);else{s=e._locale._week.dow,i=e._locale._week.doy;var u=weekOfYear(createLocal(),s,i);n=defaults(t.gg,e._a[z],u.year),a=defaults(t.w,u.week),null!=t.d?(r=t.d,(r<0||r>6)&&(d=!0)):null!=t.e?(r=t.e+s,(t.e<0||t.e>6)&&(d=!0)):r=s}a<1||a>weeksInYear(n,s,i)?getParsingFlags(e)._overflowWeeks=!0:null!=d?getParsingFlags(e)._overflowWeekday=!0:(o=dayOfYearFromWeeks(n,a,r,s,i),e._a[z]=o.year,e._dayOfYear=o.dayOfYear)}function configFromStringAndFormat(e){if(e._f===hooks.ISO_8601)return void configFromISO(e);e._a=[],getParsingFlags(e).empty=!0;var t,n,a,r,s,i=""+e._i,o=i.length,d=0;for(a=expandFormat(e._f,e._locale).match(L)||[],t=0;t<a.length;t++)r=a[t],n=(i.match(getParseRegexForToken(r,e))||[])[0],n&&(s=i.substr(0,i.indexOf(n)),s.length>0&&getParsingFlags(e).unusedInput.push(s),i=i.slice(i.indexOf(n)+n.length),d+=n.length),g[r]?(n?getParsingFlags(e).empty=!1:getParsingFlags(e).unusedTokens.push(r),addTimeToArrayFromToken(r,n,e)):e._strict&&!n&&getParsingFlags(e).unusedTokens.push(r);getParsingFlags(e).charsLeftOver=o-d,i.length>0&&getParsingFlags(e).unusedInput.push(i),e._a[G]<=12&&getParsingFlags(e).bigHour===!0&&e._a[G]>0&&(getParsingFlags(e).bigHour=void 0),getParsingFlags(e).parsedDateParts=e._a.slice(0),getParsingFlags(e).meridiem=e._meridiem,e._a[G]=meridiemFixWrap(e._locale,e._a[G],e._meridiem),configFromArray(e),checkOverflow(e)}function meridiemFixWrap(e,t,n){var a;return null==n?t:null!=e.meridiemHour?e.meridiemHour(t,n):null!=e.isPM?(a=e.isPM(n),a&&t<12&&(t+=12),a||12!==t||(t=0),t):t}function configFromStringAndArray(e){var t,n,a,r,s;if(0===e._f.length)return getParsingFlags(e).invalidFormat=!0,void(e._d=new Date(NaN));for(r=0;r<e._f.length;r++)s=0,t=copyConfig({},e),null!=e._useUTC&&(t._useUTC=e._useUTC),t._f=e._f[r],configFromStringAndFormat(t),isValid(t)&&(s+=getParsingFlags(t).charsLeftOver,s+=10*getParsingFlags(t).unusedTokens.length,getParsingFlags(t).score=s,(null==a||s<a)&&(a=s,n=t));extend(e,n||t)}function configFromObject(e){if(!e._d){var t=normalizeObjectUnits(e._i);e._a=map([t.year,t.month,t.day||t.date,t.hour,t.minute,t.second,t.millisecond],function(e){return e&&parseInt(e,10)}),configFromArray(e)}}function createFromConfig(e){var t=new Moment(checkOverflow(prepareConfig(e)));return t._nextDay&&(t.add(1,"d"),t._nextDay=void 0),t}function prepareConfig(e){var t=e._i,n=e._f;return e._locale=e._locale||getLocale(e._l),null===t||void 0===n&&""===t?createInvalid({nullInput:!0}):("string"==typeof t&&(e._i=t=e._locale.preparse(t)),isMoment(t)?new Moment(checkOverflow(t)):(isDate(t)?e._d=t:isArray(n)?configFromStringAndArray(e):n?configFromStringAndFormat(e):configFromInput(e),isValid(e)||(e._d=null),e))}function configFromInput(e){var t=e._i;void 0===t?e._d=new Date(hooks.now()):isDate(t)?e._d=new Date(t.valueOf()):"string"==typeof t?configFromString(e):isArray(t)?(e._a=map(t.slice(0),function(e){return parseInt(e,10)}),configFromArray(e)):"object"==typeof t?configFromObject(e):isNumber(t)?e._d=new Date(t):hooks.createFromInputFallback(e)}function createLocalOrUTC(e,t,n,a,r){var s={};return n!==!0&&n!==!1||(a=n,n=void 0),(isObject(e)&&isObjectEmpty(e)||isArray(e)&&0===e.length)&&(e=void 0),s._isAMomentObject=!0,s._useUTC=s._isUTC=r,s._l=n,s._i=e,s._f=t,s._strict=a,createFromConfig(s)}function createLocal(e,t,n,a){return createLocalOrUTC(e,t,n,a,!1)}function pickBy(e,t){var n,a;if(1===t.length&&isArray(t[0])&&(t=t[0]),!t.length)return createLocal();for(n=t[0],a=1;a<t.length;++a)t[a].isValid()&&!t[a][e](n)||(n=t[a]);return n}function min(){var e=[].slice.call(arguments,0);return pickBy("isBefore",e)}function max(){var e=[].slice.call(arguments,0);return pickBy("isAfter",e)}function Duration(e){var t=normalizeObjectUnits(e),n=t.year||0,a=t.quarter||0,r=t.month||0,s=t.week||0,i=t.day||0,o=t.hour||0,d=t.minute||0,u=t.second||0,l=t.millisecond||0;this._milliseconds=+l+1e3*u+6e4*d+1e3*o*60*60,this._days=+i+7*s,this._months=+r+3*a+12*n,this._data={},this._locale=getLocale(),this._bubble()}function isDuration(e){return e instanceof Duration}function absRound(e){return e<0?Math.round(-1*e)*-1:Math.round(e)}function offset(e,t){addFormatToken(e,0,0,function(){var e=this.utcOffset(),n="+";return e<0&&(e=-e,n="-"),n+zeroFill(~~(e/60),2)+t+zeroFill(~~e%60,2)})}function offsetFromString(e,t){var n=(t||"").match(e);if(null===n)return null;var a=n[n.length-1]||[],r=(a+"").match(we)||["-",0,0],s=+(60*r[1])+toInt(r[2]);return 0===s?0:"+"===r[0]?s:-s}function cloneWithOffset(e,t){var n,a;return t._isUTC?(n=t.clone(),a=(isMoment(e)||isDate(e)?e.valueOf():createLocal(e).valueOf())-n.valueOf(),n._d.setTime(n._d.valueOf()+a),hooks.updateOffset(n,!1),n):createLocal(e).local()}function getDateOffset(e){return 15*-Math.round(e._d.getTimezoneOffset()/15)}function getSetOffset(e,t){var n,a=this._offset||0;if(!this.isValid())return null!=e?this:NaN;if(null!=e){if("string"==typeof e){if(e=offsetFromString(W,e),null===e)return this}else Math.abs(e)<16&&(e*=60);return!this._isUTC&&t&&(n=getDateOffset(this)),this._offset=e,this._isUTC=!0,null!=n&&this.add(n,"m"),a!==e&&(!t||this._changeInProgress?addSubtract(this,createDuration(e-a,"m"),1,!1):this._changeInProgress||(this._changeInProgress=!0,hooks.updateOffset(this,!0),this._changeInProgress=null)),this}return this._isUTC?a:getDateOffset(this)}function getSetZone(e,t){return null!=e?("string"!=typeof e&&(e=-e),this.utcOffset(e,t),this):-this.utcOffset()}function setOffsetToUTC(e){return this.utcOffset(0,e)}function setOffsetToLocal(e){return this._isUTC&&(this.utcOffset(0,e),this._isUTC=!1,e&&this.subtract(getDateOffset(this),"m")),this}function setOffsetToParsedOffset(){if(null!=this._tzm)this.utcOffset(this._tzm);else if("string"==typeof this._i){var e=offsetFromString(A,this._i);null!=e?this.utcOffset(e):this.utcOffset(0,!0)}return this}function hasAlignedHourOffset(e){return!!this.isValid()&&(e=e?createLocal(e).utcOffset():0,(this.utcOffset()-e)%60===0)}function isDaylightSavingTime(){return this.utcOffset()>this.clone().month(0).utcOffset()||this.utcOffset()>this.clone().month(5).utcOffset()}function isDaylightSavingTimeShifted(){if(!isUndefined(this._isDSTShifted))return this._isDSTShifted;var e={};if(copyConfig(e,this),e=prepareConfig(e),e._a){var t=e._isUTC?createUTC(e._a):createLocal(e._a);this._isDSTShifted=this.isValid()&&compareArrays(e._a,t.toArray())>0}else this._isDSTShifted=!1;return this._isDSTShifted}function isLocal(){return!!this.isValid()&&!this._isUTC}function isUtcOffset(){return!!this.isValid()&&this._isUTC}function isUtc(){return!!this.isValid()&&(this._isUTC&&0===this._offset)}function createDuration(e,t){var n,a,r,s=e,i=null;return isDuration(e)?s={ms:e._milliseconds,d:e._days,M:e._months}:isNumber(e)?(s={},t?s[t]=e:s.milliseconds=e):(i=Te.exec(e))?(n="-"===i[1]?-1:1,s={y:0,d:toInt(i[J])*n,h:toInt(i[G])*n,m:toInt(i[V])*n,s:toInt(i[$])*n,ms:toInt(absRound(1e3*i[q]))*n}):(i=Se.exec(e))?(n="-"===i[1]?-1:1,s={y:parseIso(i[2],n),M:parseIso(i[3],n),w:parseIso(i[4],n),d:parseIso(i[5],n),h:parseIso(i[6],n),m:parseIso(i[7],n),s:parseIso(i[8],n)}):null==s?s={}:"object"==typeof s&&("from"in s||"to"in s)&&(r=momentsDifference(createLocal(s.from),createLocal(s.to)),s={},s.ms=r.milliseconds,s.M=r.months),a=new Duration(s),isDuration(e)&&hasOwnProp(e,"_locale")&&(a._locale=e._locale),a}function parseIso(e,t){var n=e&&parseFloat(e.replace(",","."));return(isNaN(n)?0:n)*t}function positiveMomentsDifference(e,t){var n={milliseconds:0,months:0};return n.months=t.month()-e.month()+12*(t.year()-e.year()),e.clone().add(n.months,"M").isAfter(t)&&--n.months,n.milliseconds=+t-+e.clone().add(n.months,"M"),n}function momentsDifference(e,t){var n;return e.isValid()&&t.isValid()?(t=cloneWithOffset(t,e),e.isBefore(t)?n=positiveMomentsDifference(e,t):(n=positiveMomentsDifference(t,e),n.milliseconds=-n.milliseconds,n.months=-n.months),n):{milliseconds:0,months:0}}function createAdder(e,t){return function(n,a){var r,s;return null===a||isNaN(+a)||(deprecateSimple(t,"moment()."+t+"(period, number) is deprecated. Please use moment()."+t+"(number, period). See http://momentjs.com/guides/#/warnings/add-inverted-param/ for more info."),s=n,n=a,a=s),n="string"==typeof n?+n:n,r=createDuration(n,a),addSubtract(this,r,e),this}}function addSubtract(e,t,n,a){var r=t._milliseconds,s=absRound(t._days),i=absRound(t._months);e.isValid()&&(a=null==a||a,r&&e._d.setTime(e._d.valueOf()+r*n),s&&set$1(e,"Date",get(e,"Date")+s*n),i&&setMonth(e,get(e,"Month")+i*n),a&&hooks.updateOffset(e,s||i))}function getCalendarFormat(e,t){var n=e.diff(t,"days",!0);return n<-6?"sameElse":n<-1?"lastWeek":n<0?"lastDay":n<1?"sameDay":n<2?"nextDay":n<7?"nextWeek":"sameElse"}function calendar$1(e,t){var n=e||createLocal(),a=cloneWithOffset(n,this).startOf("day"),r=hooks.calendarFormat(this,a)||"sameElse",s=t&&(isFunction(t[r])?t[r].call(this,n):t[r]);return this.format(s||this.localeData().calendar(r,this,createLocal(n)))}function clone(){return new Moment(this)}function isAfter(e,t){var n=isMoment(e)?e:createLocal(e);return!(!this.isValid()||!n.isValid())&&(t=normalizeUnits(isUndefined(t)?"millisecond":t),"millisecond"===t?this.valueOf()>n.valueOf():n.valueOf()<this.clone().startOf(t).valueOf())}function isBefore(e,t){var n=isMoment(e)?e:createLocal(e);return!(!this.isValid()||!n.isValid())&&(t=normalizeUnits(isUndefined(t)?"millisecond":t),"millisecond"===t?this.valueOf()<n.valueOf():this.clone().endOf(t).valueOf()<n.valueOf())}function isBetween(e,t,n,a){return a=a||"()",("("===a[0]?this.isAfter(e,n):!this.isBefore(e,n))&&(")"===a[1]?this.isBefore(t,n):!this.isAfter(t,n))}function isSame(e,t){var n,a=isMoment(e)?e:createLocal(e);return!(!this.isValid()||!a.isValid())&&(t=normalizeUnits(t||"millisecond"),"millisecond"===t?this.valueOf()===a.valueOf():(n=a.valueOf(),this.clone().startOf(t).valueOf()<=n&&n<=this.clone().endOf(t).valueOf()))}function isSameOrAfter(e,t){return this.isSame(e,t)||this.isAfter(e,t)}function isSameOrBefore(e,t){return this.isSame(e,t)||this.isBefore(e,t)}function diff(e,t,n){var a,r,s,i;return this.isValid()?(a=cloneWithOffset(e,this),a.isValid()?(r=6e4*(a.utcOffset()-this.utcOffset()),t=normalizeUnits(t),"year"===t||"month"===t||"quarter"===t?(i=monthDiff(this,a),"quarter"===t?i/=3:"year"===t&&(i/=12)):(s=this-a,i="second"===t?s/1e3:"minute"===t?s/6e4:"hour"===t?s/36e5:"day"===t?(s-r)/864e5:"week"===t?(s-r)/6048e5:s),n?i:absFloor(i)):NaN):NaN}function monthDiff(e,t){var n,a,r=12*(t.year()-e.year())+(t.month()-e.month()),s=e.clone().add(r,"months");return t-s<0?(n=e.clone().add(r-1,"months"),a=(t-s)/(s-n)):(n=e.clone().add(r+1,"months"),a=(t-s)/(n-s)),-(r+a)||0}function toString(){return this.clone().locale("en").format("ddd MMM DD YYYY HH:mm:ss [GMT]ZZ")}function toISOString(){var e=this.clone().utc();return 0<e.year()&&e.year()<=9999?isFunction(Date.prototype.toISOString)?this.toDate().toISOString():formatMoment(e,"YYYY-MM-DD[T]HH:mm:ss.SSS[Z]"):formatMoment(e,"YYYYYY-MM-DD[T]HH:mm:ss.SSS[Z]")}function inspect(){if(!this.isValid())return"moment.invalid(/* "+this._i+" */)";var e="moment",t="";this.isLocal()||(e=0===this.utcOffset()?"moment.utc":"moment.parseZone",t="Z");var n="["+e+'("]',a=0<this.year()&&this.year()<=9999?"YYYY":"YYYYYY",r="-MM-DD[T]HH:mm:ss.SSS",s=t+'[")]';return this.format(n+a+r+s)}function format(e){e||(e=this.isUtc()?hooks.defaultFormatUtc:hooks.defaultFormat);var t=formatMoment(this,e);return this.localeData().postformat(t)}function from(e,t){return this.isValid()&&(isMoment(e)&&e.isValid()||createLocal(e).isValid())?createDuration({to:this,from:e}).locale(this.locale()).humanize(!t):this.localeData().invalidDate()}function fromNow(e){return this.from(createLocal(),e)}function to(e,t){return this.isValid()&&(isMoment(e)&&e.isValid()||createLocal(e).isValid())?createDuration({from:this,to:e}).locale(this.locale()).humanize(!t):this.localeData().invalidDate()}function toNow(e){return this.to(createLocal(),e)}function locale(e){var t;return void 0===e?this._locale._abbr:(t=getLocale(e),null!=t&&(this._locale=t),this)}function localeData(){return this._locale}function startOf(e){switch(e=normalizeUnits(e)){case"year":this.month(0);case"quarter":case"month":this.date(1);case"week":case"isoWeek":case"day":case"date":this.hours(0);case"hour":this.minutes(0);case"minute":this.seconds(0);case"second":this.milliseconds(0)}return"week"===e&&this.weekday(0),"isoWeek"===e&&this.isoWeekday(1),"quarter"===e&&this.month(3*Math.floor(this.month()/3)),this}function endOf(e){return e=normalizeUnits(e),void 0===e||"millisecond"===e?this:("date"===e&&(e="day"),this.startOf(e).add(1,"isoWeek"===e?"week":e).subtract(1,"ms"))}function valueOf(){return this._d.valueOf()-6e4*(this._offset||0)}function unix(){return Math.floor(this.valueOf()/1e3)}function toDate(){return new Date(this.valueOf())}function toArray(){var e=this;return[e.year(),e.month(),e.date(),e.hour(),e.minute(),e.second(),e.millisecond()]}function toObject(){var e=this;return{years:e.year(),months:e.month(),date:e.date(),hours:e.hours(),minutes:e.minutes(),seconds:e.seconds(),milliseconds:e.milliseconds()}}function toJSON(){return this.isValid()?this.toISOString():null}function isValid$1(){return isValid(this)}function parsingFlags(){return extend({},getParsingFlags(this))}function invalidAt(){return getParsingFlags(this).overflow}function creationData(){return{input:this._i,format:this._f,locale:this._locale,isUTC:this._isUTC,strict:this._strict}}function addWeekYearFormatToken(e,t){addFormatToken(0,[e,e.length],0,t)}function getSetWeekYear(e){return getSetWeekYearHelper.call(this,e,this.week(),this.weekday(),this.localeData()._week.dow,this.localeData()._week.doy)}function getSetISOWeekYear(e){return getSetWeekYearHelper.call(this,e,this.isoWeek(),this.isoWeekday(),1,4)}function getISOWeeksInYear(){return weeksInYear(this.year(),1,4)}function getWeeksInYear(){var e=this.localeData()._week;return weeksInYear(this.year(),e.dow,e.doy)}function getSetWeekYearHelper(e,t,n,a,r){var s;return null==e?weekOfYear(this,a,r).year:(s=weeksInYear(e,a,r),t>s&&(t=s),setWeekAll.call(this,e,t,n,a,r))}function setWeekAll(e,t,n,a,r){var s=dayOfYearFromWeeks(e,t,n,a,r),i=createUTCDate(s.year,0,s.dayOfYear);return this.year(i.getUTCFullYear()),this.month(i.getUTCMonth()),this.date(i.getUTCDate()),this}function getSetQuarter(e){return null==e?Math.ceil((this.month()+1)/3):this.month(3*(e-1)+this.month()%3)}function getSetDayOfYear(e){var t=Math.round((this.clone().startOf("day")-this.clone().startOf("year"))/864e5)+1;return null==e?t:this.add(e-t,"d")}function parseMs(e,t){t[q]=toInt(1e3*("0."+e))}function getZoneAbbr(){return this._isUTC?"UTC":""}function getZoneName(){return this._isUTC?"Coordinated Universal Time":""}function createUnix(e){return createLocal(1e3*e)}function createInZone(){return createLocal.apply(null,arguments).parseZone()}function preParsePostFormat(e){return e}function get$1(e,t,n,a){var r=getLocale(),s=createUTC().set(a,t);return r[n](s,e)}function listMonthsImpl(e,t,n){if(isNumber(e)&&(t=e,e=void 0),e=e||"",null!=t)return get$1(e,t,n,"month");var a,r=[];for(a=0;a<12;a++)r[a]=get$1(e,a,n,"month");return r}function listWeekdaysImpl(e,t,n,a){"boolean"==typeof e?(isNumber(t)&&(n=t,t=void 0),t=t||""):(t=e,n=t,e=!1,isNumber(t)&&(n=t,t=void 0),t=t||"");var r=getLocale(),s=e?r._week.dow:0;if(null!=n)return get$1(t,(n+s)%7,a,"day");var i,o=[];for(i=0;i<7;i++)o[i]=get$1(t,(i+s)%7,a,"day");return o}function listMonths(e,t){return listMonthsImpl(e,t,"months")}function listMonthsShort(e,t){return listMonthsImpl(e,t,"monthsShort")}function listWeekdays(e,t,n){return listWeekdaysImpl(e,t,n,"weekdays")}function listWeekdaysShort(e,t,n){return listWeekdaysImpl(e,t,n,"weekdaysShort")}function listWeekdaysMin(e,t,n){return listWeekdaysImpl(e,t,n,"weekdaysMin")}function abs(){var e=this._data;return this._milliseconds=Ee(this._milliseconds),this._days=Ee(this._days),this._months=Ee(this._months),e.milliseconds=Ee(e.milliseconds),e.seconds=Ee(e.seconds),e.minutes=Ee(e.minutes),e.hours=Ee(e.hours),e.months=Ee(e.months),e.years=Ee(e.years),this}function addSubtract$1(e,t,n,a){var r=createDuration(t,n);return e._milliseconds+=a*r._milliseconds,e._days+=a*r._days,e._months+=a*r._months,e._bubble()}function add$1(e,t){return addSubtract$1(this,e,t,1)}function subtract$1(e,t){return addSubtract$1(this,e,t,-1)}function absCeil(e){return e<0?Math.floor(e):Math.ceil(e)}function bubble(){var e,t,n,a,r,s=this._milliseconds,i=this._days,o=this._months,d=this._data;return s>=0&&i>=0&&o>=0||s<=0&&i<=0&&o<=0||(s+=864e5*absCeil(monthsToDays(o)+i),i=0,o=0),d.milliseconds=s%1e3,e=absFloor(s/1e3),d.seconds=e%60,t=absFloor(e/60),d.minutes=t%60,n=absFloor(t/60),d.hours=n%24,i+=absFloor(n/24),r=absFloor(daysToMonths(i)),o+=r,i-=absCeil(monthsToDays(r)),a=absFloor(o/12),o%=12,d.days=i,d.months=o,d.years=a,this}function daysToMonths(e){return 4800*e/146097}function monthsToDays(e){return 146097*e/4800}function as(e){var t,n,a=this._milliseconds;if(e=normalizeUnits(e),"month"===e||"year"===e)return t=this._days+a/864e5,n=this._months+daysToMonths(t),"month"===e?n:n/12;switch(t=this._days+Math.round(monthsToDays(this._months)),e){case"week":return t/7+a/6048e5;case"day":return t+a/864e5;case"hour":return 24*t+a/36e5;case"minute":return 1440*t+a/6e4;case"second":return 86400*t+a/1e3;case"millisecond":return Math.floor(864e5*t)+a;default:throw new Error("Unknown unit "+e)}
-}function valueOf$1(){return this._milliseconds+864e5*this._days+this._months%12*2592e6+31536e6*toInt(this._months/12)}function makeAs(e){return function(){return this.as(e)}}function get$2(e){return e=normalizeUnits(e),this[e+"s"]()}function makeGetter(e){return function(){return this._data[e]}}function weeks(){return absFloor(this.days()/7)}function substituteTimeAgo(e,t,n,a,r){return r.relativeTime(t||1,!!n,e,a)}function relativeTime$1(e,t,n){var a=createDuration(e).abs(),r=tt(a.as("s")),s=tt(a.as("m")),i=tt(a.as("h")),o=tt(a.as("d")),d=tt(a.as("M")),u=tt(a.as("y")),l=r<nt.s&&["s",r]||s<=1&&["m"]||s<nt.m&&["mm",s]||i<=1&&["h"]||i<nt.h&&["hh",i]||o<=1&&["d"]||o<nt.d&&["dd",o]||d<=1&&["M"]||d<nt.M&&["MM",d]||u<=1&&["y"]||["yy",u];return l[2]=t,l[3]=+e>0,l[4]=n,substituteTimeAgo.apply(null,l)}function getSetRelativeTimeRounding(e){return void 0===e?tt:"function"==typeof e&&(tt=e,!0)}function getSetRelativeTimeThreshold(e,t){return void 0!==nt[e]&&(void 0===t?nt[e]:(nt[e]=t,!0))}function humanize(e){var t=this.localeData(),n=relativeTime$1(this,!e,t);return e&&(n=t.pastFuture(+this,n)),t.postformat(n)}function toISOString$1(){var e,t,n,a=at(this._milliseconds)/1e3,r=at(this._days),s=at(this._months);e=absFloor(a/60),t=absFloor(e/60),a%=60,e%=60,n=absFloor(s/12),s%=12;var i=n,o=s,d=r,u=t,l=e,c=a,_=this.asSeconds();return _?(_<0?"-":"")+"P"+(i?i+"Y":"")+(o?o+"M":"")+(d?d+"D":"")+(u||l||c?"T":"")+(u?u+"H":"")+(l?l+"M":"")+(c?c+"S":""):"P0D"}var t,a;a=Array.prototype.some?Array.prototype.some:function(e){for(var t=Object(this),n=t.length>>>0,a=0;a<n;a++)if(a in t&&e.call(this,t[a],a,t))return!0;return!1};var r=a,s=hooks.momentProperties=[],i=!1,o={};hooks.suppressDeprecationWarnings=!1,hooks.deprecationHandler=null;var d;d=Object.keys?Object.keys:function(e){var t,n=[];for(t in e)hasOwnProp(e,t)&&n.push(t);return n};var u,l=d,c={sameDay:"[Today at] LT",nextDay:"[Tomorrow at] LT",nextWeek:"dddd [at] LT",lastDay:"[Yesterday at] LT",lastWeek:"[Last] dddd [at] LT",sameElse:"L"},_={LTS:"h:mm:ss A",LT:"h:mm A",L:"MM/DD/YYYY",LL:"MMMM D, YYYY",LLL:"MMMM D, YYYY h:mm A",LLLL:"dddd, MMMM D, YYYY h:mm A"},m="Invalid date",h="%d",p=/\d{1,2}/,f={future:"in %s",past:"%s ago",s:"a few seconds",m:"a minute",mm:"%d minutes",h:"an hour",hh:"%d hours",d:"a day",dd:"%d days",M:"a month",MM:"%d months",y:"a year",yy:"%d years"},M={},y={},L=/(\[[^\[]*\])|(\\)?([Hh]mm(ss)?|Mo|MM?M?M?|Do|DDDo|DD?D?D?|ddd?d?|do?|w[o|w]?|W[o|W]?|Qo?|YYYYYY|YYYYY|YYYY|YY|gg(ggg?)?|GG(GGG?)?|e|E|a|A|hh?|HH?|kk?|mm?|ss?|S{1,9}|x|X|zz?|ZZ?|.)/g,Y=/(\[[^\[]*\])|(\\)?(LTS|LT|LL?L?L?|l{1,4})/g,v={},g={},k=/\d/,b=/\d\d/,D=/\d{3}/,w=/\d{4}/,T=/[+-]?\d{6}/,S=/\d\d?/,j=/\d\d\d\d?/,H=/\d\d\d\d\d\d?/,P=/\d{1,3}/,x=/\d{1,4}/,F=/[+-]?\d{1,6}/,R=/\d+/,O=/[+-]?\d+/,A=/Z|[+-]\d\d:?\d\d/gi,W=/Z|[+-]\d\d(?::?\d\d)?/gi,I=/[+-]?\d+(\.\d{1,3})?/,E=/[0-9]*['a-z\u00A0-\u05FF\u0700-\uD7FF\uF900-\uFDCF\uFDF0-\uFFEF]+|[\u0600-\u06FF\/]+(\s*?[\u0600-\u06FF]+){1,2}/i,C={},U={},z=0,N=1,J=2,G=3,V=4,$=5,q=6,B=7,Z=8;u=Array.prototype.indexOf?Array.prototype.indexOf:function(e){var t;for(t=0;t<this.length;++t)if(this[t]===e)return t;return-1};var K=u;addFormatToken("M",["MM",2],"Mo",function(){return this.month()+1}),addFormatToken("MMM",0,0,function(e){return this.localeData().monthsShort(this,e)}),addFormatToken("MMMM",0,0,function(e){return this.localeData().months(this,e)}),addUnitAlias("month","M"),addUnitPriority("month",8),addRegexToken("M",S),addRegexToken("MM",S,b),addRegexToken("MMM",function(e,t){return t.monthsShortRegex(e)}),addRegexToken("MMMM",function(e,t){return t.monthsRegex(e)}),addParseToken(["M","MM"],function(e,t){t[N]=toInt(e)-1}),addParseToken(["MMM","MMMM"],function(e,t,n,a){var r=n._locale.monthsParse(e,a,n._strict);null!=r?t[N]=r:getParsingFlags(n).invalidMonth=e});var Q=/D[oD]?(\[[^\[\]]*\]|\s)+MMMM?/,X="January_February_March_April_May_June_July_August_September_October_November_December".split("_"),ee="Jan_Feb_Mar_Apr_May_Jun_Jul_Aug_Sep_Oct_Nov_Dec".split("_"),te=E,ne=E;addFormatToken("Y",0,0,function(){var e=this.year();return e<=9999?""+e:"+"+e}),addFormatToken(0,["YY",2],0,function(){return this.year()%100}),addFormatToken(0,["YYYY",4],0,"year"),addFormatToken(0,["YYYYY",5],0,"year"),addFormatToken(0,["YYYYYY",6,!0],0,"year"),addUnitAlias("year","y"),addUnitPriority("year",1),addRegexToken("Y",O),addRegexToken("YY",S,b),addRegexToken("YYYY",x,w),addRegexToken("YYYYY",F,T),addRegexToken("YYYYYY",F,T),addParseToken(["YYYYY","YYYYYY"],z),addParseToken("YYYY",function(e,t){t[z]=2===e.length?hooks.parseTwoDigitYear(e):toInt(e)}),addParseToken("YY",function(e,t){t[z]=hooks.parseTwoDigitYear(e)}),addParseToken("Y",function(e,t){t[z]=parseInt(e,10)}),hooks.parseTwoDigitYear=function(e){return toInt(e)+(toInt(e)>68?1900:2e3)};var ae=makeGetSet("FullYear",!0);addFormatToken("w",["ww",2],"wo","week"),addFormatToken("W",["WW",2],"Wo","isoWeek"),addUnitAlias("week","w"),addUnitAlias("isoWeek","W"),addUnitPriority("week",5),addUnitPriority("isoWeek",5),addRegexToken("w",S),addRegexToken("ww",S,b),addRegexToken("W",S),addRegexToken("WW",S,b),addWeekParseToken(["w","ww","W","WW"],function(e,t,n,a){t[a.substr(0,1)]=toInt(e)});var re={dow:0,doy:6};addFormatToken("d",0,"do","day"),addFormatToken("dd",0,0,function(e){return this.localeData().weekdaysMin(this,e)}),addFormatToken("ddd",0,0,function(e){return this.localeData().weekdaysShort(this,e)}),addFormatToken("dddd",0,0,function(e){return this.localeData().weekdays(this,e)}),addFormatToken("e",0,0,"weekday"),addFormatToken("E",0,0,"isoWeekday"),addUnitAlias("day","d"),addUnitAlias("weekday","e"),addUnitAlias("isoWeekday","E"),addUnitPriority("day",11),addUnitPriority("weekday",11),addUnitPriority("isoWeekday",11),addRegexToken("d",S),addRegexToken("e",S),addRegexToken("E",S),addRegexToken("dd",function(e,t){return t.weekdaysMinRegex(e)}),addRegexToken("ddd",function(e,t){return t.weekdaysShortRegex(e)}),addRegexToken("dddd",function(e,t){return t.weekdaysRegex(e)}),addWeekParseToken(["dd","ddd","dddd"],function(e,t,n,a){var r=n._locale.weekdaysParse(e,a,n._strict);null!=r?t.d=r:getParsingFlags(n).invalidWeekday=e}),addWeekParseToken(["d","e","E"],function(e,t,n,a){t[a]=toInt(e)});var se="Sunday_Monday_Tuesday_Wednesday_Thursday_Friday_Saturday".split("_"),ie="Sun_Mon_Tue_Wed_Thu_Fri_Sat".split("_"),oe="Su_Mo_Tu_We_Th_Fr_Sa".split("_"),de=E,ue=E,le=E;addFormatToken("H",["HH",2],0,"hour"),addFormatToken("h",["hh",2],0,hFormat),addFormatToken("k",["kk",2],0,kFormat),addFormatToken("hmm",0,0,function(){return""+hFormat.apply(this)+zeroFill(this.minutes(),2)}),addFormatToken("hmmss",0,0,function(){return""+hFormat.apply(this)+zeroFill(this.minutes(),2)+zeroFill(this.seconds(),2)}),addFormatToken("Hmm",0,0,function(){return""+this.hours()+zeroFill(this.minutes(),2)}),addFormatToken("Hmmss",0,0,function(){return""+this.hours()+zeroFill(this.minutes(),2)+zeroFill(this.seconds(),2)}),meridiem("a",!0),meridiem("A",!1),addUnitAlias("hour","h"),addUnitPriority("hour",13),addRegexToken("a",matchMeridiem),addRegexToken("A",matchMeridiem),addRegexToken("H",S),addRegexToken("h",S),addRegexToken("HH",S,b),addRegexToken("hh",S,b),addRegexToken("hmm",j),addRegexToken("hmmss",H),addRegexToken("Hmm",j),addRegexToken("Hmmss",H),addParseToken(["H","HH"],G),addParseToken(["a","A"],function(e,t,n){n._isPm=n._locale.isPM(e),n._meridiem=e}),addParseToken(["h","hh"],function(e,t,n){t[G]=toInt(e),getParsingFlags(n).bigHour=!0}),addParseToken("hmm",function(e,t,n){var a=e.length-2;t[G]=toInt(e.substr(0,a)),t[V]=toInt(e.substr(a)),getParsingFlags(n).bigHour=!0}),addParseToken("hmmss",function(e,t,n){var a=e.length-4,r=e.length-2;t[G]=toInt(e.substr(0,a)),t[V]=toInt(e.substr(a,2)),t[$]=toInt(e.substr(r)),getParsingFlags(n).bigHour=!0}),addParseToken("Hmm",function(e,t,n){var a=e.length-2;t[G]=toInt(e.substr(0,a)),t[V]=toInt(e.substr(a))}),addParseToken("Hmmss",function(e,t,n){var a=e.length-4,r=e.length-2;t[G]=toInt(e.substr(0,a)),t[V]=toInt(e.substr(a,2)),t[$]=toInt(e.substr(r))});var ce,_e=/[ap]\.?m?\.?/i,me=makeGetSet("Hours",!0),he={calendar:c,longDateFormat:_,invalidDate:m,ordinal:h,ordinalParse:p,relativeTime:f,months:X,monthsShort:ee,week:re,weekdays:se,weekdaysMin:oe,weekdaysShort:ie,meridiemParse:_e},pe={},fe={},Me=/^\s*((?:[+-]\d{6}|\d{4})-(?:\d\d-\d\d|W\d\d-\d|W\d\d|\d\d\d|\d\d))(?:(T| )(\d\d(?::\d\d(?::\d\d(?:[.,]\d+)?)?)?)([\+\-]\d\d(?::?\d\d)?|\s*Z)?)?$/,ye=/^\s*((?:[+-]\d{6}|\d{4})(?:\d\d\d\d|W\d\d\d|W\d\d|\d\d\d|\d\d))(?:(T| )(\d\d(?:\d\d(?:\d\d(?:[.,]\d+)?)?)?)([\+\-]\d\d(?::?\d\d)?|\s*Z)?)?$/,Le=/Z|[+-]\d\d(?::?\d\d)?/,Ye=[["YYYYYY-MM-DD",/[+-]\d{6}-\d\d-\d\d/],["YYYY-MM-DD",/\d{4}-\d\d-\d\d/],["GGGG-[W]WW-E",/\d{4}-W\d\d-\d/],["GGGG-[W]WW",/\d{4}-W\d\d/,!1],["YYYY-DDD",/\d{4}-\d{3}/],["YYYY-MM",/\d{4}-\d\d/,!1],["YYYYYYMMDD",/[+-]\d{10}/],["YYYYMMDD",/\d{8}/],["GGGG[W]WWE",/\d{4}W\d{3}/],["GGGG[W]WW",/\d{4}W\d{2}/,!1],["YYYYDDD",/\d{7}/]],ve=[["HH:mm:ss.SSSS",/\d\d:\d\d:\d\d\.\d+/],["HH:mm:ss,SSSS",/\d\d:\d\d:\d\d,\d+/],["HH:mm:ss",/\d\d:\d\d:\d\d/],["HH:mm",/\d\d:\d\d/],["HHmmss.SSSS",/\d\d\d\d\d\d\.\d+/],["HHmmss,SSSS",/\d\d\d\d\d\d,\d+/],["HHmmss",/\d\d\d\d\d\d/],["HHmm",/\d\d\d\d/],["HH",/\d\d/]],ge=/^\/?Date\((\-?\d+)/i;hooks.createFromInputFallback=deprecate("value provided is not in a recognized ISO format. moment construction falls back to js Date(), which is not reliable across all browsers and versions. Non ISO date formats are discouraged and will be removed in an upcoming major release. Please refer to http://momentjs.com/guides/#/warnings/js-date/ for more info.",function(e){e._d=new Date(e._i+(e._useUTC?" UTC":""))}),hooks.ISO_8601=function(){};var ke=deprecate("moment().min is deprecated, use moment.max instead. http://momentjs.com/guides/#/warnings/min-max/",function(){var e=createLocal.apply(null,arguments);return this.isValid()&&e.isValid()?e<this?this:e:createInvalid()}),be=deprecate("moment().max is deprecated, use moment.min instead. http://momentjs.com/guides/#/warnings/min-max/",function(){var e=createLocal.apply(null,arguments);return this.isValid()&&e.isValid()?e>this?this:e:createInvalid()}),De=function(){return Date.now?Date.now():+new Date};offset("Z",":"),offset("ZZ",""),addRegexToken("Z",W),addRegexToken("ZZ",W),addParseToken(["Z","ZZ"],function(e,t,n){n._useUTC=!0,n._tzm=offsetFromString(W,e)});var we=/([\+\-]|\d\d)/gi;hooks.updateOffset=function(){};var Te=/^(\-)?(?:(\d*)[. ])?(\d+)\:(\d+)(?:\:(\d+)(\.\d*)?)?$/,Se=/^(-)?P(?:(-?[0-9,.]*)Y)?(?:(-?[0-9,.]*)M)?(?:(-?[0-9,.]*)W)?(?:(-?[0-9,.]*)D)?(?:T(?:(-?[0-9,.]*)H)?(?:(-?[0-9,.]*)M)?(?:(-?[0-9,.]*)S)?)?$/;createDuration.fn=Duration.prototype;var je=createAdder(1,"add"),He=createAdder(-1,"subtract");hooks.defaultFormat="YYYY-MM-DDTHH:mm:ssZ",hooks.defaultFormatUtc="YYYY-MM-DDTHH:mm:ss[Z]";var Pe=deprecate("moment().lang() is deprecated. Instead, use moment().localeData() to get the language configuration. Use moment().locale() to change languages.",function(e){return void 0===e?this.localeData():this.locale(e)});addFormatToken(0,["gg",2],0,function(){return this.weekYear()%100}),addFormatToken(0,["GG",2],0,function(){return this.isoWeekYear()%100}),addWeekYearFormatToken("gggg","weekYear"),addWeekYearFormatToken("ggggg","weekYear"),addWeekYearFormatToken("GGGG","isoWeekYear"),addWeekYearFormatToken("GGGGG","isoWeekYear"),addUnitAlias("weekYear","gg"),addUnitAlias("isoWeekYear","GG"),addUnitPriority("weekYear",1),addUnitPriority("isoWeekYear",1),addRegexToken("G",O),addRegexToken("g",O),addRegexToken("GG",S,b),addRegexToken("gg",S,b),addRegexToken("GGGG",x,w),addRegexToken("gggg",x,w),addRegexToken("GGGGG",F,T),addRegexToken("ggggg",F,T),addWeekParseToken(["gggg","ggggg","GGGG","GGGGG"],function(e,t,n,a){t[a.substr(0,2)]=toInt(e)}),addWeekParseToken(["gg","GG"],function(e,t,n,a){t[a]=hooks.parseTwoDigitYear(e)}),addFormatToken("Q",0,"Qo","quarter"),addUnitAlias("quarter","Q"),addUnitPriority("quarter",7),addRegexToken("Q",k),addParseToken("Q",function(e,t){t[N]=3*(toInt(e)-1)}),addFormatToken("D",["DD",2],"Do","date"),addUnitAlias("date","D"),addUnitPriority("date",9),addRegexToken("D",S),addRegexToken("DD",S,b),addRegexToken("Do",function(e,t){return e?t._ordinalParse:t._ordinalParseLenient}),addParseToken(["D","DD"],J),addParseToken("Do",function(e,t){t[J]=toInt(e.match(S)[0],10)});var xe=makeGetSet("Date",!0);addFormatToken("DDD",["DDDD",3],"DDDo","dayOfYear"),addUnitAlias("dayOfYear","DDD"),addUnitPriority("dayOfYear",4),addRegexToken("DDD",P),addRegexToken("DDDD",D),addParseToken(["DDD","DDDD"],function(e,t,n){n._dayOfYear=toInt(e)}),addFormatToken("m",["mm",2],0,"minute"),addUnitAlias("minute","m"),addUnitPriority("minute",14),addRegexToken("m",S),addRegexToken("mm",S,b),addParseToken(["m","mm"],V);var Fe=makeGetSet("Minutes",!1);addFormatToken("s",["ss",2],0,"second"),addUnitAlias("second","s"),addUnitPriority("second",15),addRegexToken("s",S),addRegexToken("ss",S,b),addParseToken(["s","ss"],$);var Re=makeGetSet("Seconds",!1);addFormatToken("S",0,0,function(){return~~(this.millisecond()/100)}),addFormatToken(0,["SS",2],0,function(){return~~(this.millisecond()/10)}),addFormatToken(0,["SSS",3],0,"millisecond"),addFormatToken(0,["SSSS",4],0,function(){return 10*this.millisecond()}),addFormatToken(0,["SSSSS",5],0,function(){return 100*this.millisecond()}),addFormatToken(0,["SSSSSS",6],0,function(){return 1e3*this.millisecond()}),addFormatToken(0,["SSSSSSS",7],0,function(){return 1e4*this.millisecond()}),addFormatToken(0,["SSSSSSSS",8],0,function(){return 1e5*this.millisecond()}),addFormatToken(0,["SSSSSSSSS",9],0,function(){return 1e6*this.millisecond()}),addUnitAlias("millisecond","ms"),addUnitPriority("millisecond",16),addRegexToken("S",P,k),addRegexToken("SS",P,b),addRegexToken("SSS",P,D);var Oe;for(Oe="SSSS";Oe.length<=9;Oe+="S")addRegexToken(Oe,R);for(Oe="S";Oe.length<=9;Oe+="S")addParseToken(Oe,parseMs);var Ae=makeGetSet("Milliseconds",!1);addFormatToken("z",0,0,"zoneAbbr"),addFormatToken("zz",0,0,"zoneName");var We=Moment.prototype;We.add=je,We.calendar=calendar$1,We.clone=clone,We.diff=diff,We.endOf=endOf,We.format=format,We.from=from,We.fromNow=fromNow,We.to=to,We.toNow=toNow,We.get=stringGet,We.invalidAt=invalidAt,We.isAfter=isAfter,We.isBefore=isBefore,We.isBetween=isBetween,We.isSame=isSame,We.isSameOrAfter=isSameOrAfter,We.isSameOrBefore=isSameOrBefore,We.isValid=isValid$1,We.lang=Pe,We.locale=locale,We.localeData=localeData,We.max=be,We.min=ke,We.parsingFlags=parsingFlags,We.set=stringSet,We.startOf=startOf,We.subtract=He,We.toArray=toArray,We.toObject=toObject,We.toDate=toDate,We.toISOString=toISOString,We.inspect=inspect,We.toJSON=toJSON,We.toString=toString,We.unix=unix,We.valueOf=valueOf,We.creationData=creationData,We.year=ae,We.isLeapYear=getIsLeapYear,We.weekYear=getSetWeekYear,We.isoWeekYear=getSetISOWeekYear,We.quarter=We.quarters=getSetQuarter,We.month=getSetMonth,We.daysInMonth=getDaysInMonth,We.week=We.weeks=getSetWeek,We.isoWeek=We.isoWeeks=getSetISOWeek,We.weeksInYear=getWeeksInYear,We.isoWeeksInYear=getISOWeeksInYear,We.date=xe,We.day=We.days=getSetDayOfWeek,We.weekday=getSetLocaleDayOfWeek,We.isoWeekday=getSetISODayOfWeek,We.dayOfYear=getSetDayOfYear,We.hour=We.hours=me,We.minute=We.minutes=Fe,We.second=We.seconds=Re,We.millisecond=We.milliseconds=Ae,We.utcOffset=getSetOffset,We.utc=setOffsetToUTC,We.local=setOffsetToLocal,We.parseZone=setOffsetToParsedOffset,We.hasAlignedHourOffset=hasAlignedHourOffset,We.isDST=isDaylightSavingTime,We.isLocal=isLocal,We.isUtcOffset=isUtcOffset,We.isUtc=isUtc,We.isUTC=isUtc,We.zoneAbbr=getZoneAbbr,We.zoneName=getZoneName,We.dates=deprecate("dates accessor is deprecated. Use date instead.",xe),We.months=deprecate("months accessor is deprecated. Use month instead",getSetMonth),We.years=deprecate("years accessor is deprecated. Use year instead",ae),We.zone=deprecate("moment().zone is deprecated, use moment().utcOffset instead. http://momentjs.com/guides/#/warnings/zone/",getSetZone),We.isDSTShifted=deprecate("isDSTShifted is deprecated. See http://momentjs.com/guides/#/warnings/dst-shifted/ for more information",isDaylightSavingTimeShifted);var Ie=Locale.prototype;Ie.calendar=calendar,Ie.longDateFormat=longDateFormat,Ie.invalidDate=invalidDate,Ie.ordinal=ordinal,Ie.preparse=preParsePostFormat,Ie.postformat=preParsePostFormat,Ie.relativeTime=relativeTime,Ie.pastFuture=pastFuture,Ie.set=set,Ie.months=localeMonths,Ie.monthsShort=localeMonthsShort,Ie.monthsParse=localeMonthsParse,Ie.monthsRegex=monthsRegex,Ie.monthsShortRegex=monthsShortRegex,Ie.week=localeWeek,Ie.firstDayOfYear=localeFirstDayOfYear,Ie.firstDayOfWeek=localeFirstDayOfWeek,Ie.weekdays=localeWeekdays,Ie.weekdaysMin=localeWeekdaysMin,Ie.weekdaysShort=localeWeekdaysShort,Ie.weekdaysParse=localeWeekdaysParse,Ie.weekdaysRegex=weekdaysRegex,Ie.weekdaysShortRegex=weekdaysShortRegex,Ie.weekdaysMinRegex=weekdaysMinRegex,Ie.isPM=localeIsPM,Ie.meridiem=localeMeridiem,getSetGlobalLocale("en",{ordinalParse:/\d{1,2}(th|st|nd|rd)/,ordinal:function(e){var t=e%10,n=1===toInt(e%100/10)?"th":1===t?"st":2===t?"nd":3===t?"rd":"th";return e+n}}),hooks.lang=deprecate("moment.lang is deprecated. Use moment.locale instead.",getSetGlobalLocale),hooks.langData=deprecate("moment.langData is deprecated. Use moment.localeData instead.",getLocale);var Ee=Math.abs,Ce=makeAs("ms"),Ue=makeAs("s"),ze=makeAs("m"),Ne=makeAs("h"),Je=makeAs("d"),Ge=makeAs("w"),Ve=makeAs("M"),$e=makeAs("y"),qe=makeGetter("milliseconds"),Be=makeGetter("seconds"),Ze=makeGetter("minutes"),Ke=makeGetter("hours"),Qe=makeGetter("days"),Xe=makeGetter("months"),et=makeGetter("years"),tt=Math.round,nt={s:45,m:45,h:22,d:26,M:11},at=Math.abs,rt=Duration.prototype;return rt.abs=abs,rt.add=add$1,rt.subtract=subtract$1,rt.as=as,rt.asMilliseconds=Ce,rt.asSeconds=Ue,rt.asMinutes=ze,rt.asHours=Ne,rt.asDays=Je,rt.asWeeks=Ge,rt.asMonths=Ve,rt.asYears=$e,rt.valueOf=valueOf$1,rt._bubble=bubble,rt.get=get$2,rt.milliseconds=qe,rt.seconds=Be,rt.minutes=Ze,rt.hours=Ke,rt.days=Qe,rt.weeks=weeks,rt.months=Xe,rt.years=et,rt.humanize=humanize,rt.toISOString=toISOString$1,rt.toString=toISOString$1,rt.toJSON=toISOString$1,rt.locale=locale,rt.localeData=localeData,rt.toIsoString=deprecate("toIsoString() is deprecated. Please use toISOString() instead (notice the capitals)",toISOString$1),rt.lang=Pe,addFormatToken("X",0,0,"unix"),addFormatToken("x",0,0,"valueOf"),addRegexToken("x",O),addRegexToken("X",I),addParseToken("X",function(e,t,n){n._d=new Date(1e3*parseFloat(e,10))}),addParseToken("x",function(e,t,n){n._d=new Date(toInt(e))}),hooks.version="2.17.1",setHookCallback(createLocal),hooks.fn=We,hooks.min=min,hooks.max=max,hooks.now=De,hooks.utc=createUTC,hooks.unix=createUnix,hooks.months=listMonths,hooks.isDate=isDate,hooks.locale=getSetGlobalLocale,hooks.invalid=createInvalid,hooks.duration=createDuration,hooks.isMoment=isMoment,hooks.weekdays=listWeekdays,hooks.parseZone=createInZone,hooks.localeData=getLocale,hooks.isDuration=isDuration,hooks.monthsShort=listMonthsShort,hooks.weekdaysMin=listWeekdaysMin,hooks.defineLocale=defineLocale,hooks.updateLocale=updateLocale,hooks.locales=listLocales,hooks.weekdaysShort=listWeekdaysShort,hooks.normalizeUnits=normalizeUnits,hooks.relativeTimeRounding=getSetRelativeTimeRounding,hooks.relativeTimeThreshold=getSetRelativeTimeThreshold,hooks.calendarFormat=getCalendarFormat,hooks.prototype=We,hooks})}).call(t,n(1205)(e))},286:function(e,t,n){"use strict";function emptyCredentials(){return{username:"",password:""}}var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},i=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},o=n(0),d=n(153),u=n(940);t.emptyCredentials=emptyCredentials;var l=function(){function AuthService(e){this.http=e}return AuthService.prototype.authenticate=function(e){return s(this,void 0,void 0,function(){var t,n,a;return i(this,function(r){switch(r.label){case 0:return r.trys.push([0,2,,3]),[4,this.http.post("/api/authenticate",e).toPromise()];case 1:return t=r.sent(),n=t.json(),window.sessionStorage.setItem("jwt",n.token),[3,3];case 2:if(a=r.sent(),a instanceof d.Response)throw new Error(a.json().message);throw a;case 3:return[2]}})})},Object.defineProperty(AuthService.prototype,"session",{get:function(){var e=sessionStorage.getItem("jwt");return e?u(sessionStorage.getItem("jwt")):null},enumerable:!0,configurable:!0}),AuthService}();l=a([o.Injectable(),r("design:paramtypes",[d.Http])],l),t.AuthService=l},452:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=n(0),s=function(){function AppComponent(){}return AppComponent}();s=a([r.Component({template:n(927),selector:"app"})],s),t.AppComponent=s},453:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=n(0),i=n(54),o=function(){function AuthGuard(e){this.router=e}return AuthGuard.prototype.canActivate=function(){return!!window.sessionStorage.getItem("jwt")||(this.router.navigate(["/login"]),!1)},AuthGuard}();o=a([s.Injectable(),r("design:paramtypes",[i.Router])],o),t.AuthGuard=o},454:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=n(0),i=n(54),o=function(){function AutoLoginGuard(e){this.router=e}return AutoLoginGuard.prototype.canActivate=function(){return!window.sessionStorage.getItem("jwt")||(this.router.navigate(["/home"]),!0)},AutoLoginGuard}();o=a([s.Injectable(),r("design:paramtypes",[i.Router])],o),t.AutoLoginGuard=o},455:function(e,t,n){"use strict";var a=this&&this.__extends||function(e,t){function __(){this.constructor=e}for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n]);e.prototype=null===t?Object.create(t):(__.prototype=t.prototype,new __)},r=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},s=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},i=n(0),o=n(457),d=n(140),u=function(e){function DosesResolve(t){return e.call(this,t)||this}return a(DosesResolve,e),DosesResolve}(o.NestedListResolve);u=r([i.Injectable(),s("design:paramtypes",[d.DoseService])],u),t.DosesResolve=u},456:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},i=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},o=n(0),d=n(194),u=function(){function DoseSummariesResolve(e){this.doseSummaryService=e}return DoseSummariesResolve.prototype.resolve=function(e){return s(this,void 0,void 0,function(){var t,n;return i(this,function(a){switch(a.label){case 0:for(t=e;!t.params.id;)t=t.parent;return n=t.params.id,[4,this.doseSummaryService.listDoseSummaries(parseInt(n))];case 1:return[2,a.sent()]}})})},DoseSummariesResolve}();u=a([o.Injectable(),r("design:paramtypes",[d.DoseSummaryService])],u),t.DoseSummariesResolve=u},457:function(e,t,n){"use strict";var a=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},r=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},s=function(){function ListResolve(e){this.apiService=e}return ListResolve.prototype.resolve=function(){return a(this,void 0,void 0,function(){return r(this,function(e){switch(e.label){case 0:return[4,this.apiService.list()];case 1:return[2,e.sent()]}})})},ListResolve}();t.ListResolve=s;var i=function(){function NestedListResolve(e){this.apiService=e,this.superIdProperty="id"}return NestedListResolve.prototype.resolve=function(e){return a(this,void 0,void 0,function(){var t,n;return r(this,function(a){switch(a.label){case 0:for(t=e;!t.params[this.superIdProperty];)t=t.parent;return n=t.params[this.superIdProperty],[4,this.apiService.list(parseInt(n))];case 1:return[2,a.sent()]}})})},NestedListResolve}();t.NestedListResolve=i},458:function(e,t,n){"use strict";var a=this&&this.__extends||function(e,t){function __(){this.constructor=e}for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n]);e.prototype=null===t?Object.create(t):(__.prototype=t.prototype,new __)},r=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},s=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},i=n(0),o=n(457),d=n(89),u=n(459),l=function(e){function MedicationsResolve(t){return e.call(this,t)||this}return a(MedicationsResolve,e),MedicationsResolve}(o.ListResolve);l=r([i.Injectable(),s("design:paramtypes",[d.MedicationService])],l),t.MedicationsResolve=l;var c=function(e){function MedicationResolve(t){return e.call(this,t)||this}return a(MedicationResolve,e),MedicationResolve}(u.SingleResolve);c=r([i.Injectable(),s("design:paramtypes",[d.MedicationService])],c),t.MedicationResolve=c},459:function(e,t,n){"use strict";var a=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},r=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,
+}function valueOf$1(){return this._milliseconds+864e5*this._days+this._months%12*2592e6+31536e6*toInt(this._months/12)}function makeAs(e){return function(){return this.as(e)}}function get$2(e){return e=normalizeUnits(e),this[e+"s"]()}function makeGetter(e){return function(){return this._data[e]}}function weeks(){return absFloor(this.days()/7)}function substituteTimeAgo(e,t,n,a,r){return r.relativeTime(t||1,!!n,e,a)}function relativeTime$1(e,t,n){var a=createDuration(e).abs(),r=tt(a.as("s")),s=tt(a.as("m")),i=tt(a.as("h")),o=tt(a.as("d")),d=tt(a.as("M")),u=tt(a.as("y")),l=r<nt.s&&["s",r]||s<=1&&["m"]||s<nt.m&&["mm",s]||i<=1&&["h"]||i<nt.h&&["hh",i]||o<=1&&["d"]||o<nt.d&&["dd",o]||d<=1&&["M"]||d<nt.M&&["MM",d]||u<=1&&["y"]||["yy",u];return l[2]=t,l[3]=+e>0,l[4]=n,substituteTimeAgo.apply(null,l)}function getSetRelativeTimeRounding(e){return void 0===e?tt:"function"==typeof e&&(tt=e,!0)}function getSetRelativeTimeThreshold(e,t){return void 0!==nt[e]&&(void 0===t?nt[e]:(nt[e]=t,!0))}function humanize(e){var t=this.localeData(),n=relativeTime$1(this,!e,t);return e&&(n=t.pastFuture(+this,n)),t.postformat(n)}function toISOString$1(){var e,t,n,a=at(this._milliseconds)/1e3,r=at(this._days),s=at(this._months);e=absFloor(a/60),t=absFloor(e/60),a%=60,e%=60,n=absFloor(s/12),s%=12;var i=n,o=s,d=r,u=t,l=e,c=a,_=this.asSeconds();return _?(_<0?"-":"")+"P"+(i?i+"Y":"")+(o?o+"M":"")+(d?d+"D":"")+(u||l||c?"T":"")+(u?u+"H":"")+(l?l+"M":"")+(c?c+"S":""):"P0D"}var t,a;a=Array.prototype.some?Array.prototype.some:function(e){for(var t=Object(this),n=t.length>>>0,a=0;a<n;a++)if(a in t&&e.call(this,t[a],a,t))return!0;return!1};var r=a,s=hooks.momentProperties=[],i=!1,o={};hooks.suppressDeprecationWarnings=!1,hooks.deprecationHandler=null;var d;d=Object.keys?Object.keys:function(e){var t,n=[];for(t in e)hasOwnProp(e,t)&&n.push(t);return n};var u,l=d,c={sameDay:"[Today at] LT",nextDay:"[Tomorrow at] LT",nextWeek:"dddd [at] LT",lastDay:"[Yesterday at] LT",lastWeek:"[Last] dddd [at] LT",sameElse:"L"},_={LTS:"h:mm:ss A",LT:"h:mm A",L:"MM/DD/YYYY",LL:"MMMM D, YYYY",LLL:"MMMM D, YYYY h:mm A",LLLL:"dddd, MMMM D, YYYY h:mm A"},m="Invalid date",h="%d",p=/\d{1,2}/,f={future:"in %s",past:"%s ago",s:"a few seconds",m:"a minute",mm:"%d minutes",h:"an hour",hh:"%d hours",d:"a day",dd:"%d days",M:"a month",MM:"%d months",y:"a year",yy:"%d years"},M={},y={},L=/(\[[^\[]*\])|(\\)?([Hh]mm(ss)?|Mo|MM?M?M?|Do|DDDo|DD?D?D?|ddd?d?|do?|w[o|w]?|W[o|W]?|Qo?|YYYYYY|YYYYY|YYYY|YY|gg(ggg?)?|GG(GGG?)?|e|E|a|A|hh?|HH?|kk?|mm?|ss?|S{1,9}|x|X|zz?|ZZ?|.)/g,Y=/(\[[^\[]*\])|(\\)?(LTS|LT|LL?L?L?|l{1,4})/g,v={},g={},k=/\d/,b=/\d\d/,D=/\d{3}/,w=/\d{4}/,T=/[+-]?\d{6}/,S=/\d\d?/,j=/\d\d\d\d?/,H=/\d\d\d\d\d\d?/,P=/\d{1,3}/,x=/\d{1,4}/,F=/[+-]?\d{1,6}/,R=/\d+/,O=/[+-]?\d+/,A=/Z|[+-]\d\d:?\d\d/gi,W=/Z|[+-]\d\d(?::?\d\d)?/gi,I=/[+-]?\d+(\.\d{1,3})?/,E=/[0-9]*['a-z\u00A0-\u05FF\u0700-\uD7FF\uF900-\uFDCF\uFDF0-\uFFEF]+|[\u0600-\u06FF\/]+(\s*?[\u0600-\u06FF]+){1,2}/i,C={},U={},z=0,N=1,J=2,G=3,V=4,$=5,q=6,B=7,Z=8;u=Array.prototype.indexOf?Array.prototype.indexOf:function(e){var t;for(t=0;t<this.length;++t)if(this[t]===e)return t;return-1};var K=u;addFormatToken("M",["MM",2],"Mo",function(){return this.month()+1}),addFormatToken("MMM",0,0,function(e){return this.localeData().monthsShort(this,e)}),addFormatToken("MMMM",0,0,function(e){return this.localeData().months(this,e)}),addUnitAlias("month","M"),addUnitPriority("month",8),addRegexToken("M",S),addRegexToken("MM",S,b),addRegexToken("MMM",function(e,t){return t.monthsShortRegex(e)}),addRegexToken("MMMM",function(e,t){return t.monthsRegex(e)}),addParseToken(["M","MM"],function(e,t){t[N]=toInt(e)-1}),addParseToken(["MMM","MMMM"],function(e,t,n,a){var r=n._locale.monthsParse(e,a,n._strict);null!=r?t[N]=r:getParsingFlags(n).invalidMonth=e});var Q=/D[oD]?(\[[^\[\]]*\]|\s)+MMMM?/,X="January_February_March_April_May_June_July_August_September_October_November_December".split("_"),ee="Jan_Feb_Mar_Apr_May_Jun_Jul_Aug_Sep_Oct_Nov_Dec".split("_"),te=E,ne=E;addFormatToken("Y",0,0,function(){var e=this.year();return e<=9999?""+e:"+"+e}),addFormatToken(0,["YY",2],0,function(){return this.year()%100}),addFormatToken(0,["YYYY",4],0,"year"),addFormatToken(0,["YYYYY",5],0,"year"),addFormatToken(0,["YYYYYY",6,!0],0,"year"),addUnitAlias("year","y"),addUnitPriority("year",1),addRegexToken("Y",O),addRegexToken("YY",S,b),addRegexToken("YYYY",x,w),addRegexToken("YYYYY",F,T),addRegexToken("YYYYYY",F,T),addParseToken(["YYYYY","YYYYYY"],z),addParseToken("YYYY",function(e,t){t[z]=2===e.length?hooks.parseTwoDigitYear(e):toInt(e)}),addParseToken("YY",function(e,t){t[z]=hooks.parseTwoDigitYear(e)}),addParseToken("Y",function(e,t){t[z]=parseInt(e,10)}),hooks.parseTwoDigitYear=function(e){return toInt(e)+(toInt(e)>68?1900:2e3)};var ae=makeGetSet("FullYear",!0);addFormatToken("w",["ww",2],"wo","week"),addFormatToken("W",["WW",2],"Wo","isoWeek"),addUnitAlias("week","w"),addUnitAlias("isoWeek","W"),addUnitPriority("week",5),addUnitPriority("isoWeek",5),addRegexToken("w",S),addRegexToken("ww",S,b),addRegexToken("W",S),addRegexToken("WW",S,b),addWeekParseToken(["w","ww","W","WW"],function(e,t,n,a){t[a.substr(0,1)]=toInt(e)});var re={dow:0,doy:6};addFormatToken("d",0,"do","day"),addFormatToken("dd",0,0,function(e){return this.localeData().weekdaysMin(this,e)}),addFormatToken("ddd",0,0,function(e){return this.localeData().weekdaysShort(this,e)}),addFormatToken("dddd",0,0,function(e){return this.localeData().weekdays(this,e)}),addFormatToken("e",0,0,"weekday"),addFormatToken("E",0,0,"isoWeekday"),addUnitAlias("day","d"),addUnitAlias("weekday","e"),addUnitAlias("isoWeekday","E"),addUnitPriority("day",11),addUnitPriority("weekday",11),addUnitPriority("isoWeekday",11),addRegexToken("d",S),addRegexToken("e",S),addRegexToken("E",S),addRegexToken("dd",function(e,t){return t.weekdaysMinRegex(e)}),addRegexToken("ddd",function(e,t){return t.weekdaysShortRegex(e)}),addRegexToken("dddd",function(e,t){return t.weekdaysRegex(e)}),addWeekParseToken(["dd","ddd","dddd"],function(e,t,n,a){var r=n._locale.weekdaysParse(e,a,n._strict);null!=r?t.d=r:getParsingFlags(n).invalidWeekday=e}),addWeekParseToken(["d","e","E"],function(e,t,n,a){t[a]=toInt(e)});var se="Sunday_Monday_Tuesday_Wednesday_Thursday_Friday_Saturday".split("_"),ie="Sun_Mon_Tue_Wed_Thu_Fri_Sat".split("_"),oe="Su_Mo_Tu_We_Th_Fr_Sa".split("_"),de=E,ue=E,le=E;addFormatToken("H",["HH",2],0,"hour"),addFormatToken("h",["hh",2],0,hFormat),addFormatToken("k",["kk",2],0,kFormat),addFormatToken("hmm",0,0,function(){return""+hFormat.apply(this)+zeroFill(this.minutes(),2)}),addFormatToken("hmmss",0,0,function(){return""+hFormat.apply(this)+zeroFill(this.minutes(),2)+zeroFill(this.seconds(),2)}),addFormatToken("Hmm",0,0,function(){return""+this.hours()+zeroFill(this.minutes(),2)}),addFormatToken("Hmmss",0,0,function(){return""+this.hours()+zeroFill(this.minutes(),2)+zeroFill(this.seconds(),2)}),meridiem("a",!0),meridiem("A",!1),addUnitAlias("hour","h"),addUnitPriority("hour",13),addRegexToken("a",matchMeridiem),addRegexToken("A",matchMeridiem),addRegexToken("H",S),addRegexToken("h",S),addRegexToken("HH",S,b),addRegexToken("hh",S,b),addRegexToken("hmm",j),addRegexToken("hmmss",H),addRegexToken("Hmm",j),addRegexToken("Hmmss",H),addParseToken(["H","HH"],G),addParseToken(["a","A"],function(e,t,n){n._isPm=n._locale.isPM(e),n._meridiem=e}),addParseToken(["h","hh"],function(e,t,n){t[G]=toInt(e),getParsingFlags(n).bigHour=!0}),addParseToken("hmm",function(e,t,n){var a=e.length-2;t[G]=toInt(e.substr(0,a)),t[V]=toInt(e.substr(a)),getParsingFlags(n).bigHour=!0}),addParseToken("hmmss",function(e,t,n){var a=e.length-4,r=e.length-2;t[G]=toInt(e.substr(0,a)),t[V]=toInt(e.substr(a,2)),t[$]=toInt(e.substr(r)),getParsingFlags(n).bigHour=!0}),addParseToken("Hmm",function(e,t,n){var a=e.length-2;t[G]=toInt(e.substr(0,a)),t[V]=toInt(e.substr(a))}),addParseToken("Hmmss",function(e,t,n){var a=e.length-4,r=e.length-2;t[G]=toInt(e.substr(0,a)),t[V]=toInt(e.substr(a,2)),t[$]=toInt(e.substr(r))});var ce,_e=/[ap]\.?m?\.?/i,me=makeGetSet("Hours",!0),he={calendar:c,longDateFormat:_,invalidDate:m,ordinal:h,ordinalParse:p,relativeTime:f,months:X,monthsShort:ee,week:re,weekdays:se,weekdaysMin:oe,weekdaysShort:ie,meridiemParse:_e},pe={},fe={},Me=/^\s*((?:[+-]\d{6}|\d{4})-(?:\d\d-\d\d|W\d\d-\d|W\d\d|\d\d\d|\d\d))(?:(T| )(\d\d(?::\d\d(?::\d\d(?:[.,]\d+)?)?)?)([\+\-]\d\d(?::?\d\d)?|\s*Z)?)?$/,ye=/^\s*((?:[+-]\d{6}|\d{4})(?:\d\d\d\d|W\d\d\d|W\d\d|\d\d\d|\d\d))(?:(T| )(\d\d(?:\d\d(?:\d\d(?:[.,]\d+)?)?)?)([\+\-]\d\d(?::?\d\d)?|\s*Z)?)?$/,Le=/Z|[+-]\d\d(?::?\d\d)?/,Ye=[["YYYYYY-MM-DD",/[+-]\d{6}-\d\d-\d\d/],["YYYY-MM-DD",/\d{4}-\d\d-\d\d/],["GGGG-[W]WW-E",/\d{4}-W\d\d-\d/],["GGGG-[W]WW",/\d{4}-W\d\d/,!1],["YYYY-DDD",/\d{4}-\d{3}/],["YYYY-MM",/\d{4}-\d\d/,!1],["YYYYYYMMDD",/[+-]\d{10}/],["YYYYMMDD",/\d{8}/],["GGGG[W]WWE",/\d{4}W\d{3}/],["GGGG[W]WW",/\d{4}W\d{2}/,!1],["YYYYDDD",/\d{7}/]],ve=[["HH:mm:ss.SSSS",/\d\d:\d\d:\d\d\.\d+/],["HH:mm:ss,SSSS",/\d\d:\d\d:\d\d,\d+/],["HH:mm:ss",/\d\d:\d\d:\d\d/],["HH:mm",/\d\d:\d\d/],["HHmmss.SSSS",/\d\d\d\d\d\d\.\d+/],["HHmmss,SSSS",/\d\d\d\d\d\d,\d+/],["HHmmss",/\d\d\d\d\d\d/],["HHmm",/\d\d\d\d/],["HH",/\d\d/]],ge=/^\/?Date\((\-?\d+)/i;hooks.createFromInputFallback=deprecate("value provided is not in a recognized ISO format. moment construction falls back to js Date(), which is not reliable across all browsers and versions. Non ISO date formats are discouraged and will be removed in an upcoming major release. Please refer to http://momentjs.com/guides/#/warnings/js-date/ for more info.",function(e){e._d=new Date(e._i+(e._useUTC?" UTC":""))}),hooks.ISO_8601=function(){};var ke=deprecate("moment().min is deprecated, use moment.max instead. http://momentjs.com/guides/#/warnings/min-max/",function(){var e=createLocal.apply(null,arguments);return this.isValid()&&e.isValid()?e<this?this:e:createInvalid()}),be=deprecate("moment().max is deprecated, use moment.min instead. http://momentjs.com/guides/#/warnings/min-max/",function(){var e=createLocal.apply(null,arguments);return this.isValid()&&e.isValid()?e>this?this:e:createInvalid()}),De=function(){return Date.now?Date.now():+new Date};offset("Z",":"),offset("ZZ",""),addRegexToken("Z",W),addRegexToken("ZZ",W),addParseToken(["Z","ZZ"],function(e,t,n){n._useUTC=!0,n._tzm=offsetFromString(W,e)});var we=/([\+\-]|\d\d)/gi;hooks.updateOffset=function(){};var Te=/^(\-)?(?:(\d*)[. ])?(\d+)\:(\d+)(?:\:(\d+)(\.\d*)?)?$/,Se=/^(-)?P(?:(-?[0-9,.]*)Y)?(?:(-?[0-9,.]*)M)?(?:(-?[0-9,.]*)W)?(?:(-?[0-9,.]*)D)?(?:T(?:(-?[0-9,.]*)H)?(?:(-?[0-9,.]*)M)?(?:(-?[0-9,.]*)S)?)?$/;createDuration.fn=Duration.prototype;var je=createAdder(1,"add"),He=createAdder(-1,"subtract");hooks.defaultFormat="YYYY-MM-DDTHH:mm:ssZ",hooks.defaultFormatUtc="YYYY-MM-DDTHH:mm:ss[Z]";var Pe=deprecate("moment().lang() is deprecated. Instead, use moment().localeData() to get the language configuration. Use moment().locale() to change languages.",function(e){return void 0===e?this.localeData():this.locale(e)});addFormatToken(0,["gg",2],0,function(){return this.weekYear()%100}),addFormatToken(0,["GG",2],0,function(){return this.isoWeekYear()%100}),addWeekYearFormatToken("gggg","weekYear"),addWeekYearFormatToken("ggggg","weekYear"),addWeekYearFormatToken("GGGG","isoWeekYear"),addWeekYearFormatToken("GGGGG","isoWeekYear"),addUnitAlias("weekYear","gg"),addUnitAlias("isoWeekYear","GG"),addUnitPriority("weekYear",1),addUnitPriority("isoWeekYear",1),addRegexToken("G",O),addRegexToken("g",O),addRegexToken("GG",S,b),addRegexToken("gg",S,b),addRegexToken("GGGG",x,w),addRegexToken("gggg",x,w),addRegexToken("GGGGG",F,T),addRegexToken("ggggg",F,T),addWeekParseToken(["gggg","ggggg","GGGG","GGGGG"],function(e,t,n,a){t[a.substr(0,2)]=toInt(e)}),addWeekParseToken(["gg","GG"],function(e,t,n,a){t[a]=hooks.parseTwoDigitYear(e)}),addFormatToken("Q",0,"Qo","quarter"),addUnitAlias("quarter","Q"),addUnitPriority("quarter",7),addRegexToken("Q",k),addParseToken("Q",function(e,t){t[N]=3*(toInt(e)-1)}),addFormatToken("D",["DD",2],"Do","date"),addUnitAlias("date","D"),addUnitPriority("date",9),addRegexToken("D",S),addRegexToken("DD",S,b),addRegexToken("Do",function(e,t){return e?t._ordinalParse:t._ordinalParseLenient}),addParseToken(["D","DD"],J),addParseToken("Do",function(e,t){t[J]=toInt(e.match(S)[0],10)});var xe=makeGetSet("Date",!0);addFormatToken("DDD",["DDDD",3],"DDDo","dayOfYear"),addUnitAlias("dayOfYear","DDD"),addUnitPriority("dayOfYear",4),addRegexToken("DDD",P),addRegexToken("DDDD",D),addParseToken(["DDD","DDDD"],function(e,t,n){n._dayOfYear=toInt(e)}),addFormatToken("m",["mm",2],0,"minute"),addUnitAlias("minute","m"),addUnitPriority("minute",14),addRegexToken("m",S),addRegexToken("mm",S,b),addParseToken(["m","mm"],V);var Fe=makeGetSet("Minutes",!1);addFormatToken("s",["ss",2],0,"second"),addUnitAlias("second","s"),addUnitPriority("second",15),addRegexToken("s",S),addRegexToken("ss",S,b),addParseToken(["s","ss"],$);var Re=makeGetSet("Seconds",!1);addFormatToken("S",0,0,function(){return~~(this.millisecond()/100)}),addFormatToken(0,["SS",2],0,function(){return~~(this.millisecond()/10)}),addFormatToken(0,["SSS",3],0,"millisecond"),addFormatToken(0,["SSSS",4],0,function(){return 10*this.millisecond()}),addFormatToken(0,["SSSSS",5],0,function(){return 100*this.millisecond()}),addFormatToken(0,["SSSSSS",6],0,function(){return 1e3*this.millisecond()}),addFormatToken(0,["SSSSSSS",7],0,function(){return 1e4*this.millisecond()}),addFormatToken(0,["SSSSSSSS",8],0,function(){return 1e5*this.millisecond()}),addFormatToken(0,["SSSSSSSSS",9],0,function(){return 1e6*this.millisecond()}),addUnitAlias("millisecond","ms"),addUnitPriority("millisecond",16),addRegexToken("S",P,k),addRegexToken("SS",P,b),addRegexToken("SSS",P,D);var Oe;for(Oe="SSSS";Oe.length<=9;Oe+="S")addRegexToken(Oe,R);for(Oe="S";Oe.length<=9;Oe+="S")addParseToken(Oe,parseMs);var Ae=makeGetSet("Milliseconds",!1);addFormatToken("z",0,0,"zoneAbbr"),addFormatToken("zz",0,0,"zoneName");var We=Moment.prototype;We.add=je,We.calendar=calendar$1,We.clone=clone,We.diff=diff,We.endOf=endOf,We.format=format,We.from=from,We.fromNow=fromNow,We.to=to,We.toNow=toNow,We.get=stringGet,We.invalidAt=invalidAt,We.isAfter=isAfter,We.isBefore=isBefore,We.isBetween=isBetween,We.isSame=isSame,We.isSameOrAfter=isSameOrAfter,We.isSameOrBefore=isSameOrBefore,We.isValid=isValid$1,We.lang=Pe,We.locale=locale,We.localeData=localeData,We.max=be,We.min=ke,We.parsingFlags=parsingFlags,We.set=stringSet,We.startOf=startOf,We.subtract=He,We.toArray=toArray,We.toObject=toObject,We.toDate=toDate,We.toISOString=toISOString,We.inspect=inspect,We.toJSON=toJSON,We.toString=toString,We.unix=unix,We.valueOf=valueOf,We.creationData=creationData,We.year=ae,We.isLeapYear=getIsLeapYear,We.weekYear=getSetWeekYear,We.isoWeekYear=getSetISOWeekYear,We.quarter=We.quarters=getSetQuarter,We.month=getSetMonth,We.daysInMonth=getDaysInMonth,We.week=We.weeks=getSetWeek,We.isoWeek=We.isoWeeks=getSetISOWeek,We.weeksInYear=getWeeksInYear,We.isoWeeksInYear=getISOWeeksInYear,We.date=xe,We.day=We.days=getSetDayOfWeek,We.weekday=getSetLocaleDayOfWeek,We.isoWeekday=getSetISODayOfWeek,We.dayOfYear=getSetDayOfYear,We.hour=We.hours=me,We.minute=We.minutes=Fe,We.second=We.seconds=Re,We.millisecond=We.milliseconds=Ae,We.utcOffset=getSetOffset,We.utc=setOffsetToUTC,We.local=setOffsetToLocal,We.parseZone=setOffsetToParsedOffset,We.hasAlignedHourOffset=hasAlignedHourOffset,We.isDST=isDaylightSavingTime,We.isLocal=isLocal,We.isUtcOffset=isUtcOffset,We.isUtc=isUtc,We.isUTC=isUtc,We.zoneAbbr=getZoneAbbr,We.zoneName=getZoneName,We.dates=deprecate("dates accessor is deprecated. Use date instead.",xe),We.months=deprecate("months accessor is deprecated. Use month instead",getSetMonth),We.years=deprecate("years accessor is deprecated. Use year instead",ae),We.zone=deprecate("moment().zone is deprecated, use moment().utcOffset instead. http://momentjs.com/guides/#/warnings/zone/",getSetZone),We.isDSTShifted=deprecate("isDSTShifted is deprecated. See http://momentjs.com/guides/#/warnings/dst-shifted/ for more information",isDaylightSavingTimeShifted);var Ie=Locale.prototype;Ie.calendar=calendar,Ie.longDateFormat=longDateFormat,Ie.invalidDate=invalidDate,Ie.ordinal=ordinal,Ie.preparse=preParsePostFormat,Ie.postformat=preParsePostFormat,Ie.relativeTime=relativeTime,Ie.pastFuture=pastFuture,Ie.set=set,Ie.months=localeMonths,Ie.monthsShort=localeMonthsShort,Ie.monthsParse=localeMonthsParse,Ie.monthsRegex=monthsRegex,Ie.monthsShortRegex=monthsShortRegex,Ie.week=localeWeek,Ie.firstDayOfYear=localeFirstDayOfYear,Ie.firstDayOfWeek=localeFirstDayOfWeek,Ie.weekdays=localeWeekdays,Ie.weekdaysMin=localeWeekdaysMin,Ie.weekdaysShort=localeWeekdaysShort,Ie.weekdaysParse=localeWeekdaysParse,Ie.weekdaysRegex=weekdaysRegex,Ie.weekdaysShortRegex=weekdaysShortRegex,Ie.weekdaysMinRegex=weekdaysMinRegex,Ie.isPM=localeIsPM,Ie.meridiem=localeMeridiem,getSetGlobalLocale("en",{ordinalParse:/\d{1,2}(th|st|nd|rd)/,ordinal:function(e){var t=e%10,n=1===toInt(e%100/10)?"th":1===t?"st":2===t?"nd":3===t?"rd":"th";return e+n}}),hooks.lang=deprecate("moment.lang is deprecated. Use moment.locale instead.",getSetGlobalLocale),hooks.langData=deprecate("moment.langData is deprecated. Use moment.localeData instead.",getLocale);var Ee=Math.abs,Ce=makeAs("ms"),Ue=makeAs("s"),ze=makeAs("m"),Ne=makeAs("h"),Je=makeAs("d"),Ge=makeAs("w"),Ve=makeAs("M"),$e=makeAs("y"),qe=makeGetter("milliseconds"),Be=makeGetter("seconds"),Ze=makeGetter("minutes"),Ke=makeGetter("hours"),Qe=makeGetter("days"),Xe=makeGetter("months"),et=makeGetter("years"),tt=Math.round,nt={s:45,m:45,h:22,d:26,M:11},at=Math.abs,rt=Duration.prototype;return rt.abs=abs,rt.add=add$1,rt.subtract=subtract$1,rt.as=as,rt.asMilliseconds=Ce,rt.asSeconds=Ue,rt.asMinutes=ze,rt.asHours=Ne,rt.asDays=Je,rt.asWeeks=Ge,rt.asMonths=Ve,rt.asYears=$e,rt.valueOf=valueOf$1,rt._bubble=bubble,rt.get=get$2,rt.milliseconds=qe,rt.seconds=Be,rt.minutes=Ze,rt.hours=Ke,rt.days=Qe,rt.weeks=weeks,rt.months=Xe,rt.years=et,rt.humanize=humanize,rt.toISOString=toISOString$1,rt.toString=toISOString$1,rt.toJSON=toISOString$1,rt.locale=locale,rt.localeData=localeData,rt.toIsoString=deprecate("toIsoString() is deprecated. Please use toISOString() instead (notice the capitals)",toISOString$1),rt.lang=Pe,addFormatToken("X",0,0,"unix"),addFormatToken("x",0,0,"valueOf"),addRegexToken("x",O),addRegexToken("X",I),addParseToken("X",function(e,t,n){n._d=new Date(1e3*parseFloat(e,10))}),addParseToken("x",function(e,t,n){n._d=new Date(toInt(e))}),hooks.version="2.17.1",setHookCallback(createLocal),hooks.fn=We,hooks.min=min,hooks.max=max,hooks.now=De,hooks.utc=createUTC,hooks.unix=createUnix,hooks.months=listMonths,hooks.isDate=isDate,hooks.locale=getSetGlobalLocale,hooks.invalid=createInvalid,hooks.duration=createDuration,hooks.isMoment=isMoment,hooks.weekdays=listWeekdays,hooks.parseZone=createInZone,hooks.localeData=getLocale,hooks.isDuration=isDuration,hooks.monthsShort=listMonthsShort,hooks.weekdaysMin=listWeekdaysMin,hooks.defineLocale=defineLocale,hooks.updateLocale=updateLocale,hooks.locales=listLocales,hooks.weekdaysShort=listWeekdaysShort,hooks.normalizeUnits=normalizeUnits,hooks.relativeTimeRounding=getSetRelativeTimeRounding,hooks.relativeTimeThreshold=getSetRelativeTimeThreshold,hooks.calendarFormat=getCalendarFormat,hooks.prototype=We,hooks})}).call(t,n(1205)(e))},286:function(e,t,n){"use strict";function emptyCredentials(){return{username:"",password:""}}var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},i=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},o=n(0),d=n(153),u=n(940);t.emptyCredentials=emptyCredentials;var c=60,l=function(){function AuthService(e){this.http=e,this.refreshing=null}return AuthService.prototype.authenticate=function(e){return s(this,void 0,void 0,function(){var t,n;return i(this,function(a){switch(a.label){case 0:return a.trys.push([0,2,,3]),[4,this.http.post("/api/authenticate",e).toPromise()];case 1:return t=a.sent(),this.storeTokens(t.json()),[3,3];case 2:if(n=a.sent(),n instanceof d.Response)throw new Error(n.json().message);throw n;case 3:return[2]}})})},AuthService.prototype.ensureFreshToken=function(){return s(this,void 0,void 0,function(){var e,t,n=this;return i(this,function(a){switch(a.label){case 0:return e=sessionStorage.getItem("refreshToken"),t=parseInt(sessionStorage.getItem("expiresAt"),10),!e||isNaN(t)||t-Date.now()/1e3>c?[2]:(this.refreshing||(this.refreshing=this.refresh(e).then(function(){n.refreshing=null},function(e){throw n.refreshing=null,e})),[4,this.refreshing]);case 1:return a.sent(),[2]}})})},AuthService.prototype.refresh=function(e){return s(this,void 0,void 0,function(){var t,n;return i(this,function(a){switch(a.label){case 0:return a.trys.push([0,2,,3]),[4,this.http.post("/api/authenticate/refresh",{refreshToken:e}).toPromise()];case 1:return t=a.sent(),this.storeTokens(t.json()),[3,3];case 2:throw n=a.sent(),n instanceof d.Response&&401==n.status&&this.clearTokens(),n;case 3:return[2]}})})},AuthService.prototype.storeTokens=function(e){window.sessionStorage.setItem("jwt",e.token),window.sessionStorage.setItem("refreshToken",e.refreshToken),window.sessionStorage.setItem("expiresAt",e.expiresAt.toString())},AuthService.prototype.clearTokens=function(){window.sessionStorage.removeItem("jwt"),window.sessionStorage.removeItem("refreshToken"),window.sessionStorage.removeItem("expiresAt")},Object.defineProperty(AuthService.prototype,"session",{get:function(){var e=sessionStorage.getItem("jwt");return e?u(sessionStorage.getItem("jwt")):null},enumerable:!0,configurable:!0}),AuthService}();l=a([o.Injectable(),r("design:paramtypes",[d.Http])],l),t.AuthService=l},452:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=n(0),s=function(){function AppComponent(){}return AppComponent}();s=a([r.Component({template:n(927),selector:"app"})],s),t.AppComponent=s},453:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=n(0),i=n(54),o=function(){function AuthGuard(e){this.router=e}return AuthGuard.prototype.canActivate=function(){return!!window.sessionStorage.getItem("jwt")||(this.router.navigate(["/login"]),!1)},AuthGuard}();o=a([s.Injectable(),r("design:paramtypes",[i.Router])],o),t.AuthGuard=o},454:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=n(0),i=n(54),o=function(){function AutoLoginGuard(e){this.router=e}return AutoLoginGuard.prototype.canActivate=function(){return!window.sessionStorage.getItem("jwt")||(this.router.navigate(["/home"]),!0)},AutoLoginGuard}();o=a([s.Injectable(),r("design:paramtypes",[i.Router])],o),t.AutoLoginGuard=o},455:function(e,t,n){"use strict";var a=this&&this.__extends||function(e,t){function __(){this.constructor=e}for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n]);e.prototype=null===t?Object.create(t):(__.prototype=t.prototype,new __)},r=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},s=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},i=n(0),o=n(457),d=n(140),u=function(e){function DosesResolve(t){return e.call(this,t)||this}return a(DosesResolve,e),DosesResolve}(o.NestedListResolve);u=r([i.Injectable(),s("design:paramtypes",[d.DoseService])],u),t.DosesResolve=u},456:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},i=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},o=n(0),d=n(194),u=function(){function DoseSummariesResolve(e){this.doseSummaryService=e}return DoseSummariesResolve.prototype.resolve=function(e){return s(this,void 0,void 0,function(){var t,n;return i(this,function(a){switch(a.label){case 0:for(t=e;!t.params.id;)t=t.parent;return n=t.params.id,[4,this.doseSummaryService.listDoseSummaries(parseInt(n))];case 1:return[2,a.sent()]}})})},DoseSummariesResolve}();u=a([o.Injectable(),r("design:paramtypes",[d.DoseSummaryService])],u),t.DoseSummariesResolve=u},457:function(e,t,n){"use strict";var a=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},r=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},s=function(){function ListResolve(e){this.apiService=e}return ListResolve.prototype.resolve=function(){return a(this,void 0,void 0,function(){return r(this,function(e){switch(e.label){case 0:return[4,this.apiService.list()];case 1:return[2,e.sent()]}})})},ListResolve}();t.ListResolve=s;var i=function(){function NestedListResolve(e){this.apiService=e,this.superIdProperty="id"}return NestedListResolve.prototype.resolve=function(e){return a(this,void 0,void 0,function(){var t,n;return r(this,function(a){switch(a.label){case 0:for(t=e;!t.params[this.superIdProperty];)t=t.parent;return n=t.params[this.superIdProperty],[4,this.apiService.list(parseInt(n))];case 1:return[2,a.sent()]}})})},NestedListResolve}();t.NestedListResolve=i},458:function(e,t,n){"use strict";var a=this&&this.__extends||function(e,t){function __(){this.constructor=e}for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n]);e.prototype=null===t?Object.create(t):(__.prototype=t.prototype,new __)},r=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},s=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},i=n(0),o=n(457),d=n(89),u=n(459),l=function(e){function MedicationsResolve(t){return e.call(this,t)||this}return a(MedicationsResolve,e),MedicationsResolve}(o.ListResolve);l=r([i.Injectable(),s("design:paramtypes",[d.MedicationService])],l),t.MedicationsResolve=l;var c=function(e){function MedicationResolve(t){return e.call(this,t)||this}return a(MedicationResolve,e),MedicationResolve}(u.SingleResolve);c=r([i.Injectable(),s("design:paramtypes",[d.MedicationService])],c),t.MedicationResolve=c},459:function(e,t,n){"use strict";var a=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},r=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,
 done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},s=function(){function SingleResolve(e){this.apiService=e,this.idProperty="id"}return SingleResolve.prototype.resolve=function(e){return a(this,void 0,void 0,function(){var t,n;return r(this,function(a){switch(a.label){case 0:for(t=e;!t.params[this.idProperty];)t=t.parent;return n=t.params[this.idProperty],[4,this.apiService.read(parseInt(n))];case 1:return[2,a.sent()]}})})},SingleResolve}();t.SingleResolve=s},460:function(e,t,n){"use strict";var a=this&&this.__extends||function(e,t){function __(){this.constructor=e}for(var n in t)t.hasOwnProperty(n)&&(e[n]=t[n]);e.prototype=null===t?Object.create(t):(__.prototype=t.prototype,new __)},r=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},s=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},i=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},o=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},d=n(0),u=n(141),l=n(459),c=function(){function PatientsResolve(e){this.userService=e}return PatientsResolve.prototype.resolve=function(){return i(this,void 0,void 0,function(){return o(this,function(e){switch(e.label){case 0:return[4,this.userService.listUsersWithRole(["patient"])];case 1:return[2,e.sent()]}})})},PatientsResolve}();c=r([d.Injectable(),s("design:paramtypes",[u.UserService])],c),t.PatientsResolve=c;var _=function(e){function UserResolve(t){return e.call(this,t)||this}return a(UserResolve,e),UserResolve}(l.SingleResolve);_=r([d.Injectable(),s("design:paramtypes",[u.UserService])],_),t.UserResolve=_},461:function(e,t,n){"use strict";var a=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},r=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},s=function(){function APIInterface(e){this.http=e}return APIInterface.prototype.create=function(e){return a(this,void 0,void 0,function(){var t;return r(this,function(n){switch(n.label){case 0:return[4,this.http.postJSON("/api"+this.baseURL,e)];case 1:return t=n.sent(),[2,new this.model(t)]}})})},APIInterface.prototype.list=function(){return a(this,void 0,void 0,function(){var e,t=this;return r(this,function(n){switch(n.label){case 0:return[4,this.http.getJSON("/api"+this.baseURL)];case 1:return e=n.sent(),[2,e.map(function(e){return new t.model(e)})]}})})},APIInterface.prototype.read=function(e){return a(this,void 0,void 0,function(){var t;return r(this,function(n){switch(n.label){case 0:return[4,this.http.getJSON("/api"+this.baseURL+"/"+e)];case 1:return t=n.sent(),[2,new this.model(t)]}})})},APIInterface.prototype.delete=function(e){return a(this,void 0,void 0,function(){return r(this,function(t){switch(t.label){case 0:return[4,this.http.delete("/api"+this.baseURL+"/"+e)];case 1:return t.sent(),[2]}})})},APIInterface}();t.APIInterface=s},462:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=n(0),i=n(286),o=function(){function HomeComponent(e){this.authService=e,this.menuItems=[{title:"Medications",path:"medications",roles:["admin","doctor","pharmacist"]},{title:"Patients",path:"patients",roles:["admin","doctor"]}]}return Object.defineProperty(HomeComponent.prototype,"visibleMenuItems",{get:function(){var e=this;return this.menuItems.filter(function(t){return t.roles.includes(e.authService.session.role)})},enumerable:!0,configurable:!0}),HomeComponent}();o=a([s.Component({selector:"home",template:n(928)}),r("design:paramtypes",[i.AuthService])],o),t.HomeComponent=o},463:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},i=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},o=n(0),d=n(54),u=n(286),l=function(){function LoginComponent(e,t){this.authService=e,this.router=t,this.credentials=u.emptyCredentials(),this.loginError=""}return LoginComponent.prototype.login=function(){return s(this,void 0,void 0,function(){var e;return i(this,function(t){switch(t.label){case 0:return t.trys.push([0,2,,3]),[4,this.authService.authenticate(this.credentials)];case 1:return t.sent(),this.router.navigate(["/home"]),[3,3];case 2:return e=t.sent(),this.loginError=e.message,[3,3];case 3:return[2]}})})},LoginComponent}();l=a([o.Component({template:n(929),selector:"login",styles:[n(942)]}),r("design:paramtypes",[u.AuthService,d.Router])],l),t.LoginComponent=l},464:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=n(0),i=n(54),o=n(89),d=function(){function MedicationComponent(e,t){this.medicationService=e,this.route=t}return MedicationComponent.prototype.ngOnInit=function(){var e=this;this.routeDataSubscription=this.route.data.subscribe(function(t){e.medication=t.medication})},MedicationComponent.prototype.ngOnDestroy=function(){this.routeDataSubscription&&this.routeDataSubscription.unsubscribe()},MedicationComponent}();d=a([s.Component({selector:"medication",template:n(930)}),r("design:paramtypes",[o.MedicationService,i.ActivatedRoute])],d),t.MedicationComponent=d},465:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},i=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},o=n(0),d=n(54),u=n(89),l=n(116),c=function(){function MedicationsComponent(e,t,n,a){this.medicationService=e,this.modalService=t,this.route=n,this.router=a,this.newMedication={title:"",description:""},this.errorMessage=""}return MedicationsComponent.prototype.ngOnInit=function(){var e=this;this.routeDataSubscription=this.route.data.subscribe(function(t){e.medications=t.medications})},MedicationsComponent.prototype.ngOnDestroy=function(){this.routeDataSubscription&&this.routeDataSubscription.unsubscribe()},MedicationsComponent.prototype.openCreateMedicationModal=function(e){this.modalService.open(e)},MedicationsComponent.prototype.closeCreateMedicationModal=function(e){e(),this.newMedication={title:"",description:""}},MedicationsComponent.prototype.createMedication=function(e){return s(this,void 0,void 0,function(){var t,n;return i(this,function(a){switch(a.label){case 0:return a.trys.push([0,2,,3]),[4,this.medicationService.create(this.newMedication)];case 1:return t=a.sent(),this.router.navigate([t.id],{relativeTo:this.route}),e(),[3,3];case 2:return n=a.sent(),this.errorMessage=n.message,[3,3];case 3:return[2]}})})},MedicationsComponent.prototype.startDeleteMedication=function(e,t){this.pendingMedication=e,this.modalService.open(t)},MedicationsComponent.prototype.closeConfirmDeleteModal=function(e){e()},MedicationsComponent.prototype.deletePendingMedication=function(e){return s(this,void 0,void 0,function(){var t=this;return i(this,function(n){switch(n.label){case 0:return[4,this.medicationService.delete(this.pendingMedication.id)];case 1:return n.sent(),this.medications=this.medications.filter(function(e){return e.id!=t.pendingMedication.id}),this.closeConfirmDeleteModal(e),[2]}})})},MedicationsComponent}();c=a([o.Component({selector:"medications",template:n(931)}),r("design:paramtypes",[u.MedicationService,l.NgbModal,d.ActivatedRoute,d.Router])],c),t.MedicationsComponent=c},466:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=this&&this.__awaiter||function(e,t,n,a){return new(n||(n=Promise))(function(r,s){function fulfilled(e){try{step(a.next(e))}catch(e){s(e)}}function rejected(e){try{step(a.throw(e))}catch(e){s(e)}}function step(e){e.done?r(e.value):new n(function(t){t(e.value)}).then(fulfilled,rejected)}step((a=a.apply(e,t||[])).next())})},i=this&&this.__generator||function(e,t){function verb(e){return function(t){return step([e,t])}}function step(i){if(n)throw new TypeError("Generator is already executing.");for(;s;)try{if(n=1,a&&(r=a[2&i[0]?"return":i[0]?"throw":"next"])&&!(r=r.call(a,i[1])).done)return r;switch(a=0,r&&(i=[0,r.value]),i[0]){case 0:case 1:r=i;break;case 4:return s.label++,{value:i[1],done:!1};case 5:s.label++,a=i[1],i=[0];continue;case 7:i=s.ops.pop(),s.trys.pop();continue;default:if(r=s.trys,!(r=r.length>0&&r[r.length-1])&&(6===i[0]||2===i[0])){s=0;continue}if(3===i[0]&&(!r||i[1]>r[0]&&i[1]<r[3])){s.label=i[1];break}if(6===i[0]&&s.label<r[1]){s.label=r[1],r=i;break}if(r&&s.label<r[2]){s.label=r[2],s.ops.push(i);break}r[2]&&s.ops.pop(),s.trys.pop();continue}i=t.call(e,s)}catch(e){i=[6,e],a=0}finally{n=r=0}if(5&i[0])throw i[1];return{value:i[0]?i[1]:void 0,done:!0}}var n,a,r,s={label:0,sent:function(){if(1&r[0])throw r[1];return r[1]},trys:[],ops:[]};return{next:verb(0),throw:verb(1),return:verb(2)}},o=n(0),d=n(54),u=n(141),l=n(140),c=n(116),_=n(194),m=n(763),h=n(193),p=function(){function PatientComponent(e,t,n,a,r,s){this.userService=e,this.doseSummaryService=t,this.dispatcherService=n,this.doseService=a,this.route=r,this.modalService=s,this.pendingDose=null}return PatientComponent.prototype.ngOnInit=function(){var e=this;this.routeDataSubscription=this.route.data.subscribe(function(t){return s(e,void 0,void 0,function(){var e,n,a=this;return i(this,function(r){switch(r.label){case 0:return this.patient=t.patient,this.doses=t.doses,this.doseSummaries=t.doseSummaries,[4,this.doseService.getCollectionUpdates(this.patient.id)];case 1:return e=r.sent(),this.dosesUpdatesSubscription=e.updates.subscribe(function(e){return a.doses=m.applyUpdateToCollection(a.doses,e)}),this.dosesUpdatesSubscriptionId=e.subscriptionId,[4,this.doseSummaryService.getDoseSummariesUpdates(this.patient.id)];case 2:return n=r.sent(),this.doseSummariesUpdatesSubscription=n.updates.subscribe(function(e){return a.doseSummaries=e}),this.doseSummariesUpdatesSubscriptionId=n.subscriptionId,[2]}})})})},PatientComponent.prototype.ngOnDestroy=function(){return s(this,void 0,void 0,function(){return i(this,function(e){switch(e.label){case 0:return this.routeDataSubscription&&this.routeDataSubscription.unsubscribe(),this.dosesUpdatesSubscription&&this.dosesUpdatesSubscription.unsubscribe(),this.doseSummariesUpdatesSubscription&&this.doseSummariesUpdatesSubscription.unsubscribe(),[4,this.dispatcherService.unsubscribeTo(this.dosesUpdatesSubscriptionId)];case 1:return e.sent(),[4,this.dispatcherService.unsubscribeTo(this.doseSummariesUpdatesSubscriptionId)];case 2:return e.sent(),[2]}})})},PatientComponent.prototype.openAddDoseModal=function(e){this.modalService.open(e,{size:"lg"})},PatientComponent.prototype.doseCreated=function(e){this.doses.some(function(t){return t.id==e.id})||this.doses.push(e)},PatientComponent.prototype.openUpdateDoseModal=function(e,t){this.pendingDose=e,this.modalService.open(t,{size:"lg"})},PatientComponent.prototype.deleteDose=function(e,t){return s(this,void 0,void 0,function(){return i(this,function(n){switch(n.label){case 0:return t.stopPropagation(),t.preventDefault(),[4,this.doseService.delete(this.patient.id,e.id)];case 1:return n.sent(),this.doses=this.doses.filter(function(t){return e.id!=t.id}),[2]}})})},PatientComponent.prototype.doseUpdated=function(e){this.doses=this.doses.map(function(t){return t.id==e.id?e:t})},PatientComponent}();p=a([o.Component({selector:"patient",template:n(935)}),r("design:paramtypes",[u.UserService,_.DoseSummaryService,h.DispatcherService,l.DoseService,d.ActivatedRoute,c.NgbModal])],p),t.PatientComponent=p},467:function(e,t,n){"use strict";var a=this&&this.__decorate||function(e,t,n,a){var r,s=arguments.length,i=s<3?t:null===a?a=Object.getOwnPropertyDescriptor(t,n):a;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)i=Reflect.decorate(e,t,n,a);else for(var o=e.length-1;o>=0;o--)(r=e[o])&&(i=(s<3?r(i):s>3?r(t,n,i):r(t,n))||i);return s>3&&i&&Object.defineProperty(t,n,i),i},r=this&&this.__metadata||function(e,t){if("object"==typeof Reflect&&"function"==typeof Reflect.metadata)return Reflect.metadata(e,t)},s=n(0),i=n(54),o=n(141),d=n(116),u=function(){function PatientsComponent(e,t,n,a){this.userService=e,this.route=t,this.router=n,this.modalService=a,this.patients=[]}return PatientsComponent.prototype.ngOnInit=function(){var e=this;this.routeDataSubscription=this.route.data.subscribe(function(t){e.patients=t.patients})},PatientsComponent.prototype.ngOnDestroy=function(){this.routeDataSubscription&&this.routeDataSubscription.unsubscribe()},PatientsComponent.prototype.openAddPatientModal=function(e){this.modalService.open(e,{size:"lg"})},PatientsComponent.prototype.patientCreated=function(e){this.patients.some(function(t){return t.id==e.id})||this.patients.push(e),this.router.navigate([e.id],{relativeTo:this.route})},PatientsComponent}();u=a([s.Component({selector:"patients",template:n(936)}),r("design:paramtypes",[o.UserService,i.ActivatedRoute,i.Router,d.NgbModal])],u),t.PatientsComponent=u},499:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("af",{months:"Januarie_Februarie_Maart_April_Mei_Junie_Julie_Augustus_September_Oktober_November_Desember".split("_"),monthsShort:"Jan_Feb_Mrt_Apr_Mei_Jun_Jul_Aug_Sep_Okt_Nov_Des".split("_"),weekdays:"Sondag_Maandag_Dinsdag_Woensdag_Donderdag_Vrydag_Saterdag".split("_"),weekdaysShort:"Son_Maa_Din_Woe_Don_Vry_Sat".split("_"),weekdaysMin:"So_Ma_Di_Wo_Do_Vr_Sa".split("_"),meridiemParse:/vm|nm/i,isPM:function(e){return/^nm$/i.test(e)},meridiem:function(e,t,n){return e<12?n?"vm":"VM":n?"nm":"NM"},longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd, D MMMM YYYY HH:mm"},calendar:{sameDay:"[Vandag om] LT",nextDay:"[Môre om] LT",nextWeek:"dddd [om] LT",lastDay:"[Gister om] LT",lastWeek:"[Laas] dddd [om] LT",sameElse:"L"},relativeTime:{future:"oor %s",past:"%s gelede",s:"'n paar sekondes",m:"'n minuut",mm:"%d minute",h:"'n uur",hh:"%d ure",d:"'n dag",dd:"%d dae",M:"'n maand",MM:"%d maande",y:"'n jaar",yy:"%d jaar"},ordinalParse:/\d{1,2}(ste|de)/,ordinal:function(e){return e+(1===e||8===e||e>=20?"ste":"de")},week:{dow:1,doy:4}});return t})},500:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("ar-dz",{months:"جانفي_فيفري_مارس_أفريل_ماي_جوان_جويلية_أوت_سبتمبر_أكتوبر_نوفمبر_ديسمبر".split("_"),monthsShort:"جانفي_فيفري_مارس_أفريل_ماي_جوان_جويلية_أوت_سبتمبر_أكتوبر_نوفمبر_ديسمبر".split("_"),weekdays:"الأحد_الإثنين_الثلاثاء_الأربعاء_الخميس_الجمعة_السبت".split("_"),weekdaysShort:"احد_اثنين_ثلاثاء_اربعاء_خميس_جمعة_سبت".split("_"),weekdaysMin:"أح_إث_ثلا_أر_خم_جم_سب".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd D MMMM YYYY HH:mm"},calendar:{sameDay:"[اليوم على الساعة] LT",nextDay:"[غدا على الساعة] LT",nextWeek:"dddd [على الساعة] LT",lastDay:"[أمس على الساعة] LT",lastWeek:"dddd [على الساعة] LT",sameElse:"L"},relativeTime:{future:"في %s",past:"منذ %s",s:"ثوان",m:"دقيقة",mm:"%d دقائق",h:"ساعة",hh:"%d ساعات",d:"يوم",dd:"%d أيام",M:"شهر",MM:"%d أشهر",y:"سنة",yy:"%d سنوات"},week:{dow:0,doy:4}});return t})},501:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t={1:"1",2:"2",3:"3",4:"4",5:"5",6:"6",7:"7",8:"8",9:"9",0:"0"},n=function(e){return 0===e?0:1===e?1:2===e?2:e%100>=3&&e%100<=10?3:e%100>=11?4:5},a={s:["أقل من ثانية","ثانية واحدة",["ثانيتان","ثانيتين"],"%d ثوان","%d ثانية","%d ثانية"],m:["أقل من دقيقة","دقيقة واحدة",["دقيقتان","دقيقتين"],"%d دقائق","%d دقيقة","%d دقيقة"],h:["أقل من ساعة","ساعة واحدة",["ساعتان","ساعتين"],"%d ساعات","%d ساعة","%d ساعة"],d:["أقل من يوم","يوم واحد",["يومان","يومين"],"%d أيام","%d يومًا","%d يوم"],M:["أقل من شهر","شهر واحد",["شهران","شهرين"],"%d أشهر","%d شهرا","%d شهر"],y:["أقل من عام","عام واحد",["عامان","عامين"],"%d أعوام","%d عامًا","%d عام"]},r=function(e){return function(t,r,s,i){var o=n(t),d=a[e][n(t)];return 2===o&&(d=d[r?0:1]),d.replace(/%d/i,t)}},s=["يناير","فبراير","مارس","أبريل","مايو","يونيو","يوليو","أغسطس","سبتمبر","أكتوبر","نوفمبر","ديسمبر"],i=e.defineLocale("ar-ly",{months:s,monthsShort:s,weekdays:"الأحد_الإثنين_الثلاثاء_الأربعاء_الخميس_الجمعة_السبت".split("_"),weekdaysShort:"أحد_إثنين_ثلاثاء_أربعاء_خميس_جمعة_سبت".split("_"),weekdaysMin:"ح_ن_ث_ر_خ_ج_س".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"D/‏M/‏YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd D MMMM YYYY HH:mm"},meridiemParse:/ص|م/,isPM:function(e){return"م"===e},meridiem:function(e,t,n){return e<12?"ص":"م"},calendar:{sameDay:"[اليوم عند الساعة] LT",nextDay:"[غدًا عند الساعة] LT",nextWeek:"dddd [عند الساعة] LT",lastDay:"[أمس عند الساعة] LT",lastWeek:"dddd [عند الساعة] LT",sameElse:"L"},relativeTime:{future:"بعد %s",past:"منذ %s",s:r("s"),m:r("m"),mm:r("m"),h:r("h"),hh:r("h"),d:r("d"),dd:r("d"),M:r("M"),MM:r("M"),y:r("y"),yy:r("y")},preparse:function(e){return e.replace(/\u200f/g,"").replace(/،/g,",")},postformat:function(e){return e.replace(/\d/g,function(e){return t[e]}).replace(/,/g,"،")},week:{dow:6,doy:12}});return i})},502:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("ar-ma",{months:"يناير_فبراير_مارس_أبريل_ماي_يونيو_يوليوز_غشت_شتنبر_أكتوبر_نونبر_دجنبر".split("_"),monthsShort:"يناير_فبراير_مارس_أبريل_ماي_يونيو_يوليوز_غشت_شتنبر_أكتوبر_نونبر_دجنبر".split("_"),weekdays:"الأحد_الإتنين_الثلاثاء_الأربعاء_الخميس_الجمعة_السبت".split("_"),weekdaysShort:"احد_اتنين_ثلاثاء_اربعاء_خميس_جمعة_سبت".split("_"),weekdaysMin:"ح_ن_ث_ر_خ_ج_س".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd D MMMM YYYY HH:mm"},calendar:{sameDay:"[اليوم على الساعة] LT",nextDay:"[غدا على الساعة] LT",nextWeek:"dddd [على الساعة] LT",lastDay:"[أمس على الساعة] LT",lastWeek:"dddd [على الساعة] LT",sameElse:"L"},relativeTime:{future:"في %s",past:"منذ %s",s:"ثوان",m:"دقيقة",mm:"%d دقائق",h:"ساعة",hh:"%d ساعات",d:"يوم",dd:"%d أيام",M:"شهر",MM:"%d أشهر",y:"سنة",yy:"%d سنوات"},week:{dow:6,doy:12}});return t})},503:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t={1:"١",2:"٢",3:"٣",4:"٤",5:"٥",6:"٦",7:"٧",8:"٨",9:"٩",0:"٠"},n={"١":"1","٢":"2","٣":"3","٤":"4","٥":"5","٦":"6","٧":"7","٨":"8","٩":"9","٠":"0"},a=e.defineLocale("ar-sa",{months:"يناير_فبراير_مارس_أبريل_مايو_يونيو_يوليو_أغسطس_سبتمبر_أكتوبر_نوفمبر_ديسمبر".split("_"),monthsShort:"يناير_فبراير_مارس_أبريل_مايو_يونيو_يوليو_أغسطس_سبتمبر_أكتوبر_نوفمبر_ديسمبر".split("_"),weekdays:"الأحد_الإثنين_الثلاثاء_الأربعاء_الخميس_الجمعة_السبت".split("_"),weekdaysShort:"أحد_إثنين_ثلاثاء_أربعاء_خميس_جمعة_سبت".split("_"),weekdaysMin:"ح_ن_ث_ر_خ_ج_س".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd D MMMM YYYY HH:mm"},meridiemParse:/ص|م/,isPM:function(e){return"م"===e},meridiem:function(e,t,n){return e<12?"ص":"م"},calendar:{sameDay:"[اليوم على الساعة] LT",nextDay:"[غدا على الساعة] LT",nextWeek:"dddd [على الساعة] LT",lastDay:"[أمس على الساعة] LT",lastWeek:"dddd [على الساعة] LT",sameElse:"L"},relativeTime:{future:"في %s",past:"منذ %s",s:"ثوان",m:"دقيقة",mm:"%d دقائق",h:"ساعة",hh:"%d ساعات",d:"يوم",dd:"%d أيام",M:"شهر",MM:"%d أشهر",y:"سنة",yy:"%d سنوات"},preparse:function(e){return e.replace(/[١٢٣٤٥٦٧٨٩٠]/g,function(e){return n[e]}).replace(/،/g,",")},postformat:function(e){return e.replace(/\d/g,function(e){return t[e]}).replace(/,/g,"،")},week:{dow:0,doy:6}});return a})},504:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("ar-tn",{months:"جانفي_فيفري_مارس_أفريل_ماي_جوان_جويلية_أوت_سبتمبر_أكتوبر_نوفمبر_ديسمبر".split("_"),monthsShort:"جانفي_فيفري_مارس_أفريل_ماي_جوان_جويلية_أوت_سبتمبر_أكتوبر_نوفمبر_ديسمبر".split("_"),weekdays:"الأحد_الإثنين_الثلاثاء_الأربعاء_الخميس_الجمعة_السبت".split("_"),weekdaysShort:"أحد_إثنين_ثلاثاء_أربعاء_خميس_جمعة_سبت".split("_"),weekdaysMin:"ح_ن_ث_ر_خ_ج_س".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd D MMMM YYYY HH:mm"},calendar:{sameDay:"[اليوم على الساعة] LT",nextDay:"[غدا على الساعة] LT",nextWeek:"dddd [على الساعة] LT",lastDay:"[أمس على الساعة] LT",lastWeek:"dddd [على الساعة] LT",sameElse:"L"},relativeTime:{future:"في %s",past:"منذ %s",s:"ثوان",m:"دقيقة",mm:"%d دقائق",h:"ساعة",hh:"%d ساعات",d:"يوم",dd:"%d أيام",M:"شهر",MM:"%d أشهر",y:"سنة",yy:"%d سنوات"},week:{dow:1,doy:4}});return t})},505:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t={1:"١",2:"٢",3:"٣",4:"٤",5:"٥",6:"٦",7:"٧",8:"٨",9:"٩",0:"٠"},n={"١":"1","٢":"2","٣":"3","٤":"4","٥":"5","٦":"6","٧":"7","٨":"8","٩":"9","٠":"0"},a=function(e){return 0===e?0:1===e?1:2===e?2:e%100>=3&&e%100<=10?3:e%100>=11?4:5},r={s:["أقل من ثانية","ثانية واحدة",["ثانيتان","ثانيتين"],"%d ثوان","%d ثانية","%d ثانية"],m:["أقل من دقيقة","دقيقة واحدة",["دقيقتان","دقيقتين"],"%d دقائق","%d دقيقة","%d دقيقة"],h:["أقل من ساعة","ساعة واحدة",["ساعتان","ساعتين"],"%d ساعات","%d ساعة","%d ساعة"],d:["أقل من يوم","يوم واحد",["يومان","يومين"],"%d أيام","%d يومًا","%d يوم"],M:["أقل من شهر","شهر واحد",["شهران","شهرين"],"%d أشهر","%d شهرا","%d شهر"],y:["أقل من عام","عام واحد",["عامان","عامين"],"%d أعوام","%d عامًا","%d عام"]},s=function(e){return function(t,n,s,i){var o=a(t),d=r[e][a(t)];return 2===o&&(d=d[n?0:1]),d.replace(/%d/i,t)}},i=["كانون الثاني يناير","شباط فبراير","آذار مارس","نيسان أبريل","أيار مايو","حزيران يونيو","تموز يوليو","آب أغسطس","أيلول سبتمبر","تشرين الأول أكتوبر","تشرين الثاني نوفمبر","كانون الأول ديسمبر"],o=e.defineLocale("ar",{months:i,monthsShort:i,weekdays:"الأحد_الإثنين_الثلاثاء_الأربعاء_الخميس_الجمعة_السبت".split("_"),weekdaysShort:"أحد_إثنين_ثلاثاء_أربعاء_خميس_جمعة_سبت".split("_"),weekdaysMin:"ح_ن_ث_ر_خ_ج_س".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"D/‏M/‏YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd D MMMM YYYY HH:mm"},meridiemParse:/ص|م/,isPM:function(e){return"م"===e},meridiem:function(e,t,n){return e<12?"ص":"م"},calendar:{sameDay:"[اليوم عند الساعة] LT",nextDay:"[غدًا عند الساعة] LT",nextWeek:"dddd [عند الساعة] LT",lastDay:"[أمس عند الساعة] LT",lastWeek:"dddd [عند الساعة] LT",sameElse:"L"},relativeTime:{future:"بعد %s",past:"منذ %s",s:s("s"),m:s("m"),mm:s("m"),h:s("h"),hh:s("h"),d:s("d"),dd:s("d"),M:s("M"),MM:s("M"),y:s("y"),yy:s("y")},preparse:function(e){return e.replace(/\u200f/g,"").replace(/[١٢٣٤٥٦٧٨٩٠]/g,function(e){return n[e]}).replace(/،/g,",")},postformat:function(e){return e.replace(/\d/g,function(e){return t[e]}).replace(/,/g,"،")},week:{dow:6,doy:12}});return o})},506:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t={1:"-inci",5:"-inci",8:"-inci",70:"-inci",80:"-inci",2:"-nci",7:"-nci",20:"-nci",50:"-nci",3:"-üncü",4:"-üncü",100:"-üncü",6:"-ncı",9:"-uncu",10:"-uncu",30:"-uncu",60:"-ıncı",90:"-ıncı"},n=e.defineLocale("az",{months:"yanvar_fevral_mart_aprel_may_iyun_iyul_avqust_sentyabr_oktyabr_noyabr_dekabr".split("_"),monthsShort:"yan_fev_mar_apr_may_iyn_iyl_avq_sen_okt_noy_dek".split("_"),weekdays:"Bazar_Bazar ertəsi_Çərşənbə axşamı_Çərşənbə_Cümə axşamı_Cümə_Şənbə".split("_"),weekdaysShort:"Baz_BzE_ÇAx_Çər_CAx_Cüm_Şən".split("_"),weekdaysMin:"Bz_BE_ÇA_Çə_CA_Cü_Şə".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD.MM.YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd, D MMMM YYYY HH:mm"},calendar:{sameDay:"[bugün saat] LT",nextDay:"[sabah saat] LT",nextWeek:"[gələn həftə] dddd [saat] LT",lastDay:"[dünən] LT",lastWeek:"[keçən həftə] dddd [saat] LT",sameElse:"L"},relativeTime:{future:"%s sonra",past:"%s əvvəl",s:"birneçə saniyyə",m:"bir dəqiqə",mm:"%d dəqiqə",h:"bir saat",hh:"%d saat",d:"bir gün",dd:"%d gün",M:"bir ay",MM:"%d ay",y:"bir il",yy:"%d il"},meridiemParse:/gecə|səhər|gündüz|axşam/,isPM:function(e){return/^(gündüz|axşam)$/.test(e)},meridiem:function(e,t,n){return e<4?"gecə":e<12?"səhər":e<17?"gündüz":"axşam"},ordinalParse:/\d{1,2}-(ıncı|inci|nci|üncü|ncı|uncu)/,ordinal:function(e){if(0===e)return e+"-ıncı";var n=e%10,a=e%100-n,r=e>=100?100:null;return e+(t[n]||t[a]||t[r])},week:{dow:1,doy:7}});return n})},507:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";function plural(e,t){var n=e.split("_");return t%10===1&&t%100!==11?n[0]:t%10>=2&&t%10<=4&&(t%100<10||t%100>=20)?n[1]:n[2]}function relativeTimeWithPlural(e,t,n){var a={mm:t?"хвіліна_хвіліны_хвілін":"хвіліну_хвіліны_хвілін",hh:t?"гадзіна_гадзіны_гадзін":"гадзіну_гадзіны_гадзін",dd:"дзень_дні_дзён",MM:"месяц_месяцы_месяцаў",yy:"год_гады_гадоў"};return"m"===n?t?"хвіліна":"хвіліну":"h"===n?t?"гадзіна":"гадзіну":e+" "+plural(a[n],+e)}var t=e.defineLocale("be",{months:{format:"студзеня_лютага_сакавіка_красавіка_траўня_чэрвеня_ліпеня_жніўня_верасня_кастрычніка_лістапада_снежня".split("_"),standalone:"студзень_люты_сакавік_красавік_травень_чэрвень_ліпень_жнівень_верасень_кастрычнік_лістапад_снежань".split("_")
 },monthsShort:"студ_лют_сак_крас_трав_чэрв_ліп_жнів_вер_каст_ліст_снеж".split("_"),weekdays:{format:"нядзелю_панядзелак_аўторак_сераду_чацвер_пятніцу_суботу".split("_"),standalone:"нядзеля_панядзелак_аўторак_серада_чацвер_пятніца_субота".split("_"),isFormat:/\[ ?[Вв] ?(?:мінулую|наступную)? ?\] ?dddd/},weekdaysShort:"нд_пн_ат_ср_чц_пт_сб".split("_"),weekdaysMin:"нд_пн_ат_ср_чц_пт_сб".split("_"),longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD.MM.YYYY",LL:"D MMMM YYYY г.",LLL:"D MMMM YYYY г., HH:mm",LLLL:"dddd, D MMMM YYYY г., HH:mm"},calendar:{sameDay:"[Сёння ў] LT",nextDay:"[Заўтра ў] LT",lastDay:"[Учора ў] LT",nextWeek:function(){return"[У] dddd [ў] LT"},lastWeek:function(){switch(this.day()){case 0:case 3:case 5:case 6:return"[У мінулую] dddd [ў] LT";case 1:case 2:case 4:return"[У мінулы] dddd [ў] LT"}},sameElse:"L"},relativeTime:{future:"праз %s",past:"%s таму",s:"некалькі секунд",m:relativeTimeWithPlural,mm:relativeTimeWithPlural,h:relativeTimeWithPlural,hh:relativeTimeWithPlural,d:"дзень",dd:relativeTimeWithPlural,M:"месяц",MM:relativeTimeWithPlural,y:"год",yy:relativeTimeWithPlural},meridiemParse:/ночы|раніцы|дня|вечара/,isPM:function(e){return/^(дня|вечара)$/.test(e)},meridiem:function(e,t,n){return e<4?"ночы":e<12?"раніцы":e<17?"дня":"вечара"},ordinalParse:/\d{1,2}-(і|ы|га)/,ordinal:function(e,t){switch(t){case"M":case"d":case"DDD":case"w":case"W":return e%10!==2&&e%10!==3||e%100===12||e%100===13?e+"-ы":e+"-і";case"D":return e+"-га";default:return e}},week:{dow:1,doy:7}});return t})},508:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("bg",{months:"януари_февруари_март_април_май_юни_юли_август_септември_октомври_ноември_декември".split("_"),monthsShort:"янр_фев_мар_апр_май_юни_юли_авг_сеп_окт_ное_дек".split("_"),weekdays:"неделя_понеделник_вторник_сряда_четвъртък_петък_събота".split("_"),weekdaysShort:"нед_пон_вто_сря_чет_пет_съб".split("_"),weekdaysMin:"нд_пн_вт_ср_чт_пт_сб".split("_"),longDateFormat:{LT:"H:mm",LTS:"H:mm:ss",L:"D.MM.YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY H:mm",LLLL:"dddd, D MMMM YYYY H:mm"},calendar:{sameDay:"[Днес в] LT",nextDay:"[Утре в] LT",nextWeek:"dddd [в] LT",lastDay:"[Вчера в] LT",lastWeek:function(){switch(this.day()){case 0:case 3:case 6:return"[В изминалата] dddd [в] LT";case 1:case 2:case 4:case 5:return"[В изминалия] dddd [в] LT"}},sameElse:"L"},relativeTime:{future:"след %s",past:"преди %s",s:"няколко секунди",m:"минута",mm:"%d минути",h:"час",hh:"%d часа",d:"ден",dd:"%d дни",M:"месец",MM:"%d месеца",y:"година",yy:"%d години"},ordinalParse:/\d{1,2}-(ев|ен|ти|ви|ри|ми)/,ordinal:function(e){var t=e%10,n=e%100;return 0===e?e+"-ев":0===n?e+"-ен":n>10&&n<20?e+"-ти":1===t?e+"-ви":2===t?e+"-ри":7===t||8===t?e+"-ми":e+"-ти"},week:{dow:1,doy:7}});return t})},509:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t={1:"১",2:"২",3:"৩",4:"৪",5:"৫",6:"৬",7:"৭",8:"৮",9:"৯",0:"০"},n={"১":"1","২":"2","৩":"3","৪":"4","৫":"5","৬":"6","৭":"7","৮":"8","৯":"9","০":"0"},a=e.defineLocale("bn",{months:"জানুয়ারী_ফেব্রুয়ারি_মার্চ_এপ্রিল_মে_জুন_জুলাই_আগস্ট_সেপ্টেম্বর_অক্টোবর_নভেম্বর_ডিসেম্বর".split("_"),monthsShort:"জানু_ফেব_মার্চ_এপ্র_মে_জুন_জুল_আগ_সেপ্ট_অক্টো_নভে_ডিসে".split("_"),weekdays:"রবিবার_সোমবার_মঙ্গলবার_বুধবার_বৃহস্পতিবার_শুক্রবার_শনিবার".split("_"),weekdaysShort:"রবি_সোম_মঙ্গল_বুধ_বৃহস্পতি_শুক্র_শনি".split("_"),weekdaysMin:"রবি_সোম_মঙ্গ_বুধ_বৃহঃ_শুক্র_শনি".split("_"),longDateFormat:{LT:"A h:mm সময়",LTS:"A h:mm:ss সময়",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY, A h:mm সময়",LLLL:"dddd, D MMMM YYYY, A h:mm সময়"},calendar:{sameDay:"[আজ] LT",nextDay:"[আগামীকাল] LT",nextWeek:"dddd, LT",lastDay:"[গতকাল] LT",lastWeek:"[গত] dddd, LT",sameElse:"L"},relativeTime:{future:"%s পরে",past:"%s আগে",s:"কয়েক সেকেন্ড",m:"এক মিনিট",mm:"%d মিনিট",h:"এক ঘন্টা",hh:"%d ঘন্টা",d:"এক দিন",dd:"%d দিন",M:"এক মাস",MM:"%d মাস",y:"এক বছর",yy:"%d বছর"},preparse:function(e){return e.replace(/[১২৩৪৫৬৭৮৯০]/g,function(e){return n[e]})},postformat:function(e){return e.replace(/\d/g,function(e){return t[e]})},meridiemParse:/রাত|সকাল|দুপুর|বিকাল|রাত/,meridiemHour:function(e,t){return 12===e&&(e=0),"রাত"===t&&e>=4||"দুপুর"===t&&e<5||"বিকাল"===t?e+12:e},meridiem:function(e,t,n){return e<4?"রাত":e<10?"সকাল":e<17?"দুপুর":e<20?"বিকাল":"রাত"},week:{dow:0,doy:6}});return a})},510:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t={1:"༡",2:"༢",3:"༣",4:"༤",5:"༥",6:"༦",7:"༧",8:"༨",9:"༩",0:"༠"},n={"༡":"1","༢":"2","༣":"3","༤":"4","༥":"5","༦":"6","༧":"7","༨":"8","༩":"9","༠":"0"},a=e.defineLocale("bo",{months:"ཟླ་བ་དང་པོ_ཟླ་བ་གཉིས་པ_ཟླ་བ་གསུམ་པ_ཟླ་བ་བཞི་པ_ཟླ་བ་ལྔ་པ_ཟླ་བ་དྲུག་པ_ཟླ་བ་བདུན་པ_ཟླ་བ་བརྒྱད་པ_ཟླ་བ་དགུ་པ_ཟླ་བ་བཅུ་པ_ཟླ་བ་བཅུ་གཅིག་པ_ཟླ་བ་བཅུ་གཉིས་པ".split("_"),monthsShort:"ཟླ་བ་དང་པོ_ཟླ་བ་གཉིས་པ_ཟླ་བ་གསུམ་པ_ཟླ་བ་བཞི་པ_ཟླ་བ་ལྔ་པ_ཟླ་བ་དྲུག་པ_ཟླ་བ་བདུན་པ_ཟླ་བ་བརྒྱད་པ_ཟླ་བ་དགུ་པ_ཟླ་བ་བཅུ་པ_ཟླ་བ་བཅུ་གཅིག་པ_ཟླ་བ་བཅུ་གཉིས་པ".split("_"),weekdays:"གཟའ་ཉི་མ་_གཟའ་ཟླ་བ་_གཟའ་མིག་དམར་_གཟའ་ལྷག་པ་_གཟའ་ཕུར་བུ_གཟའ་པ་སངས་_གཟའ་སྤེན་པ་".split("_"),weekdaysShort:"ཉི་མ་_ཟླ་བ་_མིག་དམར་_ལྷག་པ་_ཕུར་བུ_པ་སངས་_སྤེན་པ་".split("_"),weekdaysMin:"ཉི་མ་_ཟླ་བ་_མིག་དམར་_ལྷག་པ་_ཕུར་བུ_པ་སངས་_སྤེན་པ་".split("_"),longDateFormat:{LT:"A h:mm",LTS:"A h:mm:ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY, A h:mm",LLLL:"dddd, D MMMM YYYY, A h:mm"},calendar:{sameDay:"[དི་རིང] LT",nextDay:"[སང་ཉིན] LT",nextWeek:"[བདུན་ཕྲག་རྗེས་མ], LT",lastDay:"[ཁ་སང] LT",lastWeek:"[བདུན་ཕྲག་མཐའ་མ] dddd, LT",sameElse:"L"},relativeTime:{future:"%s ལ་",past:"%s སྔན་ལ",s:"ལམ་སང",m:"སྐར་མ་གཅིག",mm:"%d སྐར་མ",h:"ཆུ་ཚོད་གཅིག",hh:"%d ཆུ་ཚོད",d:"ཉིན་གཅིག",dd:"%d ཉིན་",M:"ཟླ་བ་གཅིག",MM:"%d ཟླ་བ",y:"ལོ་གཅིག",yy:"%d ལོ"},preparse:function(e){return e.replace(/[༡༢༣༤༥༦༧༨༩༠]/g,function(e){return n[e]})},postformat:function(e){return e.replace(/\d/g,function(e){return t[e]})},meridiemParse:/མཚན་མོ|ཞོགས་ཀས|ཉིན་གུང|དགོང་དག|མཚན་མོ/,meridiemHour:function(e,t){return 12===e&&(e=0),"མཚན་མོ"===t&&e>=4||"ཉིན་གུང"===t&&e<5||"དགོང་དག"===t?e+12:e},meridiem:function(e,t,n){return e<4?"མཚན་མོ":e<10?"ཞོགས་ཀས":e<17?"ཉིན་གུང":e<20?"དགོང་དག":"མཚན་མོ"},week:{dow:0,doy:6}});return a})},511:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";function relativeTimeWithMutation(e,t,n){var a={mm:"munutenn",MM:"miz",dd:"devezh"};return e+" "+mutation(a[n],e)}function specialMutationForYears(e){switch(lastNumber(e)){case 1:case 3:case 4:case 5:case 9:return e+" bloaz";default:return e+" vloaz"}}function lastNumber(e){return e>9?lastNumber(e%10):e}function mutation(e,t){return 2===t?softMutation(e):e}function softMutation(e){var t={m:"v",b:"v",d:"z"};return void 0===t[e.charAt(0)]?e:t[e.charAt(0)]+e.substring(1)}var t=e.defineLocale("br",{months:"Genver_C'hwevrer_Meurzh_Ebrel_Mae_Mezheven_Gouere_Eost_Gwengolo_Here_Du_Kerzu".split("_"),monthsShort:"Gen_C'hwe_Meu_Ebr_Mae_Eve_Gou_Eos_Gwe_Her_Du_Ker".split("_"),weekdays:"Sul_Lun_Meurzh_Merc'her_Yaou_Gwener_Sadorn".split("_"),weekdaysShort:"Sul_Lun_Meu_Mer_Yao_Gwe_Sad".split("_"),weekdaysMin:"Su_Lu_Me_Mer_Ya_Gw_Sa".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"h[e]mm A",LTS:"h[e]mm:ss A",L:"DD/MM/YYYY",LL:"D [a viz] MMMM YYYY",LLL:"D [a viz] MMMM YYYY h[e]mm A",LLLL:"dddd, D [a viz] MMMM YYYY h[e]mm A"},calendar:{sameDay:"[Hiziv da] LT",nextDay:"[Warc'hoazh da] LT",nextWeek:"dddd [da] LT",lastDay:"[Dec'h da] LT",lastWeek:"dddd [paset da] LT",sameElse:"L"},relativeTime:{future:"a-benn %s",past:"%s 'zo",s:"un nebeud segondennoù",m:"ur vunutenn",mm:relativeTimeWithMutation,h:"un eur",hh:"%d eur",d:"un devezh",dd:relativeTimeWithMutation,M:"ur miz",MM:relativeTimeWithMutation,y:"ur bloaz",yy:specialMutationForYears},ordinalParse:/\d{1,2}(añ|vet)/,ordinal:function(e){var t=1===e?"añ":"vet";return e+t},week:{dow:1,doy:4}});return t})},512:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";function translate(e,t,n){var a=e+" ";switch(n){case"m":return t?"jedna minuta":"jedne minute";case"mm":return a+=1===e?"minuta":2===e||3===e||4===e?"minute":"minuta";case"h":return t?"jedan sat":"jednog sata";case"hh":return a+=1===e?"sat":2===e||3===e||4===e?"sata":"sati";case"dd":return a+=1===e?"dan":"dana";case"MM":return a+=1===e?"mjesec":2===e||3===e||4===e?"mjeseca":"mjeseci";case"yy":return a+=1===e?"godina":2===e||3===e||4===e?"godine":"godina"}}var t=e.defineLocale("bs",{months:"januar_februar_mart_april_maj_juni_juli_august_septembar_oktobar_novembar_decembar".split("_"),monthsShort:"jan._feb._mar._apr._maj._jun._jul._aug._sep._okt._nov._dec.".split("_"),monthsParseExact:!0,weekdays:"nedjelja_ponedjeljak_utorak_srijeda_četvrtak_petak_subota".split("_"),weekdaysShort:"ned._pon._uto._sri._čet._pet._sub.".split("_"),weekdaysMin:"ne_po_ut_sr_če_pe_su".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"H:mm",LTS:"H:mm:ss",L:"DD.MM.YYYY",LL:"D. MMMM YYYY",LLL:"D. MMMM YYYY H:mm",LLLL:"dddd, D. MMMM YYYY H:mm"},calendar:{sameDay:"[danas u] LT",nextDay:"[sutra u] LT",nextWeek:function(){switch(this.day()){case 0:return"[u] [nedjelju] [u] LT";case 3:return"[u] [srijedu] [u] LT";case 6:return"[u] [subotu] [u] LT";case 1:case 2:case 4:case 5:return"[u] dddd [u] LT"}},lastDay:"[jučer u] LT",lastWeek:function(){switch(this.day()){case 0:case 3:return"[prošlu] dddd [u] LT";case 6:return"[prošle] [subote] [u] LT";case 1:case 2:case 4:case 5:return"[prošli] dddd [u] LT"}},sameElse:"L"},relativeTime:{future:"za %s",past:"prije %s",s:"par sekundi",m:translate,mm:translate,h:translate,hh:translate,d:"dan",dd:translate,M:"mjesec",MM:translate,y:"godinu",yy:translate},ordinalParse:/\d{1,2}\./,ordinal:"%d.",week:{dow:1,doy:7}});return t})},513:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("ca",{months:"gener_febrer_març_abril_maig_juny_juliol_agost_setembre_octubre_novembre_desembre".split("_"),monthsShort:"gen._febr._mar._abr._mai._jun._jul._ag._set._oct._nov._des.".split("_"),monthsParseExact:!0,weekdays:"diumenge_dilluns_dimarts_dimecres_dijous_divendres_dissabte".split("_"),weekdaysShort:"dg._dl._dt._dc._dj._dv._ds.".split("_"),weekdaysMin:"Dg_Dl_Dt_Dc_Dj_Dv_Ds".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"H:mm",LTS:"H:mm:ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY H:mm",LLLL:"dddd D MMMM YYYY H:mm"},calendar:{sameDay:function(){return"[avui a "+(1!==this.hours()?"les":"la")+"] LT"},nextDay:function(){return"[demà a "+(1!==this.hours()?"les":"la")+"] LT"},nextWeek:function(){return"dddd [a "+(1!==this.hours()?"les":"la")+"] LT"},lastDay:function(){return"[ahir a "+(1!==this.hours()?"les":"la")+"] LT"},lastWeek:function(){return"[el] dddd [passat a "+(1!==this.hours()?"les":"la")+"] LT"},sameElse:"L"},relativeTime:{future:"d'aquí %s",past:"fa %s",s:"uns segons",m:"un minut",mm:"%d minuts",h:"una hora",hh:"%d hores",d:"un dia",dd:"%d dies",M:"un mes",MM:"%d mesos",y:"un any",yy:"%d anys"},ordinalParse:/\d{1,2}(r|n|t|è|a)/,ordinal:function(e,t){var n=1===e?"r":2===e?"n":3===e?"r":4===e?"t":"è";return"w"!==t&&"W"!==t||(n="a"),e+n},week:{dow:1,doy:4}});return t})},514:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";function plural(e){return e>1&&e<5&&1!==~~(e/10)}function translate(e,t,n,a){var r=e+" ";switch(n){case"s":return t||a?"pár sekund":"pár sekundami";case"m":return t?"minuta":a?"minutu":"minutou";case"mm":return t||a?r+(plural(e)?"minuty":"minut"):r+"minutami";case"h":return t?"hodina":a?"hodinu":"hodinou";case"hh":return t||a?r+(plural(e)?"hodiny":"hodin"):r+"hodinami";case"d":return t||a?"den":"dnem";case"dd":return t||a?r+(plural(e)?"dny":"dní"):r+"dny";case"M":return t||a?"měsíc":"měsícem";case"MM":return t||a?r+(plural(e)?"měsíce":"měsíců"):r+"měsíci";case"y":return t||a?"rok":"rokem";case"yy":return t||a?r+(plural(e)?"roky":"let"):r+"lety"}}var t="leden_únor_březen_duben_květen_červen_červenec_srpen_září_říjen_listopad_prosinec".split("_"),n="led_úno_bře_dub_kvě_čvn_čvc_srp_zář_říj_lis_pro".split("_"),a=e.defineLocale("cs",{months:t,monthsShort:n,monthsParse:function(e,t){var n,a=[];for(n=0;n<12;n++)a[n]=new RegExp("^"+e[n]+"$|^"+t[n]+"$","i");return a}(t,n),shortMonthsParse:function(e){var t,n=[];for(t=0;t<12;t++)n[t]=new RegExp("^"+e[t]+"$","i");return n}(n),longMonthsParse:function(e){var t,n=[];for(t=0;t<12;t++)n[t]=new RegExp("^"+e[t]+"$","i");return n}(t),weekdays:"neděle_pondělí_úterý_středa_čtvrtek_pátek_sobota".split("_"),weekdaysShort:"ne_po_út_st_čt_pá_so".split("_"),weekdaysMin:"ne_po_út_st_čt_pá_so".split("_"),longDateFormat:{LT:"H:mm",LTS:"H:mm:ss",L:"DD.MM.YYYY",LL:"D. MMMM YYYY",LLL:"D. MMMM YYYY H:mm",LLLL:"dddd D. MMMM YYYY H:mm",l:"D. M. YYYY"},calendar:{sameDay:"[dnes v] LT",nextDay:"[zítra v] LT",nextWeek:function(){switch(this.day()){case 0:return"[v neděli v] LT";case 1:case 2:return"[v] dddd [v] LT";case 3:return"[ve středu v] LT";case 4:return"[ve čtvrtek v] LT";case 5:return"[v pátek v] LT";case 6:return"[v sobotu v] LT"}},lastDay:"[včera v] LT",lastWeek:function(){switch(this.day()){case 0:return"[minulou neděli v] LT";case 1:case 2:return"[minulé] dddd [v] LT";case 3:return"[minulou středu v] LT";case 4:case 5:return"[minulý] dddd [v] LT";case 6:return"[minulou sobotu v] LT"}},sameElse:"L"},relativeTime:{future:"za %s",past:"před %s",s:translate,m:translate,mm:translate,h:translate,hh:translate,d:translate,dd:translate,M:translate,MM:translate,y:translate,yy:translate},ordinalParse:/\d{1,2}\./,ordinal:"%d.",week:{dow:1,doy:4}});return a})},515:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("cv",{months:"кӑрлач_нарӑс_пуш_ака_май_ҫӗртме_утӑ_ҫурла_авӑн_юпа_чӳк_раштав".split("_"),monthsShort:"кӑр_нар_пуш_ака_май_ҫӗр_утӑ_ҫур_авн_юпа_чӳк_раш".split("_"),weekdays:"вырсарникун_тунтикун_ытларикун_юнкун_кӗҫнерникун_эрнекун_шӑматкун".split("_"),weekdaysShort:"выр_тун_ытл_юн_кӗҫ_эрн_шӑм".split("_"),weekdaysMin:"вр_тн_ыт_юн_кҫ_эр_шм".split("_"),longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD-MM-YYYY",LL:"YYYY [ҫулхи] MMMM [уйӑхӗн] D[-мӗшӗ]",LLL:"YYYY [ҫулхи] MMMM [уйӑхӗн] D[-мӗшӗ], HH:mm",LLLL:"dddd, YYYY [ҫулхи] MMMM [уйӑхӗн] D[-мӗшӗ], HH:mm"},calendar:{sameDay:"[Паян] LT [сехетре]",nextDay:"[Ыран] LT [сехетре]",lastDay:"[Ӗнер] LT [сехетре]",nextWeek:"[Ҫитес] dddd LT [сехетре]",lastWeek:"[Иртнӗ] dddd LT [сехетре]",sameElse:"L"},relativeTime:{future:function(e){var t=/сехет$/i.exec(e)?"рен":/ҫул$/i.exec(e)?"тан":"ран";return e+t},past:"%s каялла",s:"пӗр-ик ҫеккунт",m:"пӗр минут",mm:"%d минут",h:"пӗр сехет",hh:"%d сехет",d:"пӗр кун",dd:"%d кун",M:"пӗр уйӑх",MM:"%d уйӑх",y:"пӗр ҫул",yy:"%d ҫул"},ordinalParse:/\d{1,2}-мӗш/,ordinal:"%d-мӗш",week:{dow:1,doy:7}});return t})},516:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("cy",{months:"Ionawr_Chwefror_Mawrth_Ebrill_Mai_Mehefin_Gorffennaf_Awst_Medi_Hydref_Tachwedd_Rhagfyr".split("_"),monthsShort:"Ion_Chwe_Maw_Ebr_Mai_Meh_Gor_Aws_Med_Hyd_Tach_Rhag".split("_"),weekdays:"Dydd Sul_Dydd Llun_Dydd Mawrth_Dydd Mercher_Dydd Iau_Dydd Gwener_Dydd Sadwrn".split("_"),weekdaysShort:"Sul_Llun_Maw_Mer_Iau_Gwe_Sad".split("_"),weekdaysMin:"Su_Ll_Ma_Me_Ia_Gw_Sa".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd, D MMMM YYYY HH:mm"},calendar:{sameDay:"[Heddiw am] LT",nextDay:"[Yfory am] LT",nextWeek:"dddd [am] LT",lastDay:"[Ddoe am] LT",lastWeek:"dddd [diwethaf am] LT",sameElse:"L"},relativeTime:{future:"mewn %s",past:"%s yn ôl",s:"ychydig eiliadau",m:"munud",mm:"%d munud",h:"awr",hh:"%d awr",d:"diwrnod",dd:"%d diwrnod",M:"mis",MM:"%d mis",y:"blwyddyn",yy:"%d flynedd"},ordinalParse:/\d{1,2}(fed|ain|af|il|ydd|ed|eg)/,ordinal:function(e){var t=e,n="",a=["","af","il","ydd","ydd","ed","ed","ed","fed","fed","fed","eg","fed","eg","eg","fed","eg","eg","fed","eg","fed"];return t>20?n=40===t||50===t||60===t||80===t||100===t?"fed":"ain":t>0&&(n=a[t]),e+n},week:{dow:1,doy:4}});return t})},517:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("da",{months:"januar_februar_marts_april_maj_juni_juli_august_september_oktober_november_december".split("_"),monthsShort:"jan_feb_mar_apr_maj_jun_jul_aug_sep_okt_nov_dec".split("_"),weekdays:"søndag_mandag_tirsdag_onsdag_torsdag_fredag_lørdag".split("_"),weekdaysShort:"søn_man_tir_ons_tor_fre_lør".split("_"),weekdaysMin:"sø_ma_ti_on_to_fr_lø".split("_"),longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD/MM/YYYY",LL:"D. MMMM YYYY",LLL:"D. MMMM YYYY HH:mm",LLLL:"dddd [d.] D. MMMM YYYY HH:mm"},calendar:{sameDay:"[I dag kl.] LT",nextDay:"[I morgen kl.] LT",nextWeek:"dddd [kl.] LT",lastDay:"[I går kl.] LT",lastWeek:"[sidste] dddd [kl] LT",sameElse:"L"},relativeTime:{future:"om %s",past:"%s siden",s:"få sekunder",m:"et minut",mm:"%d minutter",h:"en time",hh:"%d timer",d:"en dag",dd:"%d dage",M:"en måned",MM:"%d måneder",y:"et år",yy:"%d år"},ordinalParse:/\d{1,2}\./,ordinal:"%d.",week:{dow:1,doy:4}});return t})},518:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";function processRelativeTime(e,t,n,a){var r={m:["eine Minute","einer Minute"],h:["eine Stunde","einer Stunde"],d:["ein Tag","einem Tag"],dd:[e+" Tage",e+" Tagen"],M:["ein Monat","einem Monat"],MM:[e+" Monate",e+" Monaten"],y:["ein Jahr","einem Jahr"],yy:[e+" Jahre",e+" Jahren"]};return t?r[n][0]:r[n][1]}var t=e.defineLocale("de-at",{months:"Jänner_Februar_März_April_Mai_Juni_Juli_August_September_Oktober_November_Dezember".split("_"),monthsShort:"Jän._Febr._Mrz._Apr._Mai_Jun._Jul._Aug._Sept._Okt._Nov._Dez.".split("_"),monthsParseExact:!0,weekdays:"Sonntag_Montag_Dienstag_Mittwoch_Donnerstag_Freitag_Samstag".split("_"),weekdaysShort:"So._Mo._Di._Mi._Do._Fr._Sa.".split("_"),weekdaysMin:"So_Mo_Di_Mi_Do_Fr_Sa".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD.MM.YYYY",LL:"D. MMMM YYYY",LLL:"D. MMMM YYYY HH:mm",LLLL:"dddd, D. MMMM YYYY HH:mm"},calendar:{sameDay:"[heute um] LT [Uhr]",sameElse:"L",nextDay:"[morgen um] LT [Uhr]",nextWeek:"dddd [um] LT [Uhr]",lastDay:"[gestern um] LT [Uhr]",lastWeek:"[letzten] dddd [um] LT [Uhr]"},relativeTime:{future:"in %s",past:"vor %s",s:"ein paar Sekunden",m:processRelativeTime,mm:"%d Minuten",h:processRelativeTime,hh:"%d Stunden",d:processRelativeTime,dd:processRelativeTime,M:processRelativeTime,MM:processRelativeTime,y:processRelativeTime,yy:processRelativeTime},ordinalParse:/\d{1,2}\./,ordinal:"%d.",week:{dow:1,doy:4}});return t})},519:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";function processRelativeTime(e,t,n,a){var r={m:["eine Minute","einer Minute"],h:["eine Stunde","einer Stunde"],d:["ein Tag","einem Tag"],dd:[e+" Tage",e+" Tagen"],M:["ein Monat","einem Monat"],MM:[e+" Monate",e+" Monaten"],y:["ein Jahr","einem Jahr"],yy:[e+" Jahre",e+" Jahren"]};return t?r[n][0]:r[n][1]}var t=e.defineLocale("de",{months:"Januar_Februar_März_April_Mai_Juni_Juli_August_September_Oktober_November_Dezember".split("_"),monthsShort:"Jan._Febr._Mrz._Apr._Mai_Jun._Jul._Aug._Sept._Okt._Nov._Dez.".split("_"),monthsParseExact:!0,weekdays:"Sonntag_Montag_Dienstag_Mittwoch_Donnerstag_Freitag_Samstag".split("_"),weekdaysShort:"So._Mo._Di._Mi._Do._Fr._Sa.".split("_"),weekdaysMin:"So_Mo_Di_Mi_Do_Fr_Sa".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD.MM.YYYY",LL:"D. MMMM YYYY",LLL:"D. MMMM YYYY HH:mm",LLLL:"dddd, D. MMMM YYYY HH:mm"},calendar:{sameDay:"[heute um] LT [Uhr]",sameElse:"L",nextDay:"[morgen um] LT [Uhr]",nextWeek:"dddd [um] LT [Uhr]",lastDay:"[gestern um] LT [Uhr]",lastWeek:"[letzten] dddd [um] LT [Uhr]"},relativeTime:{future:"in %s",past:"vor %s",s:"ein paar Sekunden",m:processRelativeTime,mm:"%d Minuten",h:processRelativeTime,hh:"%d Stunden",d:processRelativeTime,dd:processRelativeTime,M:processRelativeTime,MM:processRelativeTime,y:processRelativeTime,yy:processRelativeTime},ordinalParse:/\d{1,2}\./,ordinal:"%d.",week:{dow:1,doy:4}});return t})},520:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=["ޖެނުއަރީ","ފެބްރުއަރީ","މާރިޗު","އޭޕްރީލު","މޭ","ޖޫން","ޖުލައި","އޯގަސްޓު","ސެޕްޓެމްބަރު","އޮކްޓޯބަރު","ނޮވެމްބަރު","ޑިސެމްބަރު"],n=["އާދިއްތަ","ހޯމަ","އަންގާރަ","ބުދަ","ބުރާސްފަތި","ހުކުރު","ހޮނިހިރު"],a=e.defineLocale("dv",{months:t,monthsShort:t,weekdays:n,weekdaysShort:n,weekdaysMin:"އާދި_ހޯމަ_އަން_ބުދަ_ބުރާ_ހުކު_ހޮނި".split("_"),longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"D/M/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd D MMMM YYYY HH:mm"},meridiemParse:/މކ|މފ/,isPM:function(e){return"މފ"===e},meridiem:function(e,t,n){return e<12?"މކ":"މފ"},calendar:{sameDay:"[މިއަދު] LT",nextDay:"[މާދަމާ] LT",nextWeek:"dddd LT",lastDay:"[އިއްޔެ] LT",lastWeek:"[ފާއިތުވި] dddd LT",sameElse:"L"},relativeTime:{future:"ތެރޭގައި %s",past:"ކުރިން %s",s:"ސިކުންތުކޮޅެއް",m:"މިނިޓެއް",mm:"މިނިޓު %d",h:"ގަޑިއިރެއް",hh:"ގަޑިއިރު %d",d:"ދުވަހެއް",dd:"ދުވަސް %d",M:"މަހެއް",MM:"މަސް %d",y:"އަހަރެއް",yy:"އަހަރު %d"},preparse:function(e){return e.replace(/،/g,",")},postformat:function(e){return e.replace(/,/g,"،")},week:{dow:7,doy:12}});return a})},521:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";function isFunction(e){return e instanceof Function||"[object Function]"===Object.prototype.toString.call(e)}var t=e.defineLocale("el",{monthsNominativeEl:"Ιανουάριος_Φεβρουάριος_Μάρτιος_Απρίλιος_Μάιος_Ιούνιος_Ιούλιος_Αύγουστος_Σεπτέμβριος_Οκτώβριος_Νοέμβριος_Δεκέμβριος".split("_"),monthsGenitiveEl:"Ιανουαρίου_Φεβρουαρίου_Μαρτίου_Απριλίου_Μαΐου_Ιουνίου_Ιουλίου_Αυγούστου_Σεπτεμβρίου_Οκτωβρίου_Νοεμβρίου_Δεκεμβρίου".split("_"),months:function(e,t){return/D/.test(t.substring(0,t.indexOf("MMMM")))?this._monthsGenitiveEl[e.month()]:this._monthsNominativeEl[e.month()]},monthsShort:"Ιαν_Φεβ_Μαρ_Απρ_Μαϊ_Ιουν_Ιουλ_Αυγ_Σεπ_Οκτ_Νοε_Δεκ".split("_"),weekdays:"Κυριακή_Δευτέρα_Τρίτη_Τετάρτη_Πέμπτη_Παρασκευή_Σάββατο".split("_"),weekdaysShort:"Κυρ_Δευ_Τρι_Τετ_Πεμ_Παρ_Σαβ".split("_"),weekdaysMin:"Κυ_Δε_Τρ_Τε_Πε_Πα_Σα".split("_"),meridiem:function(e,t,n){return e>11?n?"μμ":"ΜΜ":n?"πμ":"ΠΜ"},isPM:function(e){return"μ"===(e+"").toLowerCase()[0]},meridiemParse:/[ΠΜ]\.?Μ?\.?/i,longDateFormat:{LT:"h:mm A",LTS:"h:mm:ss A",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY h:mm A",LLLL:"dddd, D MMMM YYYY h:mm A"},calendarEl:{sameDay:"[Σήμερα {}] LT",nextDay:"[Αύριο {}] LT",nextWeek:"dddd [{}] LT",lastDay:"[Χθες {}] LT",lastWeek:function(){switch(this.day()){case 6:return"[το προηγούμενο] dddd [{}] LT";default:return"[την προηγούμενη] dddd [{}] LT"}},sameElse:"L"},calendar:function(e,t){var n=this._calendarEl[e],a=t&&t.hours();return isFunction(n)&&(n=n.apply(t)),n.replace("{}",a%12===1?"στη":"στις")},relativeTime:{future:"σε %s",past:"%s πριν",s:"λίγα δευτερόλεπτα",m:"ένα λεπτό",mm:"%d λεπτά",h:"μία ώρα",hh:"%d ώρες",d:"μία μέρα",dd:"%d μέρες",M:"ένας μήνας",MM:"%d μήνες",y:"ένας χρόνος",yy:"%d χρόνια"},ordinalParse:/\d{1,2}η/,ordinal:"%dη",week:{dow:1,doy:4}});return t})},522:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("en-au",{months:"January_February_March_April_May_June_July_August_September_October_November_December".split("_"),monthsShort:"Jan_Feb_Mar_Apr_May_Jun_Jul_Aug_Sep_Oct_Nov_Dec".split("_"),weekdays:"Sunday_Monday_Tuesday_Wednesday_Thursday_Friday_Saturday".split("_"),weekdaysShort:"Sun_Mon_Tue_Wed_Thu_Fri_Sat".split("_"),weekdaysMin:"Su_Mo_Tu_We_Th_Fr_Sa".split("_"),longDateFormat:{LT:"h:mm A",LTS:"h:mm:ss A",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY h:mm A",LLLL:"dddd, D MMMM YYYY h:mm A"},calendar:{sameDay:"[Today at] LT",nextDay:"[Tomorrow at] LT",nextWeek:"dddd [at] LT",lastDay:"[Yesterday at] LT",lastWeek:"[Last] dddd [at] LT",sameElse:"L"},relativeTime:{future:"in %s",past:"%s ago",s:"a few seconds",m:"a minute",mm:"%d minutes",h:"an hour",hh:"%d hours",d:"a day",dd:"%d days",M:"a month",MM:"%d months",y:"a year",yy:"%d years"},ordinalParse:/\d{1,2}(st|nd|rd|th)/,ordinal:function(e){var t=e%10,n=1===~~(e%100/10)?"th":1===t?"st":2===t?"nd":3===t?"rd":"th";return e+n},week:{dow:1,doy:4}});return t})},523:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("en-ca",{months:"January_February_March_April_May_June_July_August_September_October_November_December".split("_"),monthsShort:"Jan_Feb_Mar_Apr_May_Jun_Jul_Aug_Sep_Oct_Nov_Dec".split("_"),weekdays:"Sunday_Monday_Tuesday_Wednesday_Thursday_Friday_Saturday".split("_"),weekdaysShort:"Sun_Mon_Tue_Wed_Thu_Fri_Sat".split("_"),weekdaysMin:"Su_Mo_Tu_We_Th_Fr_Sa".split("_"),longDateFormat:{LT:"h:mm A",LTS:"h:mm:ss A",L:"YYYY-MM-DD",LL:"MMMM D, YYYY",LLL:"MMMM D, YYYY h:mm A",LLLL:"dddd, MMMM D, YYYY h:mm A"},calendar:{sameDay:"[Today at] LT",nextDay:"[Tomorrow at] LT",nextWeek:"dddd [at] LT",lastDay:"[Yesterday at] LT",lastWeek:"[Last] dddd [at] LT",sameElse:"L"},relativeTime:{future:"in %s",past:"%s ago",s:"a few seconds",m:"a minute",mm:"%d minutes",h:"an hour",hh:"%d hours",d:"a day",dd:"%d days",M:"a month",MM:"%d months",y:"a year",yy:"%d years"},ordinalParse:/\d{1,2}(st|nd|rd|th)/,ordinal:function(e){var t=e%10,n=1===~~(e%100/10)?"th":1===t?"st":2===t?"nd":3===t?"rd":"th";return e+n}});return t})},524:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("en-gb",{months:"January_February_March_April_May_June_July_August_September_October_November_December".split("_"),monthsShort:"Jan_Feb_Mar_Apr_May_Jun_Jul_Aug_Sep_Oct_Nov_Dec".split("_"),weekdays:"Sunday_Monday_Tuesday_Wednesday_Thursday_Friday_Saturday".split("_"),weekdaysShort:"Sun_Mon_Tue_Wed_Thu_Fri_Sat".split("_"),weekdaysMin:"Su_Mo_Tu_We_Th_Fr_Sa".split("_"),longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd, D MMMM YYYY HH:mm"},calendar:{sameDay:"[Today at] LT",nextDay:"[Tomorrow at] LT",nextWeek:"dddd [at] LT",lastDay:"[Yesterday at] LT",lastWeek:"[Last] dddd [at] LT",sameElse:"L"},relativeTime:{future:"in %s",past:"%s ago",s:"a few seconds",m:"a minute",mm:"%d minutes",h:"an hour",hh:"%d hours",d:"a day",dd:"%d days",M:"a month",MM:"%d months",y:"a year",yy:"%d years"},ordinalParse:/\d{1,2}(st|nd|rd|th)/,ordinal:function(e){var t=e%10,n=1===~~(e%100/10)?"th":1===t?"st":2===t?"nd":3===t?"rd":"th";return e+n},week:{dow:1,doy:4}});return t})},525:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("en-ie",{months:"January_February_March_April_May_June_July_August_September_October_November_December".split("_"),monthsShort:"Jan_Feb_Mar_Apr_May_Jun_Jul_Aug_Sep_Oct_Nov_Dec".split("_"),weekdays:"Sunday_Monday_Tuesday_Wednesday_Thursday_Friday_Saturday".split("_"),weekdaysShort:"Sun_Mon_Tue_Wed_Thu_Fri_Sat".split("_"),weekdaysMin:"Su_Mo_Tu_We_Th_Fr_Sa".split("_"),longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD-MM-YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd D MMMM YYYY HH:mm"},calendar:{sameDay:"[Today at] LT",nextDay:"[Tomorrow at] LT",nextWeek:"dddd [at] LT",lastDay:"[Yesterday at] LT",lastWeek:"[Last] dddd [at] LT",sameElse:"L"},relativeTime:{future:"in %s",past:"%s ago",s:"a few seconds",m:"a minute",mm:"%d minutes",h:"an hour",hh:"%d hours",d:"a day",dd:"%d days",M:"a month",MM:"%d months",y:"a year",yy:"%d years"},ordinalParse:/\d{1,2}(st|nd|rd|th)/,ordinal:function(e){var t=e%10,n=1===~~(e%100/10)?"th":1===t?"st":2===t?"nd":3===t?"rd":"th";return e+n},week:{dow:1,doy:4}});return t})},526:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("en-nz",{months:"January_February_March_April_May_June_July_August_September_October_November_December".split("_"),monthsShort:"Jan_Feb_Mar_Apr_May_Jun_Jul_Aug_Sep_Oct_Nov_Dec".split("_"),weekdays:"Sunday_Monday_Tuesday_Wednesday_Thursday_Friday_Saturday".split("_"),weekdaysShort:"Sun_Mon_Tue_Wed_Thu_Fri_Sat".split("_"),weekdaysMin:"Su_Mo_Tu_We_Th_Fr_Sa".split("_"),longDateFormat:{LT:"h:mm A",LTS:"h:mm:ss A",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY h:mm A",LLLL:"dddd, D MMMM YYYY h:mm A"},calendar:{sameDay:"[Today at] LT",nextDay:"[Tomorrow at] LT",nextWeek:"dddd [at] LT",lastDay:"[Yesterday at] LT",lastWeek:"[Last] dddd [at] LT",sameElse:"L"},relativeTime:{future:"in %s",past:"%s ago",s:"a few seconds",m:"a minute",mm:"%d minutes",h:"an hour",hh:"%d hours",d:"a day",dd:"%d days",M:"a month",MM:"%d months",y:"a year",yy:"%d years"},ordinalParse:/\d{1,2}(st|nd|rd|th)/,ordinal:function(e){var t=e%10,n=1===~~(e%100/10)?"th":1===t?"st":2===t?"nd":3===t?"rd":"th";return e+n},week:{dow:1,doy:4}});return t})},527:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("eo",{months:"januaro_februaro_marto_aprilo_majo_junio_julio_aŭgusto_septembro_oktobro_novembro_decembro".split("_"),monthsShort:"jan_feb_mar_apr_maj_jun_jul_aŭg_sep_okt_nov_dec".split("_"),weekdays:"Dimanĉo_Lundo_Mardo_Merkredo_Ĵaŭdo_Vendredo_Sabato".split("_"),weekdaysShort:"Dim_Lun_Mard_Merk_Ĵaŭ_Ven_Sab".split("_"),weekdaysMin:"Di_Lu_Ma_Me_Ĵa_Ve_Sa".split("_"),longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"YYYY-MM-DD",LL:"D[-an de] MMMM, YYYY",LLL:"D[-an de] MMMM, YYYY HH:mm",LLLL:"dddd, [la] D[-an de] MMMM, YYYY HH:mm"},meridiemParse:/[ap]\.t\.m/i,isPM:function(e){return"p"===e.charAt(0).toLowerCase()},meridiem:function(e,t,n){return e>11?n?"p.t.m.":"P.T.M.":n?"a.t.m.":"A.T.M."},calendar:{sameDay:"[Hodiaŭ je] LT",nextDay:"[Morgaŭ je] LT",nextWeek:"dddd [je] LT",lastDay:"[Hieraŭ je] LT",lastWeek:"[pasinta] dddd [je] LT",sameElse:"L"},relativeTime:{future:"je %s",past:"antaŭ %s",s:"sekundoj",m:"minuto",mm:"%d minutoj",h:"horo",hh:"%d horoj",d:"tago",dd:"%d tagoj",M:"monato",MM:"%d monatoj",y:"jaro",yy:"%d jaroj"},ordinalParse:/\d{1,2}a/,ordinal:"%da",week:{dow:1,doy:7}});return t})},528:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t="ene._feb._mar._abr._may._jun._jul._ago._sep._oct._nov._dic.".split("_"),n="ene_feb_mar_abr_may_jun_jul_ago_sep_oct_nov_dic".split("_"),a=e.defineLocale("es-do",{months:"enero_febrero_marzo_abril_mayo_junio_julio_agosto_septiembre_octubre_noviembre_diciembre".split("_"),monthsShort:function(e,a){return/-MMM-/.test(a)?n[e.month()]:t[e.month()]},monthsParseExact:!0,weekdays:"domingo_lunes_martes_miércoles_jueves_viernes_sábado".split("_"),weekdaysShort:"dom._lun._mar._mié._jue._vie._sáb.".split("_"),weekdaysMin:"do_lu_ma_mi_ju_vi_sá".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"h:mm A",LTS:"h:mm:ss A",L:"DD/MM/YYYY",LL:"D [de] MMMM [de] YYYY",LLL:"D [de] MMMM [de] YYYY h:mm A",LLLL:"dddd, D [de] MMMM [de] YYYY h:mm A"},calendar:{sameDay:function(){return"[hoy a la"+(1!==this.hours()?"s":"")+"] LT"},nextDay:function(){return"[mañana a la"+(1!==this.hours()?"s":"")+"] LT"},nextWeek:function(){return"dddd [a la"+(1!==this.hours()?"s":"")+"] LT"},lastDay:function(){return"[ayer a la"+(1!==this.hours()?"s":"")+"] LT"},lastWeek:function(){return"[el] dddd [pasado a la"+(1!==this.hours()?"s":"")+"] LT"},sameElse:"L"},relativeTime:{future:"en %s",past:"hace %s",s:"unos segundos",m:"un minuto",mm:"%d minutos",h:"una hora",hh:"%d horas",d:"un día",dd:"%d días",M:"un mes",MM:"%d meses",y:"un año",yy:"%d años"},ordinalParse:/\d{1,2}º/,ordinal:"%dº",week:{dow:1,doy:4}});return a})},529:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t="ene._feb._mar._abr._may._jun._jul._ago._sep._oct._nov._dic.".split("_"),n="ene_feb_mar_abr_may_jun_jul_ago_sep_oct_nov_dic".split("_"),a=e.defineLocale("es",{months:"enero_febrero_marzo_abril_mayo_junio_julio_agosto_septiembre_octubre_noviembre_diciembre".split("_"),monthsShort:function(e,a){return/-MMM-/.test(a)?n[e.month()]:t[e.month()]},monthsParseExact:!0,weekdays:"domingo_lunes_martes_miércoles_jueves_viernes_sábado".split("_"),weekdaysShort:"dom._lun._mar._mié._jue._vie._sáb.".split("_"),weekdaysMin:"do_lu_ma_mi_ju_vi_sá".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"H:mm",LTS:"H:mm:ss",L:"DD/MM/YYYY",LL:"D [de] MMMM [de] YYYY",LLL:"D [de] MMMM [de] YYYY H:mm",LLLL:"dddd, D [de] MMMM [de] YYYY H:mm"},calendar:{sameDay:function(){return"[hoy a la"+(1!==this.hours()?"s":"")+"] LT"},nextDay:function(){return"[mañana a la"+(1!==this.hours()?"s":"")+"] LT";
 },nextWeek:function(){return"dddd [a la"+(1!==this.hours()?"s":"")+"] LT"},lastDay:function(){return"[ayer a la"+(1!==this.hours()?"s":"")+"] LT"},lastWeek:function(){return"[el] dddd [pasado a la"+(1!==this.hours()?"s":"")+"] LT"},sameElse:"L"},relativeTime:{future:"en %s",past:"hace %s",s:"unos segundos",m:"un minuto",mm:"%d minutos",h:"una hora",hh:"%d horas",d:"un día",dd:"%d días",M:"un mes",MM:"%d meses",y:"un año",yy:"%d años"},ordinalParse:/\d{1,2}º/,ordinal:"%dº",week:{dow:1,doy:4}});return a})},530:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";function processRelativeTime(e,t,n,a){var r={s:["mõne sekundi","mõni sekund","paar sekundit"],m:["ühe minuti","üks minut"],mm:[e+" minuti",e+" minutit"],h:["ühe tunni","tund aega","üks tund"],hh:[e+" tunni",e+" tundi"],d:["ühe päeva","üks päev"],M:["kuu aja","kuu aega","üks kuu"],MM:[e+" kuu",e+" kuud"],y:["ühe aasta","aasta","üks aasta"],yy:[e+" aasta",e+" aastat"]};return t?r[n][2]?r[n][2]:r[n][1]:a?r[n][0]:r[n][1]}var t=e.defineLocale("et",{months:"jaanuar_veebruar_märts_aprill_mai_juuni_juuli_august_september_oktoober_november_detsember".split("_"),monthsShort:"jaan_veebr_märts_apr_mai_juuni_juuli_aug_sept_okt_nov_dets".split("_"),weekdays:"pühapäev_esmaspäev_teisipäev_kolmapäev_neljapäev_reede_laupäev".split("_"),weekdaysShort:"P_E_T_K_N_R_L".split("_"),weekdaysMin:"P_E_T_K_N_R_L".split("_"),longDateFormat:{LT:"H:mm",LTS:"H:mm:ss",L:"DD.MM.YYYY",LL:"D. MMMM YYYY",LLL:"D. MMMM YYYY H:mm",LLLL:"dddd, D. MMMM YYYY H:mm"},calendar:{sameDay:"[Täna,] LT",nextDay:"[Homme,] LT",nextWeek:"[Järgmine] dddd LT",lastDay:"[Eile,] LT",lastWeek:"[Eelmine] dddd LT",sameElse:"L"},relativeTime:{future:"%s pärast",past:"%s tagasi",s:processRelativeTime,m:processRelativeTime,mm:processRelativeTime,h:processRelativeTime,hh:processRelativeTime,d:processRelativeTime,dd:"%d päeva",M:processRelativeTime,MM:processRelativeTime,y:processRelativeTime,yy:processRelativeTime},ordinalParse:/\d{1,2}\./,ordinal:"%d.",week:{dow:1,doy:4}});return t})},531:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("eu",{months:"urtarrila_otsaila_martxoa_apirila_maiatza_ekaina_uztaila_abuztua_iraila_urria_azaroa_abendua".split("_"),monthsShort:"urt._ots._mar._api._mai._eka._uzt._abu._ira._urr._aza._abe.".split("_"),monthsParseExact:!0,weekdays:"igandea_astelehena_asteartea_asteazkena_osteguna_ostirala_larunbata".split("_"),weekdaysShort:"ig._al._ar._az._og._ol._lr.".split("_"),weekdaysMin:"ig_al_ar_az_og_ol_lr".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"YYYY-MM-DD",LL:"YYYY[ko] MMMM[ren] D[a]",LLL:"YYYY[ko] MMMM[ren] D[a] HH:mm",LLLL:"dddd, YYYY[ko] MMMM[ren] D[a] HH:mm",l:"YYYY-M-D",ll:"YYYY[ko] MMM D[a]",lll:"YYYY[ko] MMM D[a] HH:mm",llll:"ddd, YYYY[ko] MMM D[a] HH:mm"},calendar:{sameDay:"[gaur] LT[etan]",nextDay:"[bihar] LT[etan]",nextWeek:"dddd LT[etan]",lastDay:"[atzo] LT[etan]",lastWeek:"[aurreko] dddd LT[etan]",sameElse:"L"},relativeTime:{future:"%s barru",past:"duela %s",s:"segundo batzuk",m:"minutu bat",mm:"%d minutu",h:"ordu bat",hh:"%d ordu",d:"egun bat",dd:"%d egun",M:"hilabete bat",MM:"%d hilabete",y:"urte bat",yy:"%d urte"},ordinalParse:/\d{1,2}\./,ordinal:"%d.",week:{dow:1,doy:7}});return t})},532:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t={1:"۱",2:"۲",3:"۳",4:"۴",5:"۵",6:"۶",7:"۷",8:"۸",9:"۹",0:"۰"},n={"۱":"1","۲":"2","۳":"3","۴":"4","۵":"5","۶":"6","۷":"7","۸":"8","۹":"9","۰":"0"},a=e.defineLocale("fa",{months:"ژانویه_فوریه_مارس_آوریل_مه_ژوئن_ژوئیه_اوت_سپتامبر_اکتبر_نوامبر_دسامبر".split("_"),monthsShort:"ژانویه_فوریه_مارس_آوریل_مه_ژوئن_ژوئیه_اوت_سپتامبر_اکتبر_نوامبر_دسامبر".split("_"),weekdays:"یک‌شنبه_دوشنبه_سه‌شنبه_چهارشنبه_پنج‌شنبه_جمعه_شنبه".split("_"),weekdaysShort:"یک‌شنبه_دوشنبه_سه‌شنبه_چهارشنبه_پنج‌شنبه_جمعه_شنبه".split("_"),weekdaysMin:"ی_د_س_چ_پ_ج_ش".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd, D MMMM YYYY HH:mm"},meridiemParse:/قبل از ظهر|بعد از ظهر/,isPM:function(e){return/بعد از ظهر/.test(e)},meridiem:function(e,t,n){return e<12?"قبل از ظهر":"بعد از ظهر"},calendar:{sameDay:"[امروز ساعت] LT",nextDay:"[فردا ساعت] LT",nextWeek:"dddd [ساعت] LT",lastDay:"[دیروز ساعت] LT",lastWeek:"dddd [پیش] [ساعت] LT",sameElse:"L"},relativeTime:{future:"در %s",past:"%s پیش",s:"چندین ثانیه",m:"یک دقیقه",mm:"%d دقیقه",h:"یک ساعت",hh:"%d ساعت",d:"یک روز",dd:"%d روز",M:"یک ماه",MM:"%d ماه",y:"یک سال",yy:"%d سال"},preparse:function(e){return e.replace(/[۰-۹]/g,function(e){return n[e]}).replace(/،/g,",")},postformat:function(e){return e.replace(/\d/g,function(e){return t[e]}).replace(/,/g,"،")},ordinalParse:/\d{1,2}م/,ordinal:"%dم",week:{dow:6,doy:12}});return a})},533:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";function translate(e,t,n,a){var r="";switch(n){case"s":return a?"muutaman sekunnin":"muutama sekunti";case"m":return a?"minuutin":"minuutti";case"mm":r=a?"minuutin":"minuuttia";break;case"h":return a?"tunnin":"tunti";case"hh":r=a?"tunnin":"tuntia";break;case"d":return a?"päivän":"päivä";case"dd":r=a?"päivän":"päivää";break;case"M":return a?"kuukauden":"kuukausi";case"MM":r=a?"kuukauden":"kuukautta";break;case"y":return a?"vuoden":"vuosi";case"yy":r=a?"vuoden":"vuotta"}return r=verbalNumber(e,a)+" "+r}function verbalNumber(e,a){return e<10?a?n[e]:t[e]:e}var t="nolla yksi kaksi kolme neljä viisi kuusi seitsemän kahdeksan yhdeksän".split(" "),n=["nolla","yhden","kahden","kolmen","neljän","viiden","kuuden",t[7],t[8],t[9]],a=e.defineLocale("fi",{months:"tammikuu_helmikuu_maaliskuu_huhtikuu_toukokuu_kesäkuu_heinäkuu_elokuu_syyskuu_lokakuu_marraskuu_joulukuu".split("_"),monthsShort:"tammi_helmi_maalis_huhti_touko_kesä_heinä_elo_syys_loka_marras_joulu".split("_"),weekdays:"sunnuntai_maanantai_tiistai_keskiviikko_torstai_perjantai_lauantai".split("_"),weekdaysShort:"su_ma_ti_ke_to_pe_la".split("_"),weekdaysMin:"su_ma_ti_ke_to_pe_la".split("_"),longDateFormat:{LT:"HH.mm",LTS:"HH.mm.ss",L:"DD.MM.YYYY",LL:"Do MMMM[ta] YYYY",LLL:"Do MMMM[ta] YYYY, [klo] HH.mm",LLLL:"dddd, Do MMMM[ta] YYYY, [klo] HH.mm",l:"D.M.YYYY",ll:"Do MMM YYYY",lll:"Do MMM YYYY, [klo] HH.mm",llll:"ddd, Do MMM YYYY, [klo] HH.mm"},calendar:{sameDay:"[tänään] [klo] LT",nextDay:"[huomenna] [klo] LT",nextWeek:"dddd [klo] LT",lastDay:"[eilen] [klo] LT",lastWeek:"[viime] dddd[na] [klo] LT",sameElse:"L"},relativeTime:{future:"%s päästä",past:"%s sitten",s:translate,m:translate,mm:translate,h:translate,hh:translate,d:translate,dd:translate,M:translate,MM:translate,y:translate,yy:translate},ordinalParse:/\d{1,2}\./,ordinal:"%d.",week:{dow:1,doy:4}});return a})},534:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("fo",{months:"januar_februar_mars_apríl_mai_juni_juli_august_september_oktober_november_desember".split("_"),monthsShort:"jan_feb_mar_apr_mai_jun_jul_aug_sep_okt_nov_des".split("_"),weekdays:"sunnudagur_mánadagur_týsdagur_mikudagur_hósdagur_fríggjadagur_leygardagur".split("_"),weekdaysShort:"sun_mán_týs_mik_hós_frí_ley".split("_"),weekdaysMin:"su_má_tý_mi_hó_fr_le".split("_"),longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd D. MMMM, YYYY HH:mm"},calendar:{sameDay:"[Í dag kl.] LT",nextDay:"[Í morgin kl.] LT",nextWeek:"dddd [kl.] LT",lastDay:"[Í gjár kl.] LT",lastWeek:"[síðstu] dddd [kl] LT",sameElse:"L"},relativeTime:{future:"um %s",past:"%s síðani",s:"fá sekund",m:"ein minutt",mm:"%d minuttir",h:"ein tími",hh:"%d tímar",d:"ein dagur",dd:"%d dagar",M:"ein mánaði",MM:"%d mánaðir",y:"eitt ár",yy:"%d ár"},ordinalParse:/\d{1,2}\./,ordinal:"%d.",week:{dow:1,doy:4}});return t})},535:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("fr-ca",{months:"janvier_février_mars_avril_mai_juin_juillet_août_septembre_octobre_novembre_décembre".split("_"),monthsShort:"janv._févr._mars_avr._mai_juin_juil._août_sept._oct._nov._déc.".split("_"),monthsParseExact:!0,weekdays:"dimanche_lundi_mardi_mercredi_jeudi_vendredi_samedi".split("_"),weekdaysShort:"dim._lun._mar._mer._jeu._ven._sam.".split("_"),weekdaysMin:"Di_Lu_Ma_Me_Je_Ve_Sa".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"YYYY-MM-DD",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd D MMMM YYYY HH:mm"},calendar:{sameDay:"[Aujourd'hui à] LT",nextDay:"[Demain à] LT",nextWeek:"dddd [à] LT",lastDay:"[Hier à] LT",lastWeek:"dddd [dernier à] LT",sameElse:"L"},relativeTime:{future:"dans %s",past:"il y a %s",s:"quelques secondes",m:"une minute",mm:"%d minutes",h:"une heure",hh:"%d heures",d:"un jour",dd:"%d jours",M:"un mois",MM:"%d mois",y:"un an",yy:"%d ans"},ordinalParse:/\d{1,2}(er|e)/,ordinal:function(e){return e+(1===e?"er":"e")}});return t})},536:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("fr-ch",{months:"janvier_février_mars_avril_mai_juin_juillet_août_septembre_octobre_novembre_décembre".split("_"),monthsShort:"janv._févr._mars_avr._mai_juin_juil._août_sept._oct._nov._déc.".split("_"),monthsParseExact:!0,weekdays:"dimanche_lundi_mardi_mercredi_jeudi_vendredi_samedi".split("_"),weekdaysShort:"dim._lun._mar._mer._jeu._ven._sam.".split("_"),weekdaysMin:"Di_Lu_Ma_Me_Je_Ve_Sa".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD.MM.YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd D MMMM YYYY HH:mm"},calendar:{sameDay:"[Aujourd'hui à] LT",nextDay:"[Demain à] LT",nextWeek:"dddd [à] LT",lastDay:"[Hier à] LT",lastWeek:"dddd [dernier à] LT",sameElse:"L"},relativeTime:{future:"dans %s",past:"il y a %s",s:"quelques secondes",m:"une minute",mm:"%d minutes",h:"une heure",hh:"%d heures",d:"un jour",dd:"%d jours",M:"un mois",MM:"%d mois",y:"un an",yy:"%d ans"},ordinalParse:/\d{1,2}(er|e)/,ordinal:function(e){return e+(1===e?"er":"e")},week:{dow:1,doy:4}});return t})},537:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("fr",{months:"janvier_février_mars_avril_mai_juin_juillet_août_septembre_octobre_novembre_décembre".split("_"),monthsShort:"janv._févr._mars_avr._mai_juin_juil._août_sept._oct._nov._déc.".split("_"),monthsParseExact:!0,weekdays:"dimanche_lundi_mardi_mercredi_jeudi_vendredi_samedi".split("_"),weekdaysShort:"dim._lun._mar._mer._jeu._ven._sam.".split("_"),weekdaysMin:"Di_Lu_Ma_Me_Je_Ve_Sa".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd D MMMM YYYY HH:mm"},calendar:{sameDay:"[Aujourd'hui à] LT",nextDay:"[Demain à] LT",nextWeek:"dddd [à] LT",lastDay:"[Hier à] LT",lastWeek:"dddd [dernier à] LT",sameElse:"L"},relativeTime:{future:"dans %s",past:"il y a %s",s:"quelques secondes",m:"une minute",mm:"%d minutes",h:"une heure",hh:"%d heures",d:"un jour",dd:"%d jours",M:"un mois",MM:"%d mois",y:"un an",yy:"%d ans"},ordinalParse:/\d{1,2}(er|)/,ordinal:function(e){return e+(1===e?"er":"")},week:{dow:1,doy:4}});return t})},538:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t="jan._feb._mrt._apr._mai_jun._jul._aug._sep._okt._nov._des.".split("_"),n="jan_feb_mrt_apr_mai_jun_jul_aug_sep_okt_nov_des".split("_"),a=e.defineLocale("fy",{months:"jannewaris_febrewaris_maart_april_maaie_juny_july_augustus_septimber_oktober_novimber_desimber".split("_"),monthsShort:function(e,a){return/-MMM-/.test(a)?n[e.month()]:t[e.month()]},monthsParseExact:!0,weekdays:"snein_moandei_tiisdei_woansdei_tongersdei_freed_sneon".split("_"),weekdaysShort:"si._mo._ti._wo._to._fr._so.".split("_"),weekdaysMin:"Si_Mo_Ti_Wo_To_Fr_So".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD-MM-YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd D MMMM YYYY HH:mm"},calendar:{sameDay:"[hjoed om] LT",nextDay:"[moarn om] LT",nextWeek:"dddd [om] LT",lastDay:"[juster om] LT",lastWeek:"[ôfrûne] dddd [om] LT",sameElse:"L"},relativeTime:{future:"oer %s",past:"%s lyn",s:"in pear sekonden",m:"ien minút",mm:"%d minuten",h:"ien oere",hh:"%d oeren",d:"ien dei",dd:"%d dagen",M:"ien moanne",MM:"%d moannen",y:"ien jier",yy:"%d jierren"},ordinalParse:/\d{1,2}(ste|de)/,ordinal:function(e){return e+(1===e||8===e||e>=20?"ste":"de")},week:{dow:1,doy:4}});return a})},539:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=["Am Faoilleach","An Gearran","Am Màrt","An Giblean","An Cèitean","An t-Ògmhios","An t-Iuchar","An Lùnastal","An t-Sultain","An Dàmhair","An t-Samhain","An Dùbhlachd"],n=["Faoi","Gear","Màrt","Gibl","Cèit","Ògmh","Iuch","Lùn","Sult","Dàmh","Samh","Dùbh"],a=["Didòmhnaich","Diluain","Dimàirt","Diciadain","Diardaoin","Dihaoine","Disathairne"],r=["Did","Dil","Dim","Dic","Dia","Dih","Dis"],s=["Dò","Lu","Mà","Ci","Ar","Ha","Sa"],i=e.defineLocale("gd",{months:t,monthsShort:n,monthsParseExact:!0,weekdays:a,weekdaysShort:r,weekdaysMin:s,longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd, D MMMM YYYY HH:mm"},calendar:{sameDay:"[An-diugh aig] LT",nextDay:"[A-màireach aig] LT",nextWeek:"dddd [aig] LT",lastDay:"[An-dè aig] LT",lastWeek:"dddd [seo chaidh] [aig] LT",sameElse:"L"},relativeTime:{future:"ann an %s",past:"bho chionn %s",s:"beagan diogan",m:"mionaid",mm:"%d mionaidean",h:"uair",hh:"%d uairean",d:"latha",dd:"%d latha",M:"mìos",MM:"%d mìosan",y:"bliadhna",yy:"%d bliadhna"},ordinalParse:/\d{1,2}(d|na|mh)/,ordinal:function(e){var t=1===e?"d":e%10===2?"na":"mh";return e+t},week:{dow:1,doy:4}});return i})},540:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("gl",{months:"xaneiro_febreiro_marzo_abril_maio_xuño_xullo_agosto_setembro_outubro_novembro_decembro".split("_"),monthsShort:"xan._feb._mar._abr._mai._xuñ._xul._ago._set._out._nov._dec.".split("_"),monthsParseExact:!0,weekdays:"domingo_luns_martes_mércores_xoves_venres_sábado".split("_"),weekdaysShort:"dom._lun._mar._mér._xov._ven._sáb.".split("_"),weekdaysMin:"do_lu_ma_mé_xo_ve_sá".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"H:mm",LTS:"H:mm:ss",L:"DD/MM/YYYY",LL:"D [de] MMMM [de] YYYY",LLL:"D [de] MMMM [de] YYYY H:mm",LLLL:"dddd, D [de] MMMM [de] YYYY H:mm"},calendar:{sameDay:function(){return"[hoxe "+(1!==this.hours()?"ás":"á")+"] LT"},nextDay:function(){return"[mañá "+(1!==this.hours()?"ás":"á")+"] LT"},nextWeek:function(){return"dddd ["+(1!==this.hours()?"ás":"a")+"] LT"},lastDay:function(){return"[onte "+(1!==this.hours()?"á":"a")+"] LT"},lastWeek:function(){return"[o] dddd [pasado "+(1!==this.hours()?"ás":"a")+"] LT"},sameElse:"L"},relativeTime:{future:function(e){return 0===e.indexOf("un")?"n"+e:"en "+e},past:"hai %s",s:"uns segundos",m:"un minuto",mm:"%d minutos",h:"unha hora",hh:"%d horas",d:"un día",dd:"%d días",M:"un mes",MM:"%d meses",y:"un ano",yy:"%d anos"},ordinalParse:/\d{1,2}º/,ordinal:"%dº",week:{dow:1,doy:4}});return t})},541:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("he",{months:"ינואר_פברואר_מרץ_אפריל_מאי_יוני_יולי_אוגוסט_ספטמבר_אוקטובר_נובמבר_דצמבר".split("_"),monthsShort:"ינו׳_פבר׳_מרץ_אפר׳_מאי_יוני_יולי_אוג׳_ספט׳_אוק׳_נוב׳_דצמ׳".split("_"),weekdays:"ראשון_שני_שלישי_רביעי_חמישי_שישי_שבת".split("_"),weekdaysShort:"א׳_ב׳_ג׳_ד׳_ה׳_ו׳_ש׳".split("_"),weekdaysMin:"א_ב_ג_ד_ה_ו_ש".split("_"),longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD/MM/YYYY",LL:"D [ב]MMMM YYYY",LLL:"D [ב]MMMM YYYY HH:mm",LLLL:"dddd, D [ב]MMMM YYYY HH:mm",l:"D/M/YYYY",ll:"D MMM YYYY",lll:"D MMM YYYY HH:mm",llll:"ddd, D MMM YYYY HH:mm"},calendar:{sameDay:"[היום ב־]LT",nextDay:"[מחר ב־]LT",nextWeek:"dddd [בשעה] LT",lastDay:"[אתמול ב־]LT",lastWeek:"[ביום] dddd [האחרון בשעה] LT",sameElse:"L"},relativeTime:{future:"בעוד %s",past:"לפני %s",s:"מספר שניות",m:"דקה",mm:"%d דקות",h:"שעה",hh:function(e){return 2===e?"שעתיים":e+" שעות"},d:"יום",dd:function(e){return 2===e?"יומיים":e+" ימים"},M:"חודש",MM:function(e){return 2===e?"חודשיים":e+" חודשים"},y:"שנה",yy:function(e){return 2===e?"שנתיים":e%10===0&&10!==e?e+" שנה":e+" שנים"}},meridiemParse:/אחה"צ|לפנה"צ|אחרי הצהריים|לפני הצהריים|לפנות בוקר|בבוקר|בערב/i,isPM:function(e){return/^(אחה"צ|אחרי הצהריים|בערב)$/.test(e)},meridiem:function(e,t,n){return e<5?"לפנות בוקר":e<10?"בבוקר":e<12?n?'לפנה"צ':"לפני הצהריים":e<18?n?'אחה"צ':"אחרי הצהריים":"בערב"}});return t})},542:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t={1:"१",2:"२",3:"३",4:"४",5:"५",6:"६",7:"७",8:"८",9:"९",0:"०"},n={"१":"1","२":"2","३":"3","४":"4","५":"5","६":"6","७":"7","८":"8","९":"9","०":"0"},a=e.defineLocale("hi",{months:"जनवरी_फ़रवरी_मार्च_अप्रैल_मई_जून_जुलाई_अगस्त_सितम्बर_अक्टूबर_नवम्बर_दिसम्बर".split("_"),monthsShort:"जन._फ़र._मार्च_अप्रै._मई_जून_जुल._अग._सित._अक्टू._नव._दिस.".split("_"),monthsParseExact:!0,weekdays:"रविवार_सोमवार_मंगलवार_बुधवार_गुरूवार_शुक्रवार_शनिवार".split("_"),weekdaysShort:"रवि_सोम_मंगल_बुध_गुरू_शुक्र_शनि".split("_"),weekdaysMin:"र_सो_मं_बु_गु_शु_श".split("_"),longDateFormat:{LT:"A h:mm बजे",LTS:"A h:mm:ss बजे",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY, A h:mm बजे",LLLL:"dddd, D MMMM YYYY, A h:mm बजे"},calendar:{sameDay:"[आज] LT",nextDay:"[कल] LT",nextWeek:"dddd, LT",lastDay:"[कल] LT",lastWeek:"[पिछले] dddd, LT",sameElse:"L"},relativeTime:{future:"%s में",past:"%s पहले",s:"कुछ ही क्षण",m:"एक मिनट",mm:"%d मिनट",h:"एक घंटा",hh:"%d घंटे",d:"एक दिन",dd:"%d दिन",M:"एक महीने",MM:"%d महीने",y:"एक वर्ष",yy:"%d वर्ष"},preparse:function(e){return e.replace(/[१२३४५६७८९०]/g,function(e){return n[e]})},postformat:function(e){return e.replace(/\d/g,function(e){return t[e]})},meridiemParse:/रात|सुबह|दोपहर|शाम/,meridiemHour:function(e,t){return 12===e&&(e=0),"रात"===t?e<4?e:e+12:"सुबह"===t?e:"दोपहर"===t?e>=10?e:e+12:"शाम"===t?e+12:void 0},meridiem:function(e,t,n){return e<4?"रात":e<10?"सुबह":e<17?"दोपहर":e<20?"शाम":"रात"},week:{dow:0,doy:6}});return a})},543:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";function translate(e,t,n){var a=e+" ";switch(n){case"m":return t?"jedna minuta":"jedne minute";case"mm":return a+=1===e?"minuta":2===e||3===e||4===e?"minute":"minuta";case"h":return t?"jedan sat":"jednog sata";case"hh":return a+=1===e?"sat":2===e||3===e||4===e?"sata":"sati";case"dd":return a+=1===e?"dan":"dana";case"MM":return a+=1===e?"mjesec":2===e||3===e||4===e?"mjeseca":"mjeseci";case"yy":return a+=1===e?"godina":2===e||3===e||4===e?"godine":"godina"}}var t=e.defineLocale("hr",{months:{format:"siječnja_veljače_ožujka_travnja_svibnja_lipnja_srpnja_kolovoza_rujna_listopada_studenoga_prosinca".split("_"),standalone:"siječanj_veljača_ožujak_travanj_svibanj_lipanj_srpanj_kolovoz_rujan_listopad_studeni_prosinac".split("_")},monthsShort:"sij._velj._ožu._tra._svi._lip._srp._kol._ruj._lis._stu._pro.".split("_"),monthsParseExact:!0,weekdays:"nedjelja_ponedjeljak_utorak_srijeda_četvrtak_petak_subota".split("_"),weekdaysShort:"ned._pon._uto._sri._čet._pet._sub.".split("_"),weekdaysMin:"ne_po_ut_sr_če_pe_su".split("_"),weekdaysParseExact:!0,longDateFormat:{LT:"H:mm",LTS:"H:mm:ss",L:"DD.MM.YYYY",LL:"D. MMMM YYYY",LLL:"D. MMMM YYYY H:mm",LLLL:"dddd, D. MMMM YYYY H:mm"},calendar:{sameDay:"[danas u] LT",nextDay:"[sutra u] LT",nextWeek:function(){switch(this.day()){case 0:return"[u] [nedjelju] [u] LT";case 3:return"[u] [srijedu] [u] LT";case 6:return"[u] [subotu] [u] LT";case 1:case 2:case 4:case 5:return"[u] dddd [u] LT"}},lastDay:"[jučer u] LT",lastWeek:function(){switch(this.day()){case 0:case 3:return"[prošlu] dddd [u] LT";case 6:return"[prošle] [subote] [u] LT";case 1:case 2:case 4:case 5:return"[prošli] dddd [u] LT"}},sameElse:"L"},relativeTime:{future:"za %s",past:"prije %s",s:"par sekundi",m:translate,mm:translate,h:translate,hh:translate,d:"dan",dd:translate,M:"mjesec",MM:translate,y:"godinu",yy:translate},ordinalParse:/\d{1,2}\./,ordinal:"%d.",week:{dow:1,doy:7}});return t})},544:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";function translate(e,t,n,a){var r=e;switch(n){case"s":return a||t?"néhány másodperc":"néhány másodperce";case"m":return"egy"+(a||t?" perc":" perce");case"mm":return r+(a||t?" perc":" perce");case"h":return"egy"+(a||t?" óra":" órája");case"hh":return r+(a||t?" óra":" órája");case"d":return"egy"+(a||t?" nap":" napja");case"dd":return r+(a||t?" nap":" napja");case"M":return"egy"+(a||t?" hónap":" hónapja");case"MM":return r+(a||t?" hónap":" hónapja");case"y":return"egy"+(a||t?" év":" éve");case"yy":return r+(a||t?" év":" éve")}return""}function week(e){return(e?"":"[múlt] ")+"["+t[this.day()]+"] LT[-kor]"}var t="vasárnap hétfőn kedden szerdán csütörtökön pénteken szombaton".split(" "),n=e.defineLocale("hu",{months:"január_február_március_április_május_június_július_augusztus_szeptember_október_november_december".split("_"),monthsShort:"jan_feb_márc_ápr_máj_jún_júl_aug_szept_okt_nov_dec".split("_"),weekdays:"vasárnap_hétfő_kedd_szerda_csütörtök_péntek_szombat".split("_"),weekdaysShort:"vas_hét_kedd_sze_csüt_pén_szo".split("_"),weekdaysMin:"v_h_k_sze_cs_p_szo".split("_"),longDateFormat:{LT:"H:mm",LTS:"H:mm:ss",L:"YYYY.MM.DD.",LL:"YYYY. MMMM D.",LLL:"YYYY. MMMM D. H:mm",LLLL:"YYYY. MMMM D., dddd H:mm"},meridiemParse:/de|du/i,isPM:function(e){return"u"===e.charAt(1).toLowerCase()},meridiem:function(e,t,n){return e<12?n===!0?"de":"DE":n===!0?"du":"DU"},calendar:{sameDay:"[ma] LT[-kor]",nextDay:"[holnap] LT[-kor]",nextWeek:function(){return week.call(this,!0)},lastDay:"[tegnap] LT[-kor]",lastWeek:function(){return week.call(this,!1)},sameElse:"L"},relativeTime:{future:"%s múlva",past:"%s",s:translate,m:translate,mm:translate,h:translate,hh:translate,d:translate,dd:translate,M:translate,MM:translate,y:translate,yy:translate},ordinalParse:/\d{1,2}\./,ordinal:"%d.",week:{dow:1,doy:4}});return n})},545:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("hy-am",{months:{format:"հունվարի_փետրվարի_մարտի_ապրիլի_մայիսի_հունիսի_հուլիսի_օգոստոսի_սեպտեմբերի_հոկտեմբերի_նոյեմբերի_դեկտեմբերի".split("_"),standalone:"հունվար_փետրվար_մարտ_ապրիլ_մայիս_հունիս_հուլիս_օգոստոս_սեպտեմբեր_հոկտեմբեր_նոյեմբեր_դեկտեմբեր".split("_")},monthsShort:"հնվ_փտր_մրտ_ապր_մյս_հնս_հլս_օգս_սպտ_հկտ_նմբ_դկտ".split("_"),weekdays:"կիրակի_երկուշաբթի_երեքշաբթի_չորեքշաբթի_հինգշաբթի_ուրբաթ_շաբաթ".split("_"),weekdaysShort:"կրկ_երկ_երք_չրք_հնգ_ուրբ_շբթ".split("_"),weekdaysMin:"կրկ_երկ_երք_չրք_հնգ_ուրբ_շբթ".split("_"),longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD.MM.YYYY",LL:"D MMMM YYYY թ.",LLL:"D MMMM YYYY թ., HH:mm",LLLL:"dddd, D MMMM YYYY թ., HH:mm"},calendar:{sameDay:"[այսօր] LT",nextDay:"[վաղը] LT",lastDay:"[երեկ] LT",nextWeek:function(){return"dddd [օրը ժամը] LT"},lastWeek:function(){return"[անցած] dddd [օրը ժամը] LT"},sameElse:"L"},relativeTime:{future:"%s հետո",past:"%s առաջ",s:"մի քանի վայրկյան",m:"րոպե",mm:"%d րոպե",h:"ժամ",hh:"%d ժամ",d:"օր",dd:"%d օր",M:"ամիս",MM:"%d ամիս",y:"տարի",yy:"%d տարի"},meridiemParse:/գիշերվա|առավոտվա|ցերեկվա|երեկոյան/,isPM:function(e){return/^(ցերեկվա|երեկոյան)$/.test(e)},meridiem:function(e){return e<4?"գիշերվա":e<12?"առավոտվա":e<17?"ցերեկվա":"երեկոյան"},ordinalParse:/\d{1,2}|\d{1,2}-(ին|րդ)/,ordinal:function(e,t){switch(t){case"DDD":case"w":case"W":case"DDDo":return 1===e?e+"-ին":e+"-րդ";default:return e}},week:{dow:1,doy:7}});return t})},546:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("id",{months:"Januari_Februari_Maret_April_Mei_Juni_Juli_Agustus_September_Oktober_November_Desember".split("_"),monthsShort:"Jan_Feb_Mar_Apr_Mei_Jun_Jul_Ags_Sep_Okt_Nov_Des".split("_"),weekdays:"Minggu_Senin_Selasa_Rabu_Kamis_Jumat_Sabtu".split("_"),weekdaysShort:"Min_Sen_Sel_Rab_Kam_Jum_Sab".split("_"),weekdaysMin:"Mg_Sn_Sl_Rb_Km_Jm_Sb".split("_"),longDateFormat:{LT:"HH.mm",LTS:"HH.mm.ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY [pukul] HH.mm",LLLL:"dddd, D MMMM YYYY [pukul] HH.mm"},meridiemParse:/pagi|siang|sore|malam/,meridiemHour:function(e,t){return 12===e&&(e=0),"pagi"===t?e:"siang"===t?e>=11?e:e+12:"sore"===t||"malam"===t?e+12:void 0},meridiem:function(e,t,n){return e<11?"pagi":e<15?"siang":e<19?"sore":"malam"},calendar:{sameDay:"[Hari ini pukul] LT",nextDay:"[Besok pukul] LT",nextWeek:"dddd [pukul] LT",lastDay:"[Kemarin pukul] LT",lastWeek:"dddd [lalu pukul] LT",sameElse:"L"},relativeTime:{future:"dalam %s",past:"%s yang lalu",s:"beberapa detik",m:"semenit",mm:"%d menit",h:"sejam",hh:"%d jam",d:"sehari",dd:"%d hari",M:"sebulan",MM:"%d bulan",y:"setahun",yy:"%d tahun"},week:{dow:1,doy:7}});return t})},547:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";function plural(e){return e%100===11||e%10!==1}function translate(e,t,n,a){var r=e+" ";switch(n){case"s":return t||a?"nokkrar sekúndur":"nokkrum sekúndum";case"m":return t?"mínúta":"mínútu";case"mm":return plural(e)?r+(t||a?"mínútur":"mínútum"):t?r+"mínúta":r+"mínútu";case"hh":return plural(e)?r+(t||a?"klukkustundir":"klukkustundum"):r+"klukkustund";case"d":return t?"dagur":a?"dag":"degi";case"dd":return plural(e)?t?r+"dagar":r+(a?"daga":"dögum"):t?r+"dagur":r+(a?"dag":"degi");case"M":return t?"mánuður":a?"mánuð":"mánuði";case"MM":return plural(e)?t?r+"mánuðir":r+(a?"mánuði":"mánuðum"):t?r+"mánuður":r+(a?"mánuð":"mánuði");case"y":return t||a?"ár":"ári";case"yy":return plural(e)?r+(t||a?"ár":"árum"):r+(t||a?"ár":"ári")}}var t=e.defineLocale("is",{months:"janúar_febrúar_mars_apríl_maí_júní_júlí_ágúst_september_október_nóvember_desember".split("_"),monthsShort:"jan_feb_mar_apr_maí_jún_júl_ágú_sep_okt_nóv_des".split("_"),weekdays:"sunnudagur_mánudagur_þriðjudagur_miðvikudagur_fimmtudagur_föstudagur_laugardagur".split("_"),weekdaysShort:"sun_mán_þri_mið_fim_fös_lau".split("_"),weekdaysMin:"Su_Má_Þr_Mi_Fi_Fö_La".split("_"),longDateFormat:{LT:"H:mm",LTS:"H:mm:ss",L:"DD.MM.YYYY",LL:"D. MMMM YYYY",LLL:"D. MMMM YYYY [kl.] H:mm",LLLL:"dddd, D. MMMM YYYY [kl.] H:mm"},calendar:{sameDay:"[í dag kl.] LT",nextDay:"[á morgun kl.] LT",nextWeek:"dddd [kl.] LT",lastDay:"[í gær kl.] LT",lastWeek:"[síðasta] dddd [kl.] LT",sameElse:"L"},relativeTime:{future:"eftir %s",past:"fyrir %s síðan",s:translate,m:translate,mm:translate,h:"klukkustund",hh:translate,d:translate,dd:translate,M:translate,MM:translate,y:translate,yy:translate},ordinalParse:/\d{1,2}\./,ordinal:"%d.",week:{dow:1,doy:4}});return t})},548:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("it",{months:"gennaio_febbraio_marzo_aprile_maggio_giugno_luglio_agosto_settembre_ottobre_novembre_dicembre".split("_"),monthsShort:"gen_feb_mar_apr_mag_giu_lug_ago_set_ott_nov_dic".split("_"),weekdays:"Domenica_Lunedì_Martedì_Mercoledì_Giovedì_Venerdì_Sabato".split("_"),weekdaysShort:"Dom_Lun_Mar_Mer_Gio_Ven_Sab".split("_"),weekdaysMin:"Do_Lu_Ma_Me_Gi_Ve_Sa".split("_"),longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",LLLL:"dddd, D MMMM YYYY HH:mm"},calendar:{sameDay:"[Oggi alle] LT",nextDay:"[Domani alle] LT",nextWeek:"dddd [alle] LT",lastDay:"[Ieri alle] LT",lastWeek:function(){switch(this.day()){case 0:return"[la scorsa] dddd [alle] LT";default:return"[lo scorso] dddd [alle] LT"}},sameElse:"L"},relativeTime:{future:function(e){return(/^[0-9].+$/.test(e)?"tra":"in")+" "+e},past:"%s fa",s:"alcuni secondi",m:"un minuto",mm:"%d minuti",h:"un'ora",hh:"%d ore",d:"un giorno",dd:"%d giorni",M:"un mese",MM:"%d mesi",y:"un anno",yy:"%d anni"},ordinalParse:/\d{1,2}º/,ordinal:"%dº",week:{dow:1,doy:4}});return t})},549:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("ja",{months:"1月_2月_3月_4月_5月_6月_7月_8月_9月_10月_11月_12月".split("_"),monthsShort:"1月_2月_3月_4月_5月_6月_7月_8月_9月_10月_11月_12月".split("_"),weekdays:"日曜日_月曜日_火曜日_水曜日_木曜日_金曜日_土曜日".split("_"),weekdaysShort:"日_月_火_水_木_金_土".split("_"),weekdaysMin:"日_月_火_水_木_金_土".split("_"),longDateFormat:{LT:"Ah時m分",LTS:"Ah時m分s秒",L:"YYYY/MM/DD",LL:"YYYY年M月D日",LLL:"YYYY年M月D日Ah時m分",LLLL:"YYYY年M月D日Ah時m分 dddd"},meridiemParse:/午前|午後/i,isPM:function(e){return"午後"===e},meridiem:function(e,t,n){return e<12?"午前":"午後"},calendar:{sameDay:"[今日] LT",nextDay:"[明日] LT",nextWeek:"[来週]dddd LT",lastDay:"[昨日] LT",lastWeek:"[前週]dddd LT",sameElse:"L"},ordinalParse:/\d{1,2}日/,ordinal:function(e,t){switch(t){case"d":case"D":case"DDD":return e+"日";default:return e}},relativeTime:{future:"%s後",past:"%s前",s:"数秒",m:"1分",mm:"%d分",h:"1時間",hh:"%d時間",d:"1日",dd:"%d日",M:"1ヶ月",MM:"%dヶ月",y:"1年",yy:"%d年"}});return t})},550:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("jv",{months:"Januari_Februari_Maret_April_Mei_Juni_Juli_Agustus_September_Oktober_Nopember_Desember".split("_"),monthsShort:"Jan_Feb_Mar_Apr_Mei_Jun_Jul_Ags_Sep_Okt_Nop_Des".split("_"),weekdays:"Minggu_Senen_Seloso_Rebu_Kemis_Jemuwah_Septu".split("_"),weekdaysShort:"Min_Sen_Sel_Reb_Kem_Jem_Sep".split("_"),weekdaysMin:"Mg_Sn_Sl_Rb_Km_Jm_Sp".split("_"),longDateFormat:{LT:"HH.mm",LTS:"HH.mm.ss",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY [pukul] HH.mm",LLLL:"dddd, D MMMM YYYY [pukul] HH.mm"},meridiemParse:/enjing|siyang|sonten|ndalu/,meridiemHour:function(e,t){return 12===e&&(e=0),"enjing"===t?e:"siyang"===t?e>=11?e:e+12:"sonten"===t||"ndalu"===t?e+12:void 0},meridiem:function(e,t,n){return e<11?"enjing":e<15?"siyang":e<19?"sonten":"ndalu"},calendar:{sameDay:"[Dinten puniko pukul] LT",nextDay:"[Mbenjang pukul] LT",nextWeek:"dddd [pukul] LT",lastDay:"[Kala wingi pukul] LT",lastWeek:"dddd [kepengker pukul] LT",sameElse:"L"},relativeTime:{future:"wonten ing %s",past:"%s ingkang kepengker",s:"sawetawis detik",m:"setunggal menit",mm:"%d menit",h:"setunggal jam",hh:"%d jam",d:"sedinten",dd:"%d dinten",M:"sewulan",MM:"%d wulan",y:"setaun",yy:"%d taun"},week:{dow:1,doy:7}});return t})},551:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t=e.defineLocale("ka",{months:{standalone:"იანვარი_თებერვალი_მარტი_აპრილი_მაისი_ივნისი_ივლისი_აგვისტო_სექტემბერი_ოქტომბერი_ნოემბერი_დეკემბერი".split("_"),format:"იანვარს_თებერვალს_მარტს_აპრილის_მაისს_ივნისს_ივლისს_აგვისტს_სექტემბერს_ოქტომბერს_ნოემბერს_დეკემბერს".split("_")},monthsShort:"იან_თებ_მარ_აპრ_მაი_ივნ_ივლ_აგვ_სექ_ოქტ_ნოე_დეკ".split("_"),weekdays:{standalone:"კვირა_ორშაბათი_სამშაბათი_ოთხშაბათი_ხუთშაბათი_პარასკევი_შაბათი".split("_"),format:"კვირას_ორშაბათს_სამშაბათს_ოთხშაბათს_ხუთშაბათს_პარასკევს_შაბათს".split("_"),isFormat:/(წინა|შემდეგ)/},weekdaysShort:"კვი_ორშ_სამ_ოთხ_ხუთ_პარ_შაბ".split("_"),weekdaysMin:"კვ_ორ_სა_ოთ_ხუ_პა_შა".split("_"),longDateFormat:{LT:"h:mm A",LTS:"h:mm:ss A",L:"DD/MM/YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY h:mm A",LLLL:"dddd, D MMMM YYYY h:mm A"},calendar:{sameDay:"[დღეს] LT[-ზე]",nextDay:"[ხვალ] LT[-ზე]",lastDay:"[გუშინ] LT[-ზე]",nextWeek:"[შემდეგ] dddd LT[-ზე]",lastWeek:"[წინა] dddd LT-ზე",sameElse:"L"},relativeTime:{future:function(e){return/(წამი|წუთი|საათი|წელი)/.test(e)?e.replace(/ი$/,"ში"):e+"ში"},past:function(e){return/(წამი|წუთი|საათი|დღე|თვე)/.test(e)?e.replace(/(ი|ე)$/,"ის წინ"):/წელი/.test(e)?e.replace(/წელი$/,"წლის წინ"):void 0},s:"რამდენიმე წამი",m:"წუთი",mm:"%d წუთი",h:"საათი",hh:"%d საათი",d:"დღე",dd:"%d დღე",M:"თვე",MM:"%d თვე",y:"წელი",yy:"%d წელი"},ordinalParse:/0|1-ლი|მე-\d{1,2}|\d{1,2}-ე/,ordinal:function(e){return 0===e?e:1===e?e+"-ლი":e<20||e<=100&&e%20===0||e%100===0?"მე-"+e:e+"-ე"},week:{dow:1,doy:7}});return t})},552:function(e,t,n){!function(e,t){t(n(2))}(this,function(e){"use strict";var t={0:"-ші",1:"-ші",2:"-ші",3:"-ші",4:"-ші",5:"-ші",6:"-шы",7:"-ші",8:"-ші",9:"-шы",10:"-шы",20:"-шы",30:"-шы",40:"-шы",50:"-ші",60:"-шы",70:"-ші",80:"-ші",90:"-шы",100:"-ші"},n=e.defineLocale("kk",{months:"қаңтар_ақпан_наурыз_сәуір_мамыр_маусым_шілде_тамыз_қыркүйек_қазан_қараша_желтоқсан".split("_"),monthsShort:"қаң_ақп_нау_сәу_мам_мау_шіл_там_қыр_қаз_қар_жел".split("_"),weekdays:"жексенбі_дүйсенбі_сейсенбі_сәрсенбі_бейсенбі_жұма_сенбі".split("_"),weekdaysShort:"жек_дүй_сей_сәр_бей_жұм_сен".split("_"),weekdaysMin:"жк_дй_сй_ср_бй_жм_сн".split("_"),longDateFormat:{LT:"HH:mm",LTS:"HH:mm:ss",L:"DD.MM.YYYY",LL:"D MMMM YYYY",LLL:"D MMMM YYYY HH:mm",
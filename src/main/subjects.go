@@ -1,6 +1,12 @@
 package main
 
-import "main/dispatch"
+import (
+	"fmt"
+	"main/dispatch"
+	"main/utils"
+	"net/http"
+	"time"
+)
 
 var (
 	dispatcher           *dispatch.Dispatcher
@@ -9,16 +15,82 @@ var (
 	doseSummariesSubject *DoseSummariesSubject
 	doseStatusesSubject  *DoseStatusesSubject
 	prnSubject           *PRNSubject
+	inventorySubject     *InventorySubject
 )
 
 func init() {
 	// Create the dispatcher
 	dispatcher = dispatch.NewDispatcher()
+	dispatcher.SetAuthenticator(authenticateDispatcherClient)
 
 	// Create and register subjects with the dispatcher
 	medicationsSubject = dispatch.NewCollectionSubject("medications", dispatcher)
+	medicationsSubject.Authorize = func(principal dispatch.Principal) error {
+		return authorizeSubscription(principal, MedicationsReadPermission, 0)
+	}
+
 	dosesSubject = NewDosesSubject(dispatcher)
 	doseSummariesSubject = NewDoseSummariesSubject(dispatcher)
 	doseStatusesSubject = NewDoseStatusesSubject(dispatcher)
 	prnSubject = NewPRNSubject(dispatcher)
+	inventorySubject = NewInventorySubject(dispatcher)
+}
+
+// authenticateDispatcherClient validates the token of a dispatcher client, and returns its session as principal
+func authenticateDispatcherClient(token string) (dispatch.Principal, error) {
+	session, err := ReadTokenSession(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// authorizeSubscription checks whether a dispatcher client has a permission and, for a patient ID other than 0, may access
+// the data of that patient
+func authorizeSubscription(principal dispatch.Principal, permission string, patientID int) error {
+	session, ok := principal.(Session)
+	if !ok {
+		return dispatch.UnauthorizedErrorMessage("The client has not authenticated")
+	}
+
+	// Long-lived connections must authenticate again with a fresh token once theirs has expired
+	if time.Now().After(session.ExpiresAt) {
+		return dispatch.UnauthorizedErrorMessage("The token of the client has expired, authenticate again")
+	}
+
+	allowed, err := SessionHasPermission(session, permission)
+	if err != nil {
+		return toDispatcherError(err)
+	}
+	if !allowed {
+		return dispatch.ForbiddenErrorMessage(fmt.Sprintf("Your role (%s) lacks the %s permission required for this subscription.", session.Role, permission))
+	}
+
+	if patientID == 0 {
+		return nil
+	}
+
+	return toDispatcherError(AuthorizePatientAccess(session, patientID))
+}
+
+// toDispatcherError converts an HTTP error into the corresponding dispatcher error
+func toDispatcherError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if httpErr, ok := err.(*utils.HttpError); ok {
+		switch httpErr.StatusCode {
+		case http.StatusUnauthorized:
+			return dispatch.UnauthorizedErrorMessage(httpErr.Message)
+		case http.StatusForbidden:
+			return dispatch.ForbiddenErrorMessage(httpErr.Message)
+		case http.StatusBadRequest:
+			return dispatch.BadRequestErrorMessage(httpErr.Message)
+		}
+	}
+
+	utils.LogError(err)
+	return dispatch.ToDispatcherError(fmt.Errorf("Internal server error"))
 }
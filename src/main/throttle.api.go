@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"main/utils"
+	"net/http"
+	"strconv"
+)
+
+// HandleListLockouts returns a list of lockouts to the client
+func HandleListLockouts(w http.ResponseWriter, r *http.Request) {
+	lockouts, err := ListLockouts(r.URL.Query().Get("active") == "true")
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, lockouts)
+}
+
+// HandleUnlock handles the lifting of a lockout by an admin
+func HandleUnlock(w http.ResponseWriter, r *http.Request) {
+	// Read lockout ID from URL
+	vars := mux.Vars(r)
+
+	lockoutID, err := strconv.Atoi(vars["lockoutId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'lockoutId' isn't a valid integer.", vars["lockoutId"])))
+		return
+	}
+
+	// Read session from request
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Lift the lockout and respond
+	err = Unlock(lockoutID, session.UserID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
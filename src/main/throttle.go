@@ -0,0 +1,275 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"main/utils"
+	"strings"
+	"time"
+)
+
+type (
+	// Lockout contains information on a lockout caused by too many failed login attempts
+	Lockout struct {
+		ID          int    `json:"id"`
+		ThrottleKey string `json:"throttleKey"`
+		IPAddress   string `json:"ipAddress"`
+		Failures    int    `json:"failures"`
+		LockedUntil string `json:"lockedUntil"`
+		CreatedOn   string `json:"createdOn"`
+		UnlockedOn  string `json:"unlockedOn"`
+		UnlockedBy  int    `json:"unlockedBy"`
+	}
+)
+
+const (
+	// Number of failed attempts after which an account or IP address is locked out
+	MaxAccountFailures = 5
+	MaxIPFailures      = 20
+
+	// Initial and maximum lockout duration, the duration doubles with every failed attempt beyond the maximum
+	BaseLockoutDuration = time.Minute
+	MaxLockoutDuration  = time.Hour
+
+	// Number of password reset requests for an e-mail address or from an IP address after which further requests are refused
+	MaxResetRequestsPerAccount = 3
+	MaxResetRequestsPerIP      = 10
+)
+
+var (
+	// InvalidCredentialsError is returned for every failed authentication, so usernames can't be enumerated
+	InvalidCredentialsError = utils.UnauthorizedErrorMessage("Invalid credentials")
+)
+
+// userThrottleKey returns the throttle key of a user account
+func userThrottleKey(username string) string {
+	return fmt.Sprintf("user:%s", username)
+}
+
+// dispenserThrottleKey returns the throttle key of a dispenser
+func dispenserThrottleKey(dispenserID int) string {
+	return fmt.Sprintf("dispenser:%d", dispenserID)
+}
+
+// ipThrottleKey returns the throttle key of an IP address
+func ipThrottleKey(ipAddress string) string {
+	return fmt.Sprintf("ip:%s", ipAddress)
+}
+
+// passwordResetThrottleKey returns the throttle key of password reset requests for an e-mail address
+func passwordResetThrottleKey(email string) string {
+	return fmt.Sprintf("passwordreset:%s", strings.ToLower(strings.TrimSpace(email)))
+}
+
+// passwordResetIPThrottleKey returns the throttle key of password reset requests from an IP address
+func passwordResetIPThrottleKey(ipAddress string) string {
+	return fmt.Sprintf("passwordreset-ip:%s", ipAddress)
+}
+
+// twoFactorThrottleKey returns the throttle key of two-factor code attempts of a logged in user
+func twoFactorThrottleKey(userID int) string {
+	return fmt.Sprintf("twofactor:%d", userID)
+}
+
+// checkThrottle returns an error with the given reason if any of the throttle keys is currently locked out
+func checkThrottle(reason string, throttleKeys ...string) error {
+	var lockedUntil time.Time
+
+	err := db.QueryRow(`SELECT MAX(LockedUntil) FROM LoginThrottles
+	WHERE ThrottleKey = ANY($1) AND LockedUntil > NOW()
+	HAVING COUNT(*) > 0`, pq.Array(throttleKeys)).Scan(&lockedUntil)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return utils.InternalServerError(err)
+	}
+
+	return utils.TooManyRequestsErrorMessage(fmt.Sprintf("%s, try again after %s", reason, lockedUntil.Format(time.RFC3339)))
+}
+
+// CheckLoginThrottle returns an error if the account or IP address is currently locked out
+func CheckLoginThrottle(accountKey, ipAddress string) error {
+	return checkThrottle("Too many failed login attempts", accountKey, ipThrottleKey(ipAddress))
+}
+
+// CheckPasswordResetThrottle returns an error if too many password resets were requested for an e-mail address or from an
+// IP address, and counts the request otherwise
+func CheckPasswordResetThrottle(email, ipAddress string) error {
+	accountKey := passwordResetThrottleKey(email)
+	ipKey := passwordResetIPThrottleKey(ipAddress)
+
+	err := checkThrottle("Too many password reset requests", accountKey, ipKey)
+	if err != nil {
+		return err
+	}
+
+	err = recordFailure(accountKey, ipAddress, MaxResetRequestsPerAccount)
+	if err != nil {
+		return err
+	}
+
+	return recordFailure(ipKey, ipAddress, MaxResetRequestsPerIP)
+}
+
+// RecordFailedLogin registers a failed login attempt for an account and IP address, and locks them out if necessary
+func RecordFailedLogin(accountKey, ipAddress string) error {
+	err := recordFailure(accountKey, ipAddress, MaxAccountFailures)
+	if err != nil {
+		return err
+	}
+
+	return recordFailure(ipThrottleKey(ipAddress), ipAddress, MaxIPFailures)
+}
+
+// rejectLogin records a failed login attempt and returns the uniform invalid credentials error
+func rejectLogin(accountKey, ipAddress string) error {
+	err := RecordFailedLogin(accountKey, ipAddress)
+	if err != nil {
+		return err
+	}
+
+	return InvalidCredentialsError
+}
+
+// ResetLoginThrottle clears the failed attempts of an account after a successful login
+func ResetLoginThrottle(accountKey string) error {
+	_, err := db.Exec(`DELETE FROM LoginThrottles WHERE ThrottleKey = $1`, accountKey)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// recordFailure increments the failure counter of a throttle key and locks it out once maxFailures is reached
+func recordFailure(throttleKey, ipAddress string, maxFailures int) error {
+	// Increment the failure counter, failures older than a day are forgotten
+	var failures int
+
+	err := db.QueryRow(`INSERT INTO LoginThrottles (ThrottleKey, Failures, LastFailureOn)
+	VALUES ($1, 1, NOW())
+	ON CONFLICT (ThrottleKey) DO UPDATE SET
+		Failures = CASE WHEN LoginThrottles.LastFailureOn < NOW() - INTERVAL '1 day' THEN 1 ELSE LoginThrottles.Failures + 1 END,
+		LastFailureOn = NOW()
+	RETURNING Failures`, throttleKey).Scan(&failures)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	if failures < maxFailures {
+		return nil
+	}
+
+	// Lock out progressively longer with every failure beyond the maximum
+	duration := MaxLockoutDuration
+	if shift := uint(failures - maxFailures); shift < 16 && BaseLockoutDuration<<shift < MaxLockoutDuration {
+		duration = BaseLockoutDuration << shift
+	}
+
+	lockedUntil := time.Now().Add(duration)
+
+	_, err = db.Exec(`UPDATE LoginThrottles SET LockedUntil = $1 WHERE ThrottleKey = $2`, lockedUntil, throttleKey)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	// Record the lockout, so it can be reviewed and lifted by an admin
+	_, err = db.Exec(`INSERT INTO Lockouts (ThrottleKey, IPAddress, Failures, LockedUntil)
+	VALUES ($1, $2, $3, $4)`, throttleKey, ipAddress, failures, lockedUntil)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// ListLockouts returns a list of lockouts, optionally only those that are still in effect
+func ListLockouts(activeOnly bool) ([]Lockout, error) {
+	rows, err := db.Query(`SELECT ID, ThrottleKey, IPAddress, Failures, LockedUntil, CreatedOn, UnlockedOn, COALESCE(UnlockedBy, 0)
+	FROM Lockouts
+	WHERE NOT $1 OR (UnlockedOn IS NULL AND LockedUntil > NOW())
+	ORDER BY CreatedOn DESC`, activeOnly)
+
+	if err != nil {
+		return []Lockout{}, utils.InternalServerError(err)
+	}
+
+	// Iterate over all rows and store in slice
+	lockouts := []Lockout{}
+	var lockout Lockout
+	var lockedUntil, createdOn time.Time
+	var unlockedOn *time.Time
+
+	for rows.Next() {
+		err = rows.Scan(&lockout.ID, &lockout.ThrottleKey, &lockout.IPAddress, &lockout.Failures, &lockedUntil, &createdOn, &unlockedOn, &lockout.UnlockedBy)
+		if err != nil {
+			return []Lockout{}, utils.InternalServerError(err)
+		}
+
+		lockout.LockedUntil = lockedUntil.Format(time.RFC3339)
+		lockout.CreatedOn = createdOn.Format(time.RFC3339)
+		lockout.UnlockedOn = ""
+		if unlockedOn != nil {
+			lockout.UnlockedOn = unlockedOn.Format(time.RFC3339)
+		}
+
+		lockouts = append(lockouts, lockout)
+	}
+
+	return lockouts, nil
+}
+
+// Unlock lifts a lockout and clears the failed attempts of the locked out account or IP address
+func Unlock(lockoutID, adminID int) error {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	var throttleKey string
+
+	err = tx.QueryRow(`UPDATE Lockouts
+	SET UnlockedOn = NOW(), UnlockedBy = $1
+	WHERE ID = $2 AND UnlockedOn IS NULL
+	RETURNING ThrottleKey`, adminID, lockoutID).Scan(&throttleKey)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		if err == sql.ErrNoRows {
+			return utils.NotFoundErrorMessage(fmt.Sprintf("No active lockout with ID %d found", lockoutID))
+		}
+		return utils.InternalServerError(err)
+	}
+
+	// Other lockouts of the same key are lifted along with it
+	_, err = tx.Exec(`UPDATE Lockouts
+	SET UnlockedOn = NOW(), UnlockedBy = $1
+	WHERE ThrottleKey = $2 AND UnlockedOn IS NULL`, adminID, throttleKey)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	_, err = tx.Exec(`DELETE FROM LoginThrottles WHERE ThrottleKey = $1`, throttleKey)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
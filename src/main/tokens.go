@@ -0,0 +1,130 @@
+package main
+
+import (
+	"database/sql"
+	"main/utils"
+	"time"
+)
+
+const (
+	UserTokenSubject      = "user"
+	DispenserTokenSubject = "dispenser"
+)
+
+// CreateRefreshToken creates a new refresh token for a user or dispenser and returns its plaintext value. Refresh tokens
+// of users belong to a session, dispensers pass a session ID of 0
+func CreateRefreshToken(subjectType string, subjectID, sessionID int, lifetime time.Duration) (string, error) {
+	// Generate a random token, only its hash is stored in the database
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		return "", utils.InternalServerError(err)
+	}
+
+	var session interface{}
+	if sessionID > 0 {
+		session = sessionID
+	}
+
+	_, err = db.Exec(`INSERT INTO RefreshTokens (TokenHash, SubjectType, SubjectID, SessionID, ExpiresOn)
+	VALUES ($1, $2, $3, $4, $5)`, utils.HashSHA256(token), subjectType, subjectID, session, time.Now().Add(lifetime))
+
+	if err != nil {
+		return "", utils.InternalServerError(err)
+	}
+
+	return token, nil
+}
+
+// ConsumeRefreshToken revokes a valid refresh token of the given subject type and returns the ID of its subject and session
+func ConsumeRefreshToken(subjectType, token string) (int, int, error) {
+	var subjectID, sessionID int
+
+	err := db.QueryRow(`UPDATE RefreshTokens
+	SET Revoked = TRUE
+	WHERE TokenHash = $1 AND SubjectType = $2 AND NOT Revoked AND ExpiresOn > NOW()
+	RETURNING SubjectID, COALESCE(SessionID, 0)`, utils.HashSHA256(token), subjectType).Scan(&subjectID, &sessionID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, 0, utils.UnauthorizedErrorMessage("Invalid or expired refresh token")
+		}
+		return 0, 0, utils.InternalServerError(err)
+	}
+
+	return subjectID, sessionID, nil
+}
+
+// RevokeRefreshToken revokes a refresh token of the given subject
+func RevokeRefreshToken(subjectType string, subjectID int, token string) error {
+	_, err := db.Exec(`UPDATE RefreshTokens
+	SET Revoked = TRUE
+	WHERE TokenHash = $1 AND SubjectType = $2 AND SubjectID = $3`, utils.HashSHA256(token), subjectType, subjectID)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// RevokeAllRefreshTokens revokes all refresh tokens of the given subject
+func RevokeAllRefreshTokens(subjectType string, subjectID int) error {
+	_, err := db.Exec(`UPDATE RefreshTokens
+	SET Revoked = TRUE
+	WHERE SubjectType = $1 AND SubjectID = $2 AND NOT Revoked`, subjectType, subjectID)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// RevokeToken adds the ID of an access token to the revocation list
+func RevokeToken(tokenID string, expiresOn time.Time) error {
+	_, err := db.Exec(`INSERT INTO RevokedTokens (TokenID, ExpiresOn)
+	VALUES ($1, $2)
+	ON CONFLICT (TokenID) DO NOTHING`, tokenID, expiresOn)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	// Expired tokens are rejected anyway, so they can be removed from the revocation list
+	_, err = db.Exec(`DELETE FROM RevokedTokens WHERE ExpiresOn < NOW()`)
+	if err != nil {
+		utils.LogError(err)
+	}
+
+	return nil
+}
+
+// IsTokenRevoked returns whether the access token with the given ID has been revoked
+func IsTokenRevoked(tokenID string) (bool, error) {
+	var revoked bool
+
+	err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM RevokedTokens WHERE TokenID = $1)`, tokenID).Scan(&revoked)
+	if err != nil {
+		return false, utils.InternalServerError(err)
+	}
+
+	return revoked, nil
+}
+
+// ConsumeToken adds the ID of a single-use token to the revocation list, and returns false if it had already been used
+func ConsumeToken(tokenID string, expiresOn time.Time) (bool, error) {
+	result, err := db.Exec(`INSERT INTO RevokedTokens (TokenID, ExpiresOn)
+	VALUES ($1, $2)
+	ON CONFLICT (TokenID) DO NOTHING`, tokenID, expiresOn)
+
+	if err != nil {
+		return false, utils.InternalServerError(err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, utils.InternalServerError(err)
+	}
+
+	return n > 0, nil
+}
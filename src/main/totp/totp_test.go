@@ -0,0 +1,75 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// Secret of the SHA-1 test vectors in RFC 6238, "12345678901234567890" in base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	cases := []struct {
+		unix     int64
+		expected string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, c := range cases {
+		code, err := Code(rfcSecret, Step(time.Unix(c.unix, 0)))
+		if err != nil {
+			t.Fatalf("%d: unexpected error %v", c.unix, err)
+		}
+		if code != c.expected {
+			t.Errorf("%d: expected %s, got %s", c.unix, c.expected, code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := Step(now)
+
+	codeAt := func(step int64) string {
+		code, err := Code(rfcSecret, step)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		return code
+	}
+
+	cases := []struct {
+		name         string
+		secret       string
+		code         string
+		lastStep     int64
+		expectedStep int64
+		expectedOK   bool
+	}{
+		{"current step", rfcSecret, codeAt(current), 0, current, true},
+		{"previous step", rfcSecret, codeAt(current - 1), 0, current - 1, true},
+		{"next step", rfcSecret, codeAt(current + 1), 0, current + 1, true},
+		{"outside skew before", rfcSecret, codeAt(current - 2), 0, 0, false},
+		{"outside skew after", rfcSecret, codeAt(current + 2), 0, 0, false},
+		{"replayed step", rfcSecret, codeAt(current), current, 0, false},
+		{"older than last step", rfcSecret, codeAt(current - 1), current - 1, 0, false},
+		{"newer than last step", rfcSecret, codeAt(current + 1), current, current + 1, true},
+		{"whitespace", rfcSecret, " " + codeAt(current) + "\n", 0, current, true},
+		{"lowercase secret", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", codeAt(current), 0, current, true},
+		{"wrong code", rfcSecret, "000000", 0, 0, false},
+		{"empty code", rfcSecret, "", 0, 0, false},
+		{"invalid secret", "not base32!", codeAt(current), 0, 0, false},
+	}
+
+	for _, c := range cases {
+		step, ok := Validate(c.secret, c.code, now, c.lastStep)
+		if ok != c.expectedOK || step != c.expectedStep {
+			t.Errorf("%s: expected (%d, %t), got (%d, %t)", c.name, c.expectedStep, c.expectedOK, step, ok)
+		}
+	}
+}
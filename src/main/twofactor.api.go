@@ -0,0 +1,163 @@
+package main
+
+import (
+	"main/utils"
+	"net/http"
+)
+
+// HandleBeginTwoFactorEnrollment handles the start of a two-factor enrollment of the current user
+func HandleBeginTwoFactorEnrollment(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Generate a secret and return it to the user
+	enrollment, err := BeginTwoFactorEnrollment(session.UserID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, enrollment)
+}
+
+// HandleConfirmTwoFactorEnrollment handles the confirmation of a two-factor enrollment of the current user
+func HandleConfirmTwoFactorEnrollment(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read code from request body
+	var code TwoFactorCode
+
+	err = utils.ReadJSONFromRequest(r, &code)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Enable two-factor authentication and return the recovery codes
+	recoveryCodes, err := ConfirmTwoFactorEnrollment(session.UserID, code)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, recoveryCodes)
+}
+
+// HandleRegenerateRecoveryCodes handles the replacement of the recovery codes of the current user
+func HandleRegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read code from request body
+	var code TwoFactorCode
+
+	err = utils.ReadJSONFromRequest(r, &code)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Verify the code and return new recovery codes
+	err = CheckTwoFactorCode(session.UserID, code, utils.ClientIP(r, config.Host.TrustProxyHeaders))
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	recoveryCodes, err := GenerateRecoveryCodes(session.UserID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, recoveryCodes)
+}
+
+// HandleDisableTwoFactor handles the disabling of two-factor authentication for the current user
+func HandleDisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read code from request body
+	var code TwoFactorCode
+
+	err = utils.ReadJSONFromRequest(r, &code)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Disable two-factor authentication and respond
+	err = DisableTwoFactor(session.UserID, code, utils.ClientIP(r, config.Host.TrustProxyHeaders))
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleBeginChallengeEnrollment handles the start of a two-factor enrollment during authentication
+func HandleBeginChallengeEnrollment(w http.ResponseWriter, r *http.Request) {
+	// Read challenge from request body
+	var challenge TwoFactorChallengeRequest
+
+	err := utils.ReadJSONFromRequest(r, &challenge)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Generate a secret and return it to the user
+	enrollment, err := BeginChallengeEnrollment(challenge)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, enrollment)
+}
+
+// HandleCompleteTwoFactorAuthentication handles the second step of an authentication
+func HandleCompleteTwoFactorAuthentication(w http.ResponseWriter, r *http.Request) {
+	// Read verification from request body
+	var verification TwoFactorVerification
+
+	err := utils.ReadJSONFromRequest(r, &verification)
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestError(err))
+		return
+	}
+
+	// Verify the code and return the session token
+	token, err := CompleteTwoFactorAuthentication(verification, utils.ClientIP(r, config.Host.TrustProxyHeaders), r.UserAgent())
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, token)
+}
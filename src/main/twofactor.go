@@ -0,0 +1,436 @@
+package main
+
+import (
+	"database/sql"
+	"github.com/dgrijalva/jwt-go"
+	"main/totp"
+	"main/utils"
+	"strings"
+	"time"
+)
+
+type (
+	// TwoFactorEnrollment contains the secret a user should add to their authenticator app
+	TwoFactorEnrollment struct {
+		Secret string `json:"secret"`
+		URI    string `json:"uri"`
+	}
+
+	// TwoFactorCode contains a two-factor authentication or recovery code
+	TwoFactorCode struct {
+		Code string `json:"code"`
+	}
+
+	// TwoFactorChallengeRequest contains the challenge token issued after a successful password check
+	TwoFactorChallengeRequest struct {
+		ChallengeToken string `json:"challengeToken"`
+	}
+
+	// TwoFactorVerification contains the challenge token and the code that completes an authentication
+	TwoFactorVerification struct {
+		ChallengeToken string `json:"challengeToken"`
+		Code           string `json:"code"`
+	}
+
+	// RecoveryCodes contains the single-use recovery codes of a user
+	RecoveryCodes struct {
+		RecoveryCodes []string `json:"recoveryCodes"`
+	}
+
+	// twoFactorStatus contains the two-factor authentication settings of a user
+	twoFactorStatus struct {
+		Secret   string
+		Enabled  bool
+		LastStep int64
+	}
+
+	// twoFactorChallenge contains the claims of a challenge token
+	twoFactorChallenge struct {
+		UserID    int
+		TokenID   string
+		ExpiresAt time.Time
+	}
+)
+
+const (
+	TwoFactorIssuer         = "MySMDS"
+	TwoFactorChallengeScope = "twofactor"
+	ChallengeTokenLifetime  = 5 * time.Minute
+	RecoveryCodeCount       = 10
+)
+
+// IsTwoFactorRequired returns whether users with the given role must use two-factor authentication
+func IsTwoFactorRequired(role string) bool {
+	for _, requiredRole := range strings.Split(config.TwoFactor.RequiredRoles, ",") {
+		if strings.TrimSpace(requiredRole) == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readTwoFactorStatus returns the two-factor authentication settings of a user, or nil if the user never enrolled
+func readTwoFactorStatus(userID int) (*twoFactorStatus, error) {
+	var status twoFactorStatus
+
+	err := db.QueryRow(`SELECT Secret, Enabled, LastStep FROM UserTwoFactor WHERE UserID = $1`, userID).Scan(&status.Secret, &status.Enabled, &status.LastStep)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, utils.InternalServerError(err)
+	}
+
+	return &status, nil
+}
+
+// BeginTwoFactorEnrollment generates a new secret for a user, which is enabled once a code is confirmed
+func BeginTwoFactorEnrollment(userID int) (TwoFactorEnrollment, error) {
+	status, err := readTwoFactorStatus(userID)
+	if err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+	if status != nil && status.Enabled {
+		return TwoFactorEnrollment{}, utils.BadRequestErrorMessage("Two-factor authentication is already enabled")
+	}
+
+	user, err := ReadUser(userID)
+	if err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+
+	// Generate and store the pending secret
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return TwoFactorEnrollment{}, utils.InternalServerError(err)
+	}
+
+	_, err = db.Exec(`INSERT INTO UserTwoFactor (UserID, Secret, Enabled, LastStep)
+	VALUES ($1, $2, FALSE, 0)
+	ON CONFLICT (UserID) DO UPDATE SET Secret = EXCLUDED.Secret, Enabled = FALSE, LastStep = 0`, userID, secret)
+
+	if err != nil {
+		return TwoFactorEnrollment{}, utils.InternalServerError(err)
+	}
+
+	return TwoFactorEnrollment{
+		Secret: secret,
+		URI:    totp.URI(TwoFactorIssuer, user.Username, secret),
+	}, nil
+}
+
+// ConfirmTwoFactorEnrollment enables two-factor authentication once the user proves they added the secret, and returns new recovery codes
+func ConfirmTwoFactorEnrollment(userID int, code TwoFactorCode) (RecoveryCodes, error) {
+	status, err := readTwoFactorStatus(userID)
+	if err != nil {
+		return RecoveryCodes{}, err
+	}
+	if status == nil {
+		return RecoveryCodes{}, utils.BadRequestErrorMessage("Two-factor authentication enrollment has not been started")
+	}
+	if status.Enabled {
+		return RecoveryCodes{}, utils.BadRequestErrorMessage("Two-factor authentication is already enabled")
+	}
+
+	step, ok := totp.Validate(status.Secret, code.Code, time.Now(), status.LastStep)
+	if !ok {
+		return RecoveryCodes{}, utils.BadRequestErrorMessage("Invalid two-factor authentication code")
+	}
+
+	// Only enable once, and only if the code's time step wasn't used concurrently
+	result, err := db.Exec(`UPDATE UserTwoFactor SET Enabled = TRUE, LastStep = $1
+	WHERE UserID = $2 AND NOT Enabled AND LastStep < $1`, step, userID)
+	if err != nil {
+		return RecoveryCodes{}, utils.InternalServerError(err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return RecoveryCodes{}, utils.InternalServerError(err)
+	}
+	if n == 0 {
+		return RecoveryCodes{}, utils.BadRequestErrorMessage("Invalid two-factor authentication code")
+	}
+
+	return GenerateRecoveryCodes(userID)
+}
+
+// DisableTwoFactor disables two-factor authentication for a user after verifying a code
+func DisableTwoFactor(userID int, code TwoFactorCode, ipAddress string) error {
+	err := CheckTwoFactorCode(userID, code, ipAddress)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`DELETE FROM UserTwoFactor WHERE UserID = $1`, userID)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	_, err = db.Exec(`DELETE FROM TwoFactorRecoveryCodes WHERE UserID = $1`, userID)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// GenerateRecoveryCodes replaces the recovery codes of a user, only their hashes are stored
+func GenerateRecoveryCodes(userID int) (RecoveryCodes, error) {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return RecoveryCodes{}, utils.InternalServerError(err)
+	}
+
+	_, err = tx.Exec(`DELETE FROM TwoFactorRecoveryCodes WHERE UserID = $1`, userID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return RecoveryCodes{}, utils.InternalServerError(err)
+	}
+
+	codes := []string{}
+
+	for i := 0; i < RecoveryCodeCount; i++ {
+		code, err := utils.RandomToken(5)
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return RecoveryCodes{}, utils.InternalServerError(err)
+		}
+
+		_, err = tx.Exec(`INSERT INTO TwoFactorRecoveryCodes (UserID, CodeHash) VALUES ($1, $2)`, userID, utils.HashSHA256(code))
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return RecoveryCodes{}, utils.InternalServerError(err)
+		}
+
+		codes = append(codes, code)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return RecoveryCodes{}, utils.InternalServerError(err)
+	}
+
+	return RecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// CheckTwoFactorCode verifies a code of a logged in user, failed attempts are throttled so codes can't be guessed
+func CheckTwoFactorCode(userID int, code TwoFactorCode, ipAddress string) error {
+	throttleKey := twoFactorThrottleKey(userID)
+
+	err := checkThrottle("Too many invalid two-factor authentication codes", throttleKey)
+	if err != nil {
+		return err
+	}
+
+	ok, err := verifyTwoFactorCode(userID, code.Code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		err = recordFailure(throttleKey, ipAddress, MaxAccountFailures)
+		if err != nil {
+			return err
+		}
+
+		return utils.BadRequestErrorMessage("Invalid two-factor authentication code")
+	}
+
+	return ResetLoginThrottle(throttleKey)
+}
+
+// verifyTwoFactorCode checks a code or unused recovery code of a user with two-factor authentication enabled
+func verifyTwoFactorCode(userID int, code string) (bool, error) {
+	status, err := readTwoFactorStatus(userID)
+	if err != nil {
+		return false, err
+	}
+	if status == nil || !status.Enabled {
+		return false, utils.BadRequestErrorMessage("Two-factor authentication is not enabled")
+	}
+
+	// Check the code against the secret, remembering its time step so it can't be replayed, not even by a concurrent request
+	if step, ok := totp.Validate(status.Secret, code, time.Now(), status.LastStep); ok {
+		result, err := db.Exec(`UPDATE UserTwoFactor SET LastStep = $1 WHERE UserID = $2 AND LastStep < $1`, step, userID)
+		if err != nil {
+			return false, utils.InternalServerError(err)
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil {
+			return false, utils.InternalServerError(err)
+		}
+
+		return n > 0, nil
+	}
+
+	// Otherwise, try to use the code as a recovery code
+	result, err := db.Exec(`UPDATE TwoFactorRecoveryCodes
+	SET UsedOn = NOW()
+	WHERE UserID = $1 AND CodeHash = $2 AND UsedOn IS NULL`, userID, utils.HashSHA256(strings.TrimSpace(code)))
+
+	if err != nil {
+		return false, utils.InternalServerError(err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, utils.InternalServerError(err)
+	}
+
+	return n > 0, nil
+}
+
+// createTwoFactorChallenge returns a session token containing only a challenge token for the second authentication step
+func createTwoFactorChallenge(session Session, enrollmentRequired bool) (SessionToken, error) {
+	challengeToken, expiresAt, err := signAccessToken(jwt.MapClaims{
+		"userId":             session.UserID,
+		"scope":              TwoFactorChallengeScope,
+		"enrollmentRequired": enrollmentRequired,
+	}, ChallengeTokenLifetime)
+
+	if err != nil {
+		return SessionToken{}, utils.InternalServerError(err)
+	}
+
+	return SessionToken{
+		TwoFactorRequired:  true,
+		EnrollmentRequired: enrollmentRequired,
+		ChallengeToken:     challengeToken,
+		ExpiresAt:          expiresAt.Unix(),
+	}, nil
+}
+
+// readTwoFactorChallenge validates a challenge token that has not been used yet and returns its claims
+func readTwoFactorChallenge(challengeToken string) (twoFactorChallenge, error) {
+	token, err := jwt.Parse(challengeToken, JWTKeyFunc)
+	if err != nil {
+		if _, ok := err.(*jwt.ValidationError); ok {
+			return twoFactorChallenge{}, utils.UnauthorizedError(err)
+		}
+		return twoFactorChallenge{}, utils.InternalServerError(err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["scope"] != TwoFactorChallengeScope {
+		return twoFactorChallenge{}, utils.UnauthorizedErrorMessage("Invalid challenge token")
+	}
+
+	userID, ok1 := claims["userId"].(float64)
+	tokenID, ok2 := claims["jti"].(string)
+	expiresAt, ok3 := claims["exp"].(float64)
+	if !ok1 || !ok2 || !ok3 {
+		return twoFactorChallenge{}, utils.UnauthorizedErrorMessage("Invalid challenge token")
+	}
+
+	revoked, err := IsTokenRevoked(tokenID)
+	if err != nil {
+		return twoFactorChallenge{}, err
+	}
+	if revoked {
+		return twoFactorChallenge{}, utils.UnauthorizedErrorMessage("Challenge token has already been used")
+	}
+
+	return twoFactorChallenge{
+		UserID:    int(userID),
+		TokenID:   tokenID,
+		ExpiresAt: time.Unix(int64(expiresAt), 0),
+	}, nil
+}
+
+// consumeTwoFactorChallenge marks a challenge token as used, so it can't complete more than one login
+func consumeTwoFactorChallenge(challenge twoFactorChallenge) error {
+	ok, err := ConsumeToken(challenge.TokenID, challenge.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return utils.UnauthorizedErrorMessage("Challenge token has already been used")
+	}
+
+	return nil
+}
+
+// BeginChallengeEnrollment starts the two-factor enrollment of a user that must enroll before they can log in
+func BeginChallengeEnrollment(challenge TwoFactorChallengeRequest) (TwoFactorEnrollment, error) {
+	parsed, err := readTwoFactorChallenge(challenge.ChallengeToken)
+	if err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+
+	return BeginTwoFactorEnrollment(parsed.UserID)
+}
+
+// CompleteTwoFactorAuthentication verifies the code of the second authentication step and returns the final session token
+func CompleteTwoFactorAuthentication(verification TwoFactorVerification, ipAddress, device string) (SessionToken, error) {
+	challenge, err := readTwoFactorChallenge(verification.ChallengeToken)
+	if err != nil {
+		return SessionToken{}, err
+	}
+
+	userID := challenge.UserID
+
+	session, err := readSession(userID)
+	if err != nil {
+		return SessionToken{}, err
+	}
+
+	// Code attempts count towards the same lockout as password attempts
+	accountKey := userThrottleKey(session.Username)
+
+	err = CheckLoginThrottle(accountKey, ipAddress)
+	if err != nil {
+		return SessionToken{}, err
+	}
+
+	status, err := readTwoFactorStatus(userID)
+	if err != nil {
+		return SessionToken{}, err
+	}
+
+	// Users that enroll while logging in confirm their pending secret and receive their recovery codes
+	if status != nil && !status.Enabled {
+		recoveryCodes, err := ConfirmTwoFactorEnrollment(userID, TwoFactorCode{Code: verification.Code})
+		if err != nil {
+			return SessionToken{}, rejectLogin(accountKey, ipAddress)
+		}
+
+		err = consumeTwoFactorChallenge(challenge)
+		if err != nil {
+			return SessionToken{}, err
+		}
+
+		token, err := StartUserSession(session, ipAddress, device)
+		if err != nil {
+			return SessionToken{}, err
+		}
+
+		token.RecoveryCodes = recoveryCodes.RecoveryCodes
+		return token, ResetLoginThrottle(accountKey)
+	}
+
+	ok, err := verifyTwoFactorCode(userID, verification.Code)
+	if err != nil {
+		return SessionToken{}, err
+	}
+	if !ok {
+		return SessionToken{}, rejectLogin(accountKey, ipAddress)
+	}
+
+	err = consumeTwoFactorChallenge(challenge)
+	if err != nil {
+		return SessionToken{}, err
+	}
+
+	err = ResetLoginThrottle(accountKey)
+	if err != nil {
+		return SessionToken{}, err
+	}
+
+	return StartUserSession(session, ipAddress, device)
+}
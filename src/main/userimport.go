@@ -0,0 +1,482 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/hlandau/passlib.v1"
+	"io"
+	"main/utils"
+	"main/validate"
+	"strings"
+)
+
+type (
+	// ImportedUser contains a single row of a user import. Patients are linked to their doctors and pharmacists by
+	// username, which may refer to existing users or to users in the same import
+	ImportedUser struct {
+		Username  string `json:"username"`
+		FullName  string `json:"fullName"`
+		Role      string `json:"role"`
+		Password  string `json:"password"`
+		Email     string `json:"email"`
+		Birthdate string `json:"birthdate"`
+		Gender    string `json:"gender"`
+		Phone     string `json:"phone"`
+
+		Doctors     []string `json:"doctors"`
+		Pharmacists []string `json:"pharmacists"`
+	}
+
+	// UserImportRowResult contains the result of importing a single row
+	UserImportRowResult struct {
+		Row      int      `json:"row"`
+		Username string   `json:"username"`
+		Status   string   `json:"status"`
+		UserID   int      `json:"userId,omitempty"`
+		Errors   []string `json:"errors"`
+	}
+
+	// UserImportReport contains the results of a user import. Nothing is imported if any row is invalid
+	UserImportReport struct {
+		DryRun   bool                  `json:"dryRun"`
+		Imported bool                  `json:"imported"`
+		Valid    int                   `json:"valid"`
+		Invalid  int                   `json:"invalid"`
+		Rows     []UserImportRowResult `json:"rows"`
+	}
+
+	// importRelation contains a relation of an imported patient, by the username of the related user
+	importRelation struct {
+		Username     string
+		Role         string
+		RelationType string
+	}
+
+	// importExistingUser contains an existing user an import refers to, the ID is 0 if no user with the username exists
+	importExistingUser struct {
+		ID       int
+		Role     string
+		Archived bool
+	}
+)
+
+const (
+	MaxImportRows = 1000
+
+	ImportStatusValid   = "valid"
+	ImportStatusInvalid = "invalid"
+	ImportStatusCreated = "created"
+
+	// Separator of the usernames in the doctors and pharmacists columns of a CSV import
+	importListSeparator = ";"
+)
+
+var (
+	importRequiredColumns = []string{"username", "fullname", "role", "email"}
+	importOptionalColumns = []string{"password", "birthdate", "gender", "phone", "doctors", "pharmacists"}
+)
+
+// ReadUserImportCSV reads the rows of a user import from a CSV file with a header row
+func ReadUserImportCSV(reader io.Reader) ([]ImportedUser, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, utils.BadRequestErrorMessage(fmt.Sprintf("Invalid CSV: %s", err.Error()))
+	}
+
+	if len(records) == 0 {
+		return nil, utils.BadRequestErrorMessage("The CSV file has no header row")
+	}
+
+	// Map the columns by their lowercase header
+	columns := make(map[string]int)
+
+	for i, header := range records[0] {
+		column := strings.ToLower(strings.TrimSpace(header))
+		if !containsString(importRequiredColumns, column) && !containsString(importOptionalColumns, column) {
+			return nil, utils.BadRequestErrorMessage(fmt.Sprintf("Unknown column '%s'", header))
+		}
+
+		columns[column] = i
+	}
+
+	for _, column := range importRequiredColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, utils.BadRequestErrorMessage(fmt.Sprintf("Missing required column '%s'", column))
+		}
+	}
+
+	// Read the rows
+	users := []ImportedUser{}
+
+	for _, record := range records[1:] {
+		field := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		users = append(users, ImportedUser{
+			Username:    field("username"),
+			FullName:    field("fullname"),
+			Role:        field("role"),
+			Password:    field("password"),
+			Email:       field("email"),
+			Birthdate:   field("birthdate"),
+			Gender:      field("gender"),
+			Phone:       field("phone"),
+			Doctors:     splitImportList(field("doctors")),
+			Pharmacists: splitImportList(field("pharmacists")),
+		})
+	}
+
+	return users, nil
+}
+
+// ReadUserImportJSON reads the rows of a user import from a JSON array
+func ReadUserImportJSON(reader io.Reader) ([]ImportedUser, error) {
+	users := []ImportedUser{}
+
+	err := json.NewDecoder(reader).Decode(&users)
+	if err != nil {
+		return nil, utils.BadRequestError(err)
+	}
+
+	return users, nil
+}
+
+// splitImportList splits a list of usernames in a CSV field
+func splitImportList(field string) []string {
+	list := []string{}
+
+	for _, item := range strings.Split(field, importListSeparator) {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// containsString returns whether a slice contains a string
+func containsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ImportUsers validates the rows of a user import and, unless it is a dry run, creates all users and their relations in a
+// single transaction. Users are only created if all rows are valid. Staff accounts can only be imported if allowStaff is set
+func ImportUsers(users []ImportedUser, dryRun, allowStaff bool, actor AuditActor) (UserImportReport, error) {
+	if len(users) == 0 {
+		return UserImportReport{}, utils.BadRequestErrorMessage("The import contains no users")
+	}
+
+	if len(users) > MaxImportRows {
+		return UserImportReport{}, utils.BadRequestErrorMessage(fmt.Sprintf("An import may contain at most %d users", MaxImportRows))
+	}
+
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return UserImportReport{}, utils.InternalServerError(err)
+	}
+
+	// Block concurrent changes of users until the import is committed, so validated usernames stay unique and the
+	// related users keep their role
+	_, err = tx.Exec(`LOCK TABLE Users IN SHARE ROW EXCLUSIVE MODE`)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserImportReport{}, utils.InternalServerError(err)
+	}
+
+	// Validate every row
+	report, existingUsers, err := validateUserImport(tx, users, allowStaff)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserImportReport{}, err
+	}
+
+	report.DryRun = dryRun
+	if dryRun || report.Invalid > 0 {
+		utils.RollbackOrLog(tx)
+		return report, nil
+	}
+
+	// Create the users, relations can refer to both existing and created users
+	userIDs := make(map[string]int)
+
+	for username, existingUser := range existingUsers {
+		if existingUser.ID > 0 {
+			userIDs[username] = existingUser.ID
+		}
+	}
+
+	for i, user := range users {
+		password := user.Password
+
+		// Users imported without a password must set one using a password reset
+		if len(password) == 0 {
+			password, err = utils.RandomToken(32)
+			if err != nil {
+				utils.RollbackOrLog(tx)
+				return UserImportReport{}, utils.InternalServerError(err)
+			}
+		}
+
+		passHash, err := passlib.Hash(password)
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return UserImportReport{}, utils.InternalServerError(err)
+		}
+
+		var userID int
+		err = tx.QueryRow(`INSERT INTO Users (Username, FullName, PasswordHash, Role, Email, birthdate, gender, phone)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`, user.Username, user.FullName, passHash, user.Role, user.Email,
+			user.Birthdate, user.Gender, user.Phone).Scan(&userID)
+
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return UserImportReport{}, utils.InternalServerError(err)
+		}
+
+		userIDs[user.Username] = userID
+		report.Rows[i].UserID = userID
+		report.Rows[i].Status = ImportStatusCreated
+	}
+
+	// Link the patients to their doctors and pharmacists
+	for _, user := range users {
+		for _, relation := range user.relations() {
+			_, err = tx.Exec(`INSERT INTO PatientRelations (PatientID, RelationID, RelationType) VALUES ($1, $2, $3)`,
+				userIDs[user.Username], userIDs[relation.Username], relation.RelationType)
+
+			if err != nil {
+				utils.RollbackOrLog(tx)
+				return UserImportReport{}, utils.InternalServerError(err)
+			}
+		}
+	}
+
+	// Audit the created users, once their relations are known
+	for _, row := range report.Rows {
+		user, err := readUser(tx, row.UserID)
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return UserImportReport{}, err
+		}
+
+		err = RecordAudit(tx, actor, AuditActionCreate, AuditEntityUser, user.ID, user.AuditPatientID(), nil, user)
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return UserImportReport{}, err
+		}
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserImportReport{}, utils.InternalServerError(err)
+	}
+
+	report.Imported = true
+
+	// Send a password reset to the users that were imported without a password
+	for i, user := range users {
+		if len(user.Password) == 0 {
+			err = SendPasswordReset(report.Rows[i].UserID)
+			if err != nil {
+				utils.LogErrorMessage(fmt.Sprintf("Failed to send a password reset to imported user %s: %s", user.Username, err.Error()))
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// relations returns the distinct relations of an imported user
+func (iu ImportedUser) relations() []importRelation {
+	relations := []importRelation{}
+	seen := make(map[string]bool)
+
+	// The first doctor becomes the primary doctor of the patient
+	for _, username := range iu.Doctors {
+		if !seen[username] {
+			relationType := RelationConsultingDoctor
+			if len(relations) == 0 {
+				relationType = RelationPrimaryDoctor
+			}
+
+			relations = append(relations, importRelation{Username: username, Role: DoctorRole, RelationType: relationType})
+			seen[username] = true
+		}
+	}
+
+	for _, username := range iu.Pharmacists {
+		if !seen[username] {
+			relations = append(relations, importRelation{Username: username, Role: PharmacistRole, RelationType: RelationPharmacist})
+			seen[username] = true
+		}
+	}
+
+	return relations
+}
+
+// validateUserImport validates all rows of a user import inside the import transaction, and returns a report with the
+// errors of each row and the existing users the import refers to by username
+func validateUserImport(tx *sql.Tx, users []ImportedUser, allowStaff bool) (UserImportReport, map[string]importExistingUser, error) {
+	report := UserImportReport{Rows: []UserImportRowResult{}}
+
+	// Index the users in the import by username, so relations can refer to them
+	importedRoles := make(map[string]string)
+	occurrences := make(map[string]int)
+
+	for _, user := range users {
+		importedRoles[user.Username] = user.Role
+		occurrences[user.Username]++
+	}
+
+	// Cache the lookups of roles and existing users, as many rows refer to the same ones
+	roleExists := make(map[string]bool)
+	existingUsers := make(map[string]importExistingUser)
+
+	lookupRole := func(role string) (bool, error) {
+		if exists, ok := roleExists[role]; ok {
+			return exists, nil
+		}
+
+		exists, err := RoleExists(role)
+		if err != nil {
+			return false, err
+		}
+
+		roleExists[role] = exists
+		return exists, nil
+	}
+
+	lookupUser := func(username string) (importExistingUser, error) {
+		if existingUser, ok := existingUsers[username]; ok {
+			return existingUser, nil
+		}
+
+		var existingUser importExistingUser
+
+		err := tx.QueryRow(`SELECT ID, Role, ArchivedOn IS NOT NULL FROM Users WHERE Username = $1`, username).Scan(&existingUser.ID, &existingUser.Role, &existingUser.Archived)
+		if err != nil && err != sql.ErrNoRows {
+			return existingUser, utils.InternalServerError(err)
+		}
+
+		existingUsers[username] = existingUser
+		return existingUser, nil
+	}
+
+	for i, user := range users {
+		// Check the profile fields
+		errors := validate.ProfileErrors(validate.Profile{
+			Username:  user.Username,
+			FullName:  user.FullName,
+			Email:     user.Email,
+			Birthdate: user.Birthdate,
+			Gender:    user.Gender,
+		})
+
+		if len(user.Password) > 0 {
+			if err := validatePassword(user.Password); err != nil {
+				errors = append(errors, err.Error())
+			}
+		}
+
+		// Check the role
+		if len(user.Role) == 0 {
+			errors = append(errors, "Role is required")
+		} else if user.Role == DispenserRole {
+			errors = append(errors, "Users can't get the dispenser role")
+		} else {
+			exists, err := lookupRole(user.Role)
+			if err != nil {
+				return UserImportReport{}, nil, err
+			}
+			if !exists {
+				errors = append(errors, fmt.Sprintf("Role '%s' does not exist", user.Role))
+			} else if IsStaffRole(user.Role) && !allowStaff {
+				errors = append(errors, fmt.Sprintf("Users with role '%s' can only be imported by administrators", user.Role))
+			}
+		}
+
+		// Check whether the username is unique
+		if len(user.Username) > 0 {
+			if occurrences[user.Username] > 1 {
+				errors = append(errors, fmt.Sprintf("Username '%s' occurs more than once in the import", user.Username))
+			}
+
+			// Archived users keep their username
+			existingUser, err := lookupUser(user.Username)
+			if err != nil {
+				return UserImportReport{}, nil, err
+			}
+			if existingUser.ID > 0 {
+				errors = append(errors, fmt.Sprintf("Username '%s' is already taken", user.Username))
+			}
+		}
+
+		// Check the relations, which must refer to a user with the right role in either the import or the database
+		relations := user.relations()
+		if len(relations) > 0 && user.Role != PatientRole {
+			errors = append(errors, "Only patients can be linked to doctors and pharmacists")
+		}
+
+		for _, relation := range relations {
+			role, ok := importedRoles[relation.Username]
+			if !ok {
+				existingUser, err := lookupUser(relation.Username)
+				if err != nil {
+					return UserImportReport{}, nil, err
+				}
+
+				if existingUser.ID == 0 {
+					errors = append(errors, fmt.Sprintf("No user with username '%s' found", relation.Username))
+					continue
+				}
+				if existingUser.Archived {
+					errors = append(errors, fmt.Sprintf("User '%s' has been archived", relation.Username))
+					continue
+				}
+
+				role = existingUser.Role
+			}
+
+			if role != relation.Role {
+				errors = append(errors, fmt.Sprintf("User '%s' isn't a %s", relation.Username, relation.Role))
+			}
+		}
+
+		// Add the result of the row, rows are numbered from 1, excluding the header of a CSV import
+		result := UserImportRowResult{
+			Row:      i + 1,
+			Username: user.Username,
+			Status:   ImportStatusValid,
+			Errors:   errors,
+		}
+
+		if len(errors) > 0 {
+			result.Status = ImportStatusInvalid
+			report.Invalid++
+		} else {
+			report.Valid++
+		}
+
+		report.Rows = append(report.Rows, result)
+	}
+
+	return report, existingUsers, nil
+}
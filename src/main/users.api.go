@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"fmt"
 	"main/utils"
+	"strings"
+	"net/url"
 )
 
 func HandleCreateUser(w http.ResponseWriter, r *http.Request) {
@@ -18,8 +20,24 @@ func HandleCreateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Only sessions that may manage staff accounts can create staff
+	if IsStaffRole(newUser.Role) {
+		err = checkStaffPermission(r)
+		if err != nil {
+			utils.WriteError(w, err)
+			return
+		}
+	}
+
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
 	// Create the user
-	user, err := CreateUser(newUser)
+	user, err := CreateUser(newUser, actor)
 
 	if err != nil {
 		utils.WriteError(w, err)
@@ -29,10 +47,37 @@ func HandleCreateUser(w http.ResponseWriter, r *http.Request) {
 	utils.WriteJSON(w, user)
 }
 
-// HandleListUsers returns a list of all users to the client
+// checkStaffPermission returns an error if the session of a request may not manage staff accounts
+func checkStaffPermission(r *http.Request) error {
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		return err
+	}
+
+	allowed, err := SessionHasPermission(session, UsersStaffPermission)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return utils.ForbiddenErrorMessage(fmt.Sprintf("Managing staff accounts requires the %s permission.", UsersStaffPermission))
+	}
+
+	return nil
+}
+
+// HandleListUsers returns a page of users to the client, with the total number of users and the next cursor in the headers
 func HandleListUsers(w http.ResponseWriter, r *http.Request) {
-	users, err := ListUsers(map[string]string{
-		"role": r.URL.Query().Get("role"),
+	query := r.URL.Query()
+
+	page, err := ListUsers(url.Values{
+		"role":     query["role"],
+		"q":        query["q"],
+		"sort":     query["sort"],
+		"limit":    query["limit"],
+		"offset":   query["offset"],
+		"cursor":   query["cursor"],
+		"archived": query["archived"],
 	})
 
 	if err != nil {
@@ -40,7 +85,12 @@ func HandleListUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	utils.WriteJSON(w, users)
+	w.Header().Set(TotalCountHeader, strconv.Itoa(page.Total))
+	if len(page.NextCursor) > 0 {
+		w.Header().Set(NextCursorHeader, page.NextCursor)
+	}
+
+	utils.WriteJSON(w, page.Users)
 }
 
 // HandleReadUser returns a single user to the client
@@ -61,9 +111,47 @@ func HandleReadUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Patients may only be read by those who may access their data
+	if user.Role == PatientRole {
+		session, err := ReadJWTSession(r)
+		if err != nil {
+			utils.WriteError(w, err)
+			return
+		}
+
+		err = AuthorizePatientAccess(session, user.ID)
+		if err != nil {
+			utils.WriteError(w, err)
+			return
+		}
+
+		err = attachClinicalProfile(session, &user)
+		if err != nil {
+			utils.WriteError(w, err)
+			return
+		}
+	}
+
 	utils.WriteJSON(w, user)
 }
 
+// attachClinicalProfile adds the clinical profile to a patient, but only if the session may read profiles. Otherwise the
+// user is returned without their profile
+func attachClinicalProfile(session Session, user *UserDetails) error {
+	allowed, err := SessionHasPermission(session, ProfilesReadPermission)
+	if err != nil || !allowed {
+		return err
+	}
+
+	profile, err := ReadClinicalProfile(user.ID)
+	if err != nil {
+		return err
+	}
+
+	user.ClinicalProfile = &profile
+	return nil
+}
+
 // HandleUpdateUser handles the update of a user
 func HandleUpdateUser(w http.ResponseWriter, r *http.Request) {
 	// Read user ID from URL
@@ -84,8 +172,28 @@ func HandleUpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Only sessions that may manage staff accounts can change roles and edit staff
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	allowStaff, err := SessionHasPermission(session, UsersStaffPermission)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
 	// Update user
-	user, err := UpdateUser(userID, updatedUser)
+	user, err := UpdateUser(userID, updatedUser, allowStaff, actor)
 	if err != nil {
 		utils.WriteError(w, err)
 		return
@@ -94,8 +202,44 @@ func HandleUpdateUser(w http.ResponseWriter, r *http.Request) {
 	utils.WriteJSON(w, user)
 }
 
-// HandleDeleteUser handles the removal of a user
+// HandleDeleteUser handles the removal of a user, which archives the user
 func HandleDeleteUser(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read user ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Archive user and respond
+	err = ArchiveUser(userID, session.UserID, actor)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleRestoreUser handles the restoration of an archived user
+func HandleRestoreUser(w http.ResponseWriter, r *http.Request) {
 	// Read user ID from URL
 	vars := mux.Vars(r)
 
@@ -105,8 +249,44 @@ func HandleDeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Delete user and respond
-	err = DeleteUser(userID)
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Restore user and write to the client
+	user, err := RestoreUser(userID, actor)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, user)
+}
+
+// HandlePurgeUser handles the permanent removal of an archived user
+func HandlePurgeUser(w http.ResponseWriter, r *http.Request) {
+	// Read user ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Purge user and respond
+	err = PurgeUser(userID, actor)
 
 	if err != nil {
 		utils.WriteError(w, err)
@@ -114,4 +294,52 @@ func HandleDeleteUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.WriteHeader(http.StatusNoContent)
-}
\ No newline at end of file
+}
+
+// HandleImportUsers handles the import of users from a CSV file or JSON array, and returns the import report
+func HandleImportUsers(w http.ResponseWriter, r *http.Request) {
+	// Read the rows from the request body, depending on its content type
+	var users []ImportedUser
+	var err error
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+		users, err = ReadUserImportCSV(r.Body)
+	} else {
+		users, err = ReadUserImportJSON(r.Body)
+	}
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read the actor of the request for the audit log
+	actor, err := ReadAuditActor(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Only sessions that may manage staff accounts can import staff
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	allowStaff, err := SessionHasPermission(session, UsersStaffPermission)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Import the users, or only validate them for a dry run
+	report, err := ImportUsers(users, r.URL.Query().Get("dryRun") == "true", allowStaff, actor)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, report)
+}
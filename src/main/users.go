@@ -2,9 +2,15 @@ package main
 
 import (
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"gopkg.in/hlandau/passlib.v1"
 	"main/utils"
+	"main/validate"
+	"net/url"
+	"strings"
+	"time"
 )
 
 type (
@@ -47,38 +53,71 @@ type (
 		Gender    string `json:"gender"`
 		Phone     string `json:"phone"`
 
+		// Set when the user has been archived
+		ArchivedOn string `json:"archivedOn,omitempty"`
+
+		// Only set for patients when read by a session with access to their clinical data, see HandleReadUser
+		ClinicalProfile *ClinicalProfile `json:"clinicalProfile,omitempty"`
+
 		Patients    []UserSummary `json:"patients,omitempty"`
 		Customers   []UserSummary `json:"customers,omitempty"`
 		Doctors     []UserSummary `json:"doctors,omitempty"`
 		Pharmacists []UserSummary `json:"pharmacists,omitempty"`
+		Caregivers  []UserSummary `json:"caregivers,omitempty"`
 	}
 
-	// UpdatedUser represents an updated user
-	UpdatedUser struct {
-		Username string `json:"username"`
-		FullName string `json:"fullName"`
-		Email    string `json:"email"`
+	// ContactDetails contains the contact details users can change themselves. Changing the e-mail address, which
+	// receives password resets, requires the current password
+	ContactDetails struct {
+		Email           string `json:"email"`
+		Phone           string `json:"phone"`
+		CurrentPassword string `json:"currentPassword"`
+	}
 
-		Patients []struct {
-			ID int `json:"id"`
-		} `json:"patients"`
+	// UpdatedUser represents an updated user. An empty role keeps the current role. When a patient gets another role, their
+	// doses and PRN medications are only ended if EndMedication is set, otherwise the role change is refused
+	UpdatedUser struct {
+		Username  string `json:"username"`
+		FullName  string `json:"fullName"`
+		Role      string `json:"role"`
+		Email     string `json:"email"`
+		Birthdate string `json:"birthdate"`
+		Gender    string `json:"gender"`
+		Phone     string `json:"phone"`
 
-		Customers []struct {
-			ID int `json:"id"`
-		} `json:"customers"`
+		EndMedication bool `json:"endMedication"`
+	}
 
-		Doctors []struct {
-			ID int `json:"id"`
-		} `json:"doctors"`
+	// UserPage contains a page of users, the total number of users matching the search and the cursor of the next page
+	UserPage struct {
+		Users      []UserSummary
+		Total      int
+		NextCursor string
+	}
 
-		Pharmacists []struct {
-			ID int `json:"id"`
-		} `json:"pharmacists"`
+	// userCursor contains the position in a sorted user list after which the next page starts
+	userCursor struct {
+		Sort  string `json:"s"`
+		Value string `json:"v"`
+		ID    int    `json:"id"`
 	}
 )
 
+const (
+	MaxUserListLimit = 1000
+)
+
 var (
 	usersSearchMapping SearchMapping
+
+	// Expressions by which user lists can be sorted, in addition to the ID. Text is sorted case-insensitively
+	userSortFields = map[string]string{
+		"username":  "LOWER(Username)",
+		"fullName":  "LOWER(FullName)",
+		"email":     "LOWER(Email)",
+		"birthdate": "COALESCE(CAST(Birthdate AS TEXT), '')",
+		"role":      "Role",
+	}
 )
 
 func init() {
@@ -87,10 +126,38 @@ func init() {
 		SearchType: SearchTypeEqual,
 		DBField:    "Role",
 	})
+
+	usersSearchMapping.DefineFieldMapping("q", FieldMapping{
+		SearchType: SearchTypeContains,
+		DBFields:   []string{"FullName", "Username", "Email", "CAST(Birthdate AS TEXT)"},
+	})
 }
 
-// CreateUser creates a new user
-func CreateUser(newUser NewUser) (UserDetails, error) {
+// AuditPatientID returns the patient ID under which changes to the user are audited, or 0 if the user isn't a patient
+func (ud UserDetails) AuditPatientID() int {
+	if ud.Role == PatientRole {
+		return ud.ID
+	}
+
+	return 0
+}
+
+// CreateUser creates a new user. The care team members given for the user are invited, see InviteToCareTeam
+func CreateUser(newUser NewUser, actor AuditActor) (UserDetails, error) {
+	// Dispensers authenticate with their own credentials and can't be users
+	if newUser.Role == DispenserRole {
+		return UserDetails{}, utils.BadRequestErrorMessage("Users can't get the dispenser role")
+	}
+
+	// Check whether the role exists
+	roleExists, err := RoleExists(newUser.Role)
+	if err != nil {
+		return UserDetails{}, err
+	}
+	if !roleExists {
+		return UserDetails{}, utils.BadRequestErrorMessage(fmt.Sprintf("Role '%s' does not exist", newUser.Role))
+	}
+
 	// Begin SQL transaction
 	tx, err := db.Begin()
 
@@ -116,50 +183,71 @@ func CreateUser(newUser NewUser) (UserDetails, error) {
 		return UserDetails{}, utils.InternalServerError(err)
 	}
 
-	// Commit the created user as Postgres won't accept the foreign keys otherwise
-	err = tx.Commit()
-
-	if err != nil {
-		utils.RollbackOrLog(tx)
-		return UserDetails{}, utils.InternalServerError(err)
+	// Invite the care team members given for the new user, they join the care team when they accept. The first doctor of a
+	// new patient is invited as their primary doctor and a new doctor consults their patients, as these may already have
+	// a primary doctor
+	type invitedMember struct {
+		PatientID    int
+		InviteeID    int
+		RelationType string
 	}
 
-	// Begin new SQL transaction
-	tx, err = db.Begin()
-
-	if err != nil {
-		utils.RollbackOrLog(tx)
-		return UserDetails{}, utils.InternalServerError(err)
-	}
-
-	// Determine to-be inserted relation IDs
-	insertedRelationIDs := []int{}
-	insertedPatientIDs := []int{}
+	invitedMembers := []invitedMember{}
 
 	switch newUser.Role {
 	case PatientRole:
-		insertedRelationIDs = append(newUser.DoctorIDs, newUser.PharmacistIDs...)
+		for i, doctorID := range newUser.DoctorIDs {
+			relationType := RelationConsultingDoctor
+			if i == 0 {
+				relationType = RelationPrimaryDoctor
+			}
+			invitedMembers = append(invitedMembers, invitedMember{userID, doctorID, relationType})
+		}
+		for _, pharmacistID := range newUser.PharmacistIDs {
+			invitedMembers = append(invitedMembers, invitedMember{userID, pharmacistID, RelationPharmacist})
+		}
 	case DoctorRole:
-		insertedPatientIDs = newUser.PatientIDs
+		for _, patientID := range newUser.PatientIDs {
+			invitedMembers = append(invitedMembers, invitedMember{patientID, userID, RelationConsultingDoctor})
+		}
 	case PharmacistRole:
-		insertedPatientIDs = newUser.CustomerIDs
+		for _, customerID := range newUser.CustomerIDs {
+			invitedMembers = append(invitedMembers, invitedMember{customerID, userID, RelationPharmacist})
+		}
 	}
 
-	// Insert relations into the database
-	for _, insertedRelationID := range insertedRelationIDs {
-		_, err = db.Exec(`INSERT INTO PatientRelations (PatientID, RelationID) VALUES ($1, $2)`, userID, insertedRelationID)
-		if err != nil {
-			utils.RollbackOrLog(tx)
-			return UserDetails{}, utils.InternalServerError(err)
-		}
+	// Users created by API keys have no inviter
+	inviterID := 0
+	if actor.Type == UserActor {
+		inviterID = actor.ID
 	}
 
-	for _, insertedPatientID := range insertedPatientIDs {
-		_, err = db.Exec(`INSERT INTO PatientRelations (PatientID, RelationID) VALUES ($1, $2)`, insertedPatientID, userID)
+	invitations := []CareTeamInvitation{}
+
+	for _, member := range invitedMembers {
+		invitation, err := inviteToCareTeam(tx, member.PatientID, inviterID, NewCareTeamInvitation{
+			InviteeID:    member.InviteeID,
+			RelationType: member.RelationType,
+		})
 		if err != nil {
 			utils.RollbackOrLog(tx)
-			return UserDetails{}, utils.InternalServerError(err)
+			return UserDetails{}, err
 		}
+
+		invitations = append(invitations, invitation)
+	}
+
+	// Audit the created user
+	user, err := readUser(tx, userID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, err
+	}
+
+	err = RecordAudit(tx, actor, AuditActionCreate, AuditEntityUser, userID, user.AuditPatientID(), nil, user)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, err
 	}
 
 	// Commit SQL transaction
@@ -170,32 +258,164 @@ func CreateUser(newUser NewUser) (UserDetails, error) {
 		return UserDetails{}, utils.InternalServerError(err)
 	}
 
-	return ReadUser(int(userID))
+	for _, invitation := range invitations {
+		notifyCareTeamInvitee(invitation)
+	}
+
+	return user, nil
 }
 
-// ListUsers returns a list of all users
-func ListUsers(search map[string]string) ([]UserSummary, error) {
-	query, queryParams := usersSearchMapping.CreateQuery(`SELECT id, username, fullname, role, email, phone FROM users
-	WHERE %MAPPING_CONDITIONS%`, search)
+// ListUsers returns a page of the users matching a search. Pages are selected by either an offset or the cursor returned
+// with the previous page, and sorted by the field in the sort parameter, prefixed with '-' to sort descending. Archived
+// users are only listed when the archived parameter is "true", in which case only archived users are listed
+func ListUsers(search url.Values) (UserPage, error) {
+	// Determine the page of users to return, all users are returned when no limit is given
+	limit, offset, err := readPageParams(search, 0, MaxUserListLimit)
+	if err != nil {
+		return UserPage{}, err
+	}
+
+	cursorStr := search.Get("cursor")
+	if len(cursorStr) > 0 && offset > 0 {
+		return UserPage{}, utils.BadRequestErrorMessage("Query parameters 'cursor' and 'offset' can't be combined.")
+	}
 
-	// Read all users from the database
-	rows, err := db.Query(query, queryParams...)
+	// Determine the sort order, users with an equal sort value are sorted by ID
+	sort := search.Get("sort")
+	if len(sort) == 0 {
+		sort = "id"
+	}
+
+	sortField, direction, comparison := strings.TrimPrefix(sort, "-"), "ASC", ">"
+	if strings.HasPrefix(sort, "-") {
+		direction, comparison = "DESC", "<"
+	}
+
+	sortExpr := "CAST(ID AS TEXT)"
+	if sortField != "id" {
+		var ok bool
+		sortExpr, ok = userSortFields[sortField]
+		if !ok {
+			return UserPage{}, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of query parameter 'sort' isn't a valid sort field.", sort))
+		}
+	}
+
+	archivedCondition := "ArchivedOn IS NULL"
+	if search.Get("archived") == "true" {
+		archivedCondition = "ArchivedOn IS NOT NULL"
+	}
 
+	// Count all users matching the search
+	var page UserPage
+
+	countQuery, countParams := usersSearchMapping.CreateQuery(fmt.Sprintf(`SELECT COUNT(*) FROM Users
+	WHERE %%MAPPING_CONDITIONS%% AND %s`, archivedCondition), search)
+
+	err = db.QueryRow(countQuery, countParams...).Scan(&page.Total)
 	if err != nil {
-		return []UserSummary{}, utils.InternalServerError(err)
+		return UserPage{}, utils.InternalServerError(err)
 	}
 
+	// Select the page, continuing after the cursor if given
+	query, queryParams := usersSearchMapping.CreateQuery(fmt.Sprintf(`SELECT ID, Username, FullName, Role, Email, Phone, %s
+	FROM Users
+	WHERE %%MAPPING_CONDITIONS%% AND %s`, sortExpr, archivedCondition), search)
+
+	if len(cursorStr) > 0 {
+		cursor, err := decodeUserCursor(cursorStr)
+		if err != nil || cursor.Sort != sort {
+			return UserPage{}, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of query parameter 'cursor' isn't a valid cursor for sort '%s'.", cursorStr, sort))
+		}
+
+		if sortField == "id" {
+			query = fmt.Sprintf("%s AND ID %s $%d", query, comparison, len(queryParams)+1)
+			queryParams = append(queryParams, cursor.ID)
+		} else {
+			query = fmt.Sprintf("%s AND (%s, ID) %s ($%d, $%d)", query, sortExpr, comparison, len(queryParams)+1, len(queryParams)+2)
+			queryParams = append(queryParams, cursor.Value, cursor.ID)
+		}
+	}
+
+	if sortField == "id" {
+		query = fmt.Sprintf("%s ORDER BY ID %s", query, direction)
+	} else {
+		query = fmt.Sprintf("%s ORDER BY %s %s, ID %s", query, sortExpr, direction, direction)
+	}
+
+	// Select one user more than requested, to determine whether there is a next page
+	if limit > 0 {
+		query = fmt.Sprintf("%s LIMIT %d", query, limit+1)
+	}
+	query = fmt.Sprintf("%s OFFSET %d", query, offset)
+
+	rows, err := db.Query(query, queryParams...)
+	if err != nil {
+		return UserPage{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
 	// Iterate over all rows and store in slice
-	return readUsersFromRows(rows)
+	page.Users = []UserSummary{}
+	var user UserSummary
+	var sortValue string
+
+	for rows.Next() {
+		if limit > 0 && len(page.Users) == limit {
+			page.NextCursor, err = encodeUserCursor(userCursor{Sort: sort, Value: sortValue, ID: user.ID})
+			if err != nil {
+				return UserPage{}, utils.InternalServerError(err)
+			}
+			break
+		}
+
+		err = rows.Scan(&user.ID, &user.Username, &user.FullName, &user.Role, &user.Email, &user.Phone, &sortValue)
+		if err != nil {
+			return UserPage{}, utils.InternalServerError(err)
+		}
+
+		user.EmailMD5 = utils.HashMD5(user.Email)
+		page.Users = append(page.Users, user)
+	}
+
+	return page, nil
+}
+
+// encodeUserCursor encodes a user list cursor into an opaque string
+func encodeUserCursor(cursor userCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeUserCursor decodes a user list cursor encoded by encodeUserCursor
+func decodeUserCursor(cursorStr string) (userCursor, error) {
+	var cursor userCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(cursorStr)
+	if err != nil {
+		return cursor, err
+	}
+
+	err = json.Unmarshal(data, &cursor)
+	return cursor, err
 }
 
 // ReadUser returns a user by its ID
 func ReadUser(userID int) (UserDetails, error) {
+	return readUser(db, userID)
+}
+
+// readUser reads a user inside or outside a transaction
+func readUser(q querier, userID int) (UserDetails, error) {
 	// Read user from the database
 	var user UserDetails
+	var archivedOn *time.Time
 
-	err := db.QueryRow(`SELECT ID, Username, FullName, Role, Email, birthdate, gender, phone FROM Users
-	WHERE ID = $1`, userID).Scan(&user.ID, &user.Username, &user.FullName, &user.Role, &user.Email, &user.Birthdate, &user.Gender, &user.Phone)
+	err := q.QueryRow(`SELECT ID, Username, FullName, Role, Email, birthdate, gender, phone, ArchivedOn FROM Users
+	WHERE ID = $1`, userID).Scan(&user.ID, &user.Username, &user.FullName, &user.Role, &user.Email, &user.Birthdate, &user.Gender, &user.Phone, &archivedOn)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -206,24 +426,32 @@ func ReadUser(userID int) (UserDetails, error) {
 
 	user.EmailMD5 = utils.HashMD5(user.Email)
 
+	if archivedOn != nil {
+		user.ArchivedOn = archivedOn.Format(time.RFC3339)
+	}
+
 	// Retrieve relations
 	switch user.Role {
 	case PatientRole:
-		user.Doctors, err = ListRelations(user.ID, DoctorRole)
+		user.Doctors, err = listRelations(q, user.ID, DoctorRole)
 		if err != nil {
 			return user, err
 		}
-		user.Pharmacists, err = ListRelations(user.ID, PharmacistRole)
+		user.Pharmacists, err = listRelations(q, user.ID, PharmacistRole)
 		if err != nil {
 			return user, err
 		}
-	case DoctorRole:
-		user.Patients, err = ListRelatedPatients(user.ID)
+		user.Caregivers, err = listRelations(q, user.ID, CaregiverRole)
+		if err != nil {
+			return user, err
+		}
+	case DoctorRole, CaregiverRole:
+		user.Patients, err = listRelatedPatients(q, user.ID)
 		if err != nil {
 			return user, err
 		}
 	case PharmacistRole:
-		user.Customers, err = ListRelatedPatients(user.ID)
+		user.Customers, err = listRelatedPatients(q, user.ID)
 		if err != nil {
 			return user, err
 		}
@@ -233,146 +461,448 @@ func ReadUser(userID int) (UserDetails, error) {
 	return user, nil
 }
 
-// UpdateUser updates a user
-func UpdateUser(userID int, updatedUser UpdatedUser) (UserDetails, error) {
-	user, err := ReadUser(userID)
+// lockUser locks the row of a user until the end of a transaction and returns the user
+func lockUser(tx *sql.Tx, userID int) (UserDetails, error) {
+	_, err := tx.Exec(`SELECT ID FROM Users WHERE ID = $1 FOR UPDATE`, userID)
 	if err != nil {
-		return UserDetails{}, err
+		return UserDetails{}, utils.InternalServerError(err)
 	}
 
+	return readUser(tx, userID)
+}
+
+// UpdateUser updates a user, including their role. When the role changes, the care team memberships of the user end,
+// their pending invitations are cancelled and their sessions are revoked, as these all depend on the role. Care teams
+// are otherwise changed through invitations, see InviteToCareTeam. Roles can only be changed and staff can only edit
+// the accounts of other staff if allowStaff is set
+func UpdateUser(userID int, updatedUser UpdatedUser, allowStaff bool, actor AuditActor) (UserDetails, error) {
 	// Begin transaction
 	tx, err := db.Begin()
+	if err != nil {
+		return UserDetails{}, utils.InternalServerError(err)
+	}
+
+	// Lock the user and get their current state
+	user, err := lockUser(tx, userID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, err
+	}
+
+	if len(updatedUser.Role) == 0 {
+		updatedUser.Role = user.Role
+	}
+
+	err = validateUpdatedUser(tx, userID, updatedUser)
 	if err != nil {
 		utils.RollbackOrLog(tx)
 		return UserDetails{}, err
 	}
 
+	roleChanged := updatedUser.Role != user.Role
+
+	// Only staff managers can change roles, or edit a staff account other than their own
+	editsOwnAccount := actor.Type == UserActor && actor.ID == userID
+	if !allowStaff && (roleChanged || (IsStaffRole(user.Role) && !editsOwnAccount)) {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, utils.ForbiddenErrorMessage(fmt.Sprintf("Changing roles and editing staff accounts requires the %s permission.", UsersStaffPermission))
+	}
+
+	// Patients can't lose their role while they still have medication, unless it is ended
+	if roleChanged && user.Role == PatientRole && !updatedUser.EndMedication {
+		var doseCount, prnMedicationCount int
+
+		err = tx.QueryRow(`SELECT
+			(SELECT COUNT(*) FROM Doses WHERE UserID = $1 AND EndedOn IS NULL),
+			(SELECT COUNT(*) FROM prnmedications WHERE userid = $1 AND endedon IS NULL)`, userID).Scan(&doseCount, &prnMedicationCount)
+
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return UserDetails{}, utils.InternalServerError(err)
+		}
+
+		if doseCount > 0 || prnMedicationCount > 0 {
+			utils.RollbackOrLog(tx)
+			return UserDetails{}, utils.BadRequestErrorMessage(fmt.Sprintf("The patient still has %d doses and %d PRN medications, set 'endMedication' to end them along with the role change",
+				doseCount, prnMedicationCount))
+		}
+	}
+
 	// Update user record
-	_, err = db.Exec(`UPDATE Users
+	_, err = tx.Exec(`UPDATE Users
 	SET
 		Username = $1,
 		FullName = $2,
-		Email = $3
-	WHERE ID = $4`, updatedUser.Username, updatedUser.FullName, updatedUser.Email, userID)
+		Role = $3,
+		Email = $4,
+		Birthdate = $5,
+		Gender = $6,
+		Phone = $7
+	WHERE ID = $8`, updatedUser.Username, updatedUser.FullName, updatedUser.Role, updatedUser.Email, updatedUser.Birthdate,
+		updatedUser.Gender, updatedUser.Phone, userID)
 
 	if err != nil {
 		utils.RollbackOrLog(tx)
-		return UserDetails{}, err
+		return UserDetails{}, utils.InternalServerError(err)
 	}
 
-	// Update relations
-	if user.Role == PatientRole {
-		processedIds := []int{}
+	if roleChanged {
+		// Memberships and invitations are tied to the role, both those of the user and those of their care team if they
+		// were a patient
+		queries := []string{
+			`UPDATE PatientRelations SET EndsOn = GREATEST(CURRENT_DATE, StartsOn)
+			WHERE (PatientID = $1 OR RelationID = $1) AND (EndsOn IS NULL OR EndsOn > CURRENT_DATE)`,
+			`UPDATE CareTeamInvitations SET Status = 'cancelled', RespondedOn = NOW()
+			WHERE (PatientID = $1 OR InviteeID = $1) AND Status = 'pending'`,
+		}
 
-		// Insert all new and keep track of which relations didn't change
-		for _, updatedRelation := range append(updatedUser.Doctors, updatedUser.Pharmacists...) {
-			isNew := true
+		// The medication of a former patient is ended rather than deleted, so their history is kept
+		if user.Role == PatientRole {
+			queries = append(queries,
+				`DELETE FROM DispenserAssignments WHERE PatientID = $1`,
+				`UPDATE Doses SET EndedOn = NOW() WHERE UserID = $1 AND EndedOn IS NULL`,
+				`UPDATE prnmedications SET endedon = NOW() WHERE userid = $1 AND endedon IS NULL`,
+			)
+		}
 
-			for _, relation := range append(user.Doctors, user.Pharmacists...) {
-				if relation.ID == updatedRelation.ID {
-					processedIds = append(processedIds, updatedRelation.ID)
-					isNew = false
-				}
+		for _, query := range queries {
+			_, err = tx.Exec(query, userID)
+			if err != nil {
+				utils.RollbackOrLog(tx)
+				return UserDetails{}, utils.InternalServerError(err)
 			}
+		}
 
-			if isNew {
-				_, err := tx.Exec(`INSERT INTO PatientRelations (PatientID, RelationID) VALUES ($1, $2)`, userID, updatedRelation.ID)
-				if err != nil {
-					utils.RollbackOrLog(tx)
-					return UserDetails{}, err
-				}
-			}
+		// Sessions carry the role of the user, so they must log in again
+		err = revokeAllUserSessions(tx, userID)
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return UserDetails{}, err
 		}
+	}
 
-		// Remove all old relations
-		for _, relation := range append(user.Doctors, user.Pharmacists...) {
-			processed := false
+	// Audit the change
+	updated, err := readUser(tx, userID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, err
+	}
 
-			for _, processedID := range processedIds {
-				if processedID == relation.ID {
-					processed = true
-				}
-			}
+	err = RecordAudit(tx, actor, AuditActionUpdate, AuditEntityUser, userID, updated.AuditPatientID(), user, updated)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, err
+	}
 
-			if !processed {
-				_, err := tx.Exec(`DELETE FROM PatientRelations WHERE PatientID = $1 AND RelationID = $2`, userID, relation.ID)
-				if err != nil {
-					utils.RollbackOrLog(tx)
-					return UserDetails{}, err
-				}
-			}
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, utils.InternalServerError(err)
+	}
+
+	if roleChanged {
+		closeUserDispatcherClients(userID, 0)
+	}
+
+	return updated, nil
+}
+
+// validateUpdatedUser validates the fields of an updated user
+func validateUpdatedUser(q querier, userID int, updatedUser UpdatedUser) error {
+	errors := validate.ProfileErrors(validate.Profile{
+		Username:  updatedUser.Username,
+		FullName:  updatedUser.FullName,
+		Email:     updatedUser.Email,
+		Birthdate: updatedUser.Birthdate,
+		Gender:    updatedUser.Gender,
+	})
+
+	if len(errors) > 0 {
+		return utils.BadRequestErrorMessage(strings.Join(errors, ", "))
+	}
+
+	// Dispensers authenticate with their own credentials and can't be users
+	if updatedUser.Role == DispenserRole {
+		return utils.BadRequestErrorMessage("Users can't get the dispenser role")
+	}
+
+	exists, err := RoleExists(updatedUser.Role)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return utils.BadRequestErrorMessage(fmt.Sprintf("Role '%s' does not exist", updatedUser.Role))
+	}
+
+	// Archived users keep their username
+	var taken bool
+
+	err = q.QueryRow(`SELECT EXISTS(SELECT 1 FROM Users WHERE Username = $1 AND ID <> $2)`, updatedUser.Username, userID).Scan(&taken)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+	if taken {
+		return utils.BadRequestErrorMessage(fmt.Sprintf("Username '%s' is already taken", updatedUser.Username))
+	}
+
+	return nil
+}
+
+// UpdateContactDetails updates the e-mail address and phone number of a user, the current password of the user is verified
+// when their e-mail address changes
+func UpdateContactDetails(userID int, contactDetails ContactDetails, actor AuditActor) (UserDetails, error) {
+	contactDetails.Email = strings.TrimSpace(contactDetails.Email)
+	contactDetails.Phone = strings.TrimSpace(contactDetails.Phone)
+
+	if len(contactDetails.Email) == 0 || !strings.Contains(contactDetails.Email, "@") {
+		return UserDetails{}, utils.BadRequestErrorMessage(fmt.Sprintf("'%s' isn't a valid e-mail address", contactDetails.Email))
+	}
+
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return UserDetails{}, utils.InternalServerError(err)
+	}
+
+	// Lock the user and get their current state
+	user, err := lockUser(tx, userID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, err
+	}
+
+	if len(user.ArchivedOn) > 0 {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, utils.NotFoundErrorMessage(fmt.Sprintf("No user with ID %d found", userID))
+	}
+
+	// Check the current password, as a stolen session could otherwise take over the account through a password reset
+	if contactDetails.Email != user.Email {
+		var passwordHash string
+
+		err = tx.QueryRow(`SELECT PasswordHash FROM Users WHERE ID = $1`, userID).Scan(&passwordHash)
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return UserDetails{}, utils.InternalServerError(err)
 		}
-	} else if user.Role == DoctorRole || user.Role == PharmacistRole {
-		processedIds := []int{}
-
-		// Insert all new and keep track of which relations didn't change
-		for _, updatedPatient := range append(updatedUser.Patients, updatedUser.Customers...) {
-			isNew := true
-
-			for _, patient := range append(user.Patients, user.Customers...) {
-				if patient.ID == updatedPatient.ID {
-					processedIds = append(processedIds, updatedPatient.ID)
-					isNew = false
-				}
-			}
 
-			if isNew {
-				_, err := tx.Exec(`INSERT INTO PatientRelations (PatientID, RelationID) VALUES ($1, $2)`, updatedPatient.ID, userID)
-				if err != nil {
-					utils.RollbackOrLog(tx)
-					return UserDetails{}, err
-				}
-			}
+		_, err = passlib.Verify(contactDetails.CurrentPassword, passwordHash)
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return UserDetails{}, utils.BadRequestErrorMessage("The current password is incorrect")
 		}
+	}
 
-		// Remove all old relations
-		for _, patient := range append(user.Patients, user.Customers...) {
-			processed := false
+	_, err = tx.Exec(`UPDATE Users
+	SET Email = $1, Phone = $2
+	WHERE ID = $3`, contactDetails.Email, contactDetails.Phone, userID)
 
-			for _, processedID := range processedIds {
-				if processedID == patient.ID {
-					processed = true
-				}
-			}
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, utils.InternalServerError(err)
+	}
 
-			if !processed {
-				_, err := tx.Exec(`DELETE FROM PatientRelations WHERE PatientID = $1 AND RelationID = $2`, patient.ID, userID)
-				if err != nil {
-					utils.RollbackOrLog(tx)
-					return UserDetails{}, err
-				}
-			}
-		}
+	// Audit the change
+	updated, err := readUser(tx, userID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, err
 	}
 
-	// Commit the transaction and return
+	err = RecordAudit(tx, actor, AuditActionUpdate, AuditEntityUser, userID, updated.AuditPatientID(), user, updated)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, err
+	}
+
+	// Commit the transaction
 	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, utils.InternalServerError(err)
+	}
+
+	return updated, nil
+}
+
+// ArchiveUser archives a user, after which they can no longer log in and are no longer listed. Their doses and history
+// are kept, and their sessions are revoked
+func ArchiveUser(userID, adminID int, actor AuditActor) error {
+	if userID == adminID {
+		return utils.BadRequestErrorMessage("Users can't archive themselves")
+	}
+
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
 
+	// Lock the user and get their current state
+	user, err := lockUser(tx, userID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
+	if len(user.ArchivedOn) > 0 {
+		utils.RollbackOrLog(tx)
+		return utils.NotFoundErrorMessage(fmt.Sprintf("No active user with ID %d found", userID))
+	}
+
+	_, err = tx.Exec(`UPDATE Users
+	SET ArchivedOn = NOW(), ArchivedBy = $1
+	WHERE ID = $2`, adminID, userID)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	// Archived users can no longer log in, so their sessions are revoked along with the archival
+	err = revokeAllUserSessions(tx, userID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
+	err = RecordAudit(tx, actor, AuditActionArchive, AuditEntityUser, userID, user.AuditPatientID(), user, nil)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	closeUserDispatcherClients(userID, 0)
+
+	return nil
+}
+
+// RestoreUser restores an archived user
+func RestoreUser(userID int, actor AuditActor) (UserDetails, error) {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return UserDetails{}, utils.InternalServerError(err)
+	}
+
+	result, err := tx.Exec(`UPDATE Users
+	SET ArchivedOn = NULL, ArchivedBy = NULL
+	WHERE ID = $1 AND ArchivedOn IS NOT NULL`, userID)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, utils.InternalServerError(err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, utils.InternalServerError(err)
+	} else if n == 0 {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, utils.NotFoundErrorMessage(fmt.Sprintf("No archived user with ID %d found", userID))
+	}
+
+	// Audit the restored user
+	user, err := readUser(tx, userID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, err
+	}
+
+	err = RecordAudit(tx, actor, AuditActionRestore, AuditEntityUser, userID, user.AuditPatientID(), nil, user)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return UserDetails{}, err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
 	if err != nil {
 		utils.RollbackOrLog(tx)
 		return UserDetails{}, utils.InternalServerError(err)
 	}
 
-	return ReadUser(userID)
+	return user, nil
 }
 
-// DeleteUser deletes a user
-func DeleteUser(userID int) error {
-	_, err := db.Exec(`DELETE FROM Users WHERE ID = $1`, userID)
+// PurgeUser permanently deletes an archived user, along with their doses, PRN medications, history and relations
+func PurgeUser(userID int, actor AuditActor) error {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
 
+	// Lock the user and get their current state, so they can't be restored while they are purged
+	user, err := lockUser(tx, userID)
 	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
+	// Only archived users can be purged
+	if len(user.ArchivedOn) == 0 {
+		utils.RollbackOrLog(tx)
+		return utils.BadRequestErrorMessage("Users must be archived before they can be purged")
+	}
+
+	// Delete everything that refers to the user, before deleting the user itself. Purging is the only way in which the
+	// medication history of a patient is removed
+	queries := []string{
+		`DELETE FROM DoseHistory WHERE DoseID IN (SELECT ID FROM Doses WHERE UserID = $1)`,
+		`DELETE FROM DoseMedications WHERE DoseID IN (SELECT ID FROM Doses WHERE UserID = $1)`,
+		`DELETE FROM Doses WHERE UserID = $1`,
+		`DELETE FROM prnhistory WHERE prnmedicationid IN (SELECT id FROM prnmedications WHERE userid = $1)`,
+		`DELETE FROM prnmedications WHERE userid = $1`,
+		`DELETE FROM PatientRelations WHERE PatientID = $1 OR RelationID = $1`,
+		`DELETE FROM DispenserAssignments WHERE PatientID = $1`,
+		`UPDATE Lockouts SET UnlockedBy = NULL WHERE UnlockedBy = $1`,
+		`DELETE FROM Users WHERE ID = $1`,
+	}
+
+	for _, query := range queries {
+		_, err = tx.Exec(query, userID)
+		if err != nil {
+			utils.RollbackOrLog(tx)
+			return utils.InternalServerError(err)
+		}
+	}
+
+	err = RecordAudit(tx, actor, AuditActionDelete, AuditEntityUser, userID, user.AuditPatientID(), user, nil)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
 		return utils.InternalServerError(err)
 	}
 
 	return nil
 }
 
-// ListRelatedPatients returns a list of all patients related to a user
+// ListRelatedPatients returns a list of all patients whose care team a user currently is a member of
 func ListRelatedPatients(userID int) ([]UserSummary, error) {
+	return listRelatedPatients(db, userID)
+}
+
+// listRelatedPatients reads the patients of a care team member inside or outside a transaction
+func listRelatedPatients(q querier, userID int) ([]UserSummary, error) {
 	// Read patients from database
-	rows, err := db.Query(`SELECT U.ID, U.Username, U.FullName, U.Role, U.Email, U.phone FROM PatientRelations PR
+	rows, err := q.Query(fmt.Sprintf(`SELECT U.ID, U.Username, U.FullName, U.Role, U.Email, U.phone FROM PatientRelations PR
 	LEFT JOIN Users U ON PR.PatientID = U.ID
-	WHERE PR.RelationID = $1`, userID)
+	WHERE PR.RelationID = $1 AND U.ArchivedOn IS NULL AND %s`, activeMembershipCondition), userID)
 
 	if err != nil {
 		return []UserSummary{}, utils.InternalServerError(err)
@@ -381,12 +911,31 @@ func ListRelatedPatients(userID int) ([]UserSummary, error) {
 	return readUsersFromRows(rows)
 }
 
-// ListRelations returns a list of all relations of a patient
+// IsRelatedToPatient returns whether a user currently is a member of the care team of a patient
+func IsRelatedToPatient(userID, patientID int) (bool, error) {
+	var related bool
+
+	err := db.QueryRow(fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM PatientRelations PR
+	WHERE PR.PatientID = $1 AND PR.RelationID = $2 AND %s)`, activeMembershipCondition), patientID, userID).Scan(&related)
+
+	if err != nil {
+		return false, utils.InternalServerError(err)
+	}
+
+	return related, nil
+}
+
+// ListRelations returns a list of all current members of the care team of a patient with a role
 func ListRelations(userID int, role string) ([]UserSummary, error) {
+	return listRelations(db, userID, role)
+}
+
+// listRelations reads the care team members of a patient with a role inside or outside a transaction
+func listRelations(q querier, userID int, role string) ([]UserSummary, error) {
 	// Read patients from database
-	rows, err := db.Query(`SELECT U.ID, U.Username, U.FullName, U.Role, U.Email, U.phone FROM PatientRelations PR
+	rows, err := q.Query(fmt.Sprintf(`SELECT U.ID, U.Username, U.FullName, U.Role, U.Email, U.phone FROM PatientRelations PR
 	LEFT JOIN Users U ON PR.RelationID = U.ID
-	WHERE PR.PatientID = $1 AND U.Role = $2`, userID, role)
+	WHERE PR.PatientID = $1 AND U.Role = $2 AND U.ArchivedOn IS NULL AND %s`, activeMembershipCondition), userID, role)
 
 	if err != nil {
 		return []UserSummary{}, utils.InternalServerError(err)
@@ -397,6 +946,8 @@ func ListRelations(userID int, role string) ([]UserSummary, error) {
 
 // readUsersFromRows is a helper function to easily read a *sql.Rows of users into a slice
 func readUsersFromRows(rows *sql.Rows) ([]UserSummary, error) {
+	defer rows.Close()
+
 	users := []UserSummary{}
 	var user UserSummary
 
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"main/utils"
+	"net/http"
+	"strconv"
+)
+
+// readCurrentUserSession reads the session of the current request, which must belong to a user
+func readCurrentUserSession(r *http.Request) (Session, error) {
+	session, err := ReadJWTSession(r)
+	if err != nil {
+		return Session{}, err
+	}
+
+	if session.ActorType != UserActor {
+		return Session{}, utils.ForbiddenErrorMessage("Only users have sessions")
+	}
+
+	return session, nil
+}
+
+// HandleListOwnSessions returns the active sessions of the current user to the client
+func HandleListOwnSessions(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := readCurrentUserSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read the sessions from the database and write to the client
+	sessions, err := ListUserSessions(session.UserID, session.SessionID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, sessions)
+}
+
+// HandleRevokeOwnSession handles the revocation of a session of the current user
+func HandleRevokeOwnSession(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := readCurrentUserSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Read session ID from URL
+	vars := mux.Vars(r)
+
+	sessionID, err := strconv.Atoi(vars["sessionId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'sessionId' isn't a valid integer.", vars["sessionId"])))
+		return
+	}
+
+	// Revoke the session and respond
+	err = RevokeUserSession(session.UserID, sessionID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleRevokeOwnSessions handles the revocation of all sessions of the current user
+func HandleRevokeOwnSessions(w http.ResponseWriter, r *http.Request) {
+	// Read session from request
+	session, err := readCurrentUserSession(r)
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	// Revoke the sessions and respond
+	err = RevokeAllUserSessions(session.UserID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleListUserSessions returns the active sessions of a user to the client
+func HandleListUserSessions(w http.ResponseWriter, r *http.Request) {
+	// Read user ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	// Read the sessions from the database and write to the client
+	sessions, err := ListUserSessions(userID, 0)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	utils.WriteJSON(w, sessions)
+}
+
+// HandleRevokeUserSession handles the revocation of a session of a user
+func HandleRevokeUserSession(w http.ResponseWriter, r *http.Request) {
+	// Read user ID and session ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	sessionID, err := strconv.Atoi(vars["sessionId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'sessionId' isn't a valid integer.", vars["sessionId"])))
+		return
+	}
+
+	// Revoke the session and respond
+	err = RevokeUserSession(userID, sessionID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleRevokeUserSessions handles the revocation of all sessions of a user
+func HandleRevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	// Read user ID from URL
+	vars := mux.Vars(r)
+
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.WriteError(w, utils.BadRequestErrorMessage(fmt.Sprintf("Value '%s' of URL parameter 'userId' isn't a valid integer.", vars["userId"])))
+		return
+	}
+
+	// Revoke the sessions and respond
+	err = RevokeAllUserSessions(userID)
+
+	if err != nil {
+		utils.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"main/dispatch"
+	"main/utils"
+	"time"
+)
+
+type (
+	// UserSession contains information on a session of a user, which is started when the user logs in
+	UserSession struct {
+		ID         int    `json:"id"`
+		Device     string `json:"device"`
+		IPAddress  string `json:"ipAddress"`
+		CreatedOn  string `json:"createdOn"`
+		LastUsedOn string `json:"lastUsedOn"`
+		Current    bool   `json:"current"`
+	}
+)
+
+const (
+	MaxDeviceLength = 255
+	unknownDevice   = "Unknown device"
+
+	// LastUsedUpdateInterval is the minimum time between two updates of the last used time of a session
+	LastUsedUpdateInterval = time.Minute
+)
+
+// StartUserSession records a new session for a user and returns the session token for it
+func StartUserSession(session Session, ipAddress, device string) (SessionToken, error) {
+	if len(device) == 0 {
+		device = unknownDevice
+	} else if len(device) > MaxDeviceLength {
+		device = device[:MaxDeviceLength]
+	}
+
+	err := db.QueryRow(`INSERT INTO UserSessions (UserID, Device, IPAddress)
+	VALUES ($1, $2, $3) RETURNING ID`, session.UserID, device, ipAddress).Scan(&session.SessionID)
+
+	if err != nil {
+		return SessionToken{}, utils.InternalServerError(err)
+	}
+
+	return CreateJWT(session)
+}
+
+// refreshUserSession updates the last used time and IP address of a session when its access token is refreshed
+func refreshUserSession(sessionID int, ipAddress string) error {
+	_, err := db.Exec(`UPDATE UserSessions
+	SET LastUsedOn = NOW(), IPAddress = $1
+	WHERE ID = $2`, ipAddress, sessionID)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// touchUserSession updates the last used time of a session, and returns an error if it has been revoked. The last used time
+// is only written when it is out of date
+func touchUserSession(sessionID int) error {
+	var active, outdated bool
+
+	err := db.QueryRow(`SELECT RevokedOn IS NULL, LastUsedOn < NOW() - $2 * INTERVAL '1 second'
+	FROM UserSessions
+	WHERE ID = $1`, sessionID, LastUsedUpdateInterval.Seconds()).Scan(&active, &outdated)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return utils.UnauthorizedErrorMessage("Session has been revoked")
+		}
+		return utils.InternalServerError(err)
+	}
+
+	if !active {
+		return utils.UnauthorizedErrorMessage("Session has been revoked")
+	}
+
+	if !outdated {
+		return nil
+	}
+
+	_, err = db.Exec(`UPDATE UserSessions SET LastUsedOn = NOW() WHERE ID = $1`, sessionID)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// ListUserSessions returns the active sessions of a user, the session with the current session ID is marked as current
+func ListUserSessions(userID, currentSessionID int) ([]UserSession, error) {
+	// Sessions that haven't been used for longer than a refresh token is valid can't be continued
+	rows, err := db.Query(`SELECT ID, Device, IPAddress, CreatedOn, LastUsedOn
+	FROM UserSessions
+	WHERE UserID = $1 AND RevokedOn IS NULL AND LastUsedOn > NOW() - $2::INTEGER * INTERVAL '1 hour'
+	ORDER BY LastUsedOn DESC`, userID, config.JWT.RefreshTokenLifetime)
+
+	if err != nil {
+		return []UserSession{}, utils.InternalServerError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over all rows and store in slice
+	sessions := []UserSession{}
+	var session UserSession
+	var createdOn, lastUsedOn time.Time
+
+	for rows.Next() {
+		err = rows.Scan(&session.ID, &session.Device, &session.IPAddress, &createdOn, &lastUsedOn)
+		if err != nil {
+			return []UserSession{}, utils.InternalServerError(err)
+		}
+
+		session.CreatedOn = createdOn.Format(time.RFC3339)
+		session.LastUsedOn = lastUsedOn.Format(time.RFC3339)
+		session.Current = session.ID == currentSessionID
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// RevokeUserSession revokes a session of a user and its refresh tokens, and disconnects its dispatcher clients
+func RevokeUserSession(userID, sessionID int) error {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	result, err := tx.Exec(`UPDATE UserSessions
+	SET RevokedOn = NOW()
+	WHERE ID = $1 AND UserID = $2 AND RevokedOn IS NULL`, sessionID, userID)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	} else if n == 0 {
+		utils.RollbackOrLog(tx)
+		return utils.NotFoundErrorMessage(fmt.Sprintf("No active session with ID %d found for user %d", sessionID, userID))
+	}
+
+	_, err = tx.Exec(`UPDATE RefreshTokens
+	SET Revoked = TRUE
+	WHERE SessionID = $1 AND NOT Revoked`, sessionID)
+
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	closeUserDispatcherClients(userID, sessionID)
+
+	return nil
+}
+
+// RevokeAllUserSessions revokes all sessions of a user and their refresh tokens, and disconnects their dispatcher clients
+func RevokeAllUserSessions(userID int) error {
+	// Begin transaction
+	tx, err := db.Begin()
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	err = revokeAllUserSessions(tx, userID)
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		utils.RollbackOrLog(tx)
+		return utils.InternalServerError(err)
+	}
+
+	closeUserDispatcherClients(userID, 0)
+
+	return nil
+}
+
+// revokeAllUserSessions revokes all sessions of a user and their refresh tokens inside a transaction. The dispatcher
+// clients of the user must be disconnected after the transaction is committed
+func revokeAllUserSessions(tx *sql.Tx, userID int) error {
+	_, err := tx.Exec(`UPDATE UserSessions
+	SET RevokedOn = NOW()
+	WHERE UserID = $1 AND RevokedOn IS NULL`, userID)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	_, err = tx.Exec(`UPDATE RefreshTokens
+	SET Revoked = TRUE
+	WHERE SubjectType = $1 AND SubjectID = $2 AND NOT Revoked`, UserTokenSubject, userID)
+
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	return nil
+}
+
+// closeUserDispatcherClients disconnects the dispatcher clients of a session of a user, or of all their sessions if the
+// session ID is 0
+func closeUserDispatcherClients(userID, sessionID int) {
+	dispatcher.CloseClients(func(principal dispatch.Principal) bool {
+		session, ok := principal.(Session)
+
+		return ok && session.ActorType == UserActor && session.UserID == userID &&
+			(sessionID == 0 || session.SessionID == sessionID)
+	}, "The session has been revoked")
+}
@@ -56,6 +56,22 @@ func UnauthorizedErrorMessage(msg string) *HttpError {
 	}
 }
 
+// ForbiddenErrorMessage returns a HTTP 403 error with the given error message
+func ForbiddenErrorMessage(msg string) *HttpError {
+	return &HttpError{
+		Message:    msg,
+		StatusCode: http.StatusForbidden,
+	}
+}
+
+// TooManyRequestsErrorMessage returns a HTTP 429 error with the given error message
+func TooManyRequestsErrorMessage(msg string) *HttpError {
+	return &HttpError{
+		Message:    msg,
+		StatusCode: http.StatusTooManyRequests,
+	}
+}
+
 // InternalServerError returns a HTTP 500 error with the given error message
 func InternalServerErrorMessage(msg string) *HttpError {
 	return &HttpError{
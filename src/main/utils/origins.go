@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type (
+	// OriginPolicy decides which cross-origin web applications may access the API
+	OriginPolicy struct {
+		patterns []string
+	}
+)
+
+// NewOriginPolicy creates an origin policy from a comma-separated list of allowed origins. An origin may contain a single
+// '*' wildcard, e.g. "https://*.example.com", and "*" allows every origin
+func NewOriginPolicy(allowedOrigins string) *OriginPolicy {
+	patterns := []string{}
+
+	for _, origin := range strings.Split(allowedOrigins, ",") {
+		origin = strings.ToLower(strings.TrimRight(strings.TrimSpace(origin), "/"))
+		if len(origin) > 0 {
+			patterns = append(patterns, origin)
+		}
+	}
+
+	return &OriginPolicy{patterns: patterns}
+}
+
+// IsAllowed returns whether an origin matches one of the allowed origins
+func (op *OriginPolicy) IsAllowed(origin string) bool {
+	origin = strings.ToLower(origin)
+
+	for _, pattern := range op.patterns {
+		if matchOrigin(pattern, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsAllowedRequest returns whether the origin of a request is allowed. Requests without an Origin header don't come from a
+// browser, and requests from the same host are never cross-origin, so both are always allowed
+func (op *OriginPolicy) IsAllowedRequest(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if len(origin) == 0 {
+		return true
+	}
+
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+
+	return op.IsAllowed(origin)
+}
+
+// matchOrigin matches an origin against a pattern with at most one wildcard, which can't match across a '/'
+func matchOrigin(pattern, origin string) bool {
+	if pattern == "*" {
+		return true
+	}
+
+	index := strings.Index(pattern, "*")
+	if index < 0 {
+		return pattern == origin
+	}
+
+	prefix, suffix := pattern[:index], pattern[index+1:]
+	if len(origin) <= len(prefix)+len(suffix) || !strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+		return false
+	}
+
+	return !strings.ContainsAny(origin[len(prefix):len(origin)-len(suffix)], "/:")
+}
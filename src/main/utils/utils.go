@@ -2,11 +2,15 @@ package utils
 
 import (
 	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
 	"log"
+	"net"
 	"net/http"
+	"strings"
 )
 
 type (
@@ -28,12 +32,51 @@ func HashMD5(s string) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
+// HashSHA256 returns the SHA-256 hash of a string
+func HashSHA256(s string) string {
+	h := sha256.New()
+	h.Write([]byte(s))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// RandomToken returns a hex encoded cryptographically secure random string of n bytes
+func RandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
 // ReadJSONFromRequest unmarshals a JSON request body
 func ReadJSONFromRequest(r *http.Request, target interface{}) error {
 	reader := json.NewDecoder(r.Body)
 	return reader.Decode(target)
 }
 
+// ClientIP returns the IP address of the client that sent a request, optionally trusting the X-Forwarded-For header.
+// Only the rightmost entry of the header is used, as it was appended by the proxy in front of the server. The entries
+// before it are sent by the client, which can put anything in them
+func ClientIP(r *http.Request, trustProxyHeaders bool) string {
+	if trustProxyHeaders {
+		if headers := r.Header["X-Forwarded-For"]; len(headers) > 0 {
+			entries := strings.Split(headers[len(headers)-1], ",")
+			if forwardedFor := strings.TrimSpace(entries[len(entries)-1]); len(forwardedFor) > 0 {
+				return forwardedFor
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
 // WriteJSON writes a JSON value to a HTTP response
 func WriteJSON(w http.ResponseWriter, response interface{}) {
 	data, err := json.Marshal(response)
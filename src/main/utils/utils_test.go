@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	cases := []struct {
+		name              string
+		remoteAddr        string
+		forwardedFor      []string
+		trustProxyHeaders bool
+		expected          string
+	}{
+		{"remote address", "10.0.0.1:5123", nil, false, "10.0.0.1"},
+		{"remote address without port", "10.0.0.1", nil, false, "10.0.0.1"},
+		{"untrusted header", "10.0.0.1:5123", []string{"203.0.113.7"}, false, "10.0.0.1"},
+		{"trusted header", "10.0.0.1:5123", []string{"203.0.113.7"}, true, "203.0.113.7"},
+		{"spoofed entries", "10.0.0.1:5123", []string{"198.51.100.1, 203.0.113.7"}, true, "203.0.113.7"},
+		{"spoofed header", "10.0.0.1:5123", []string{"198.51.100.1", "203.0.113.7"}, true, "203.0.113.7"},
+		{"whitespace", "10.0.0.1:5123", []string{" 198.51.100.1 ,  203.0.113.7 "}, true, "203.0.113.7"},
+		{"empty last entry", "10.0.0.1:5123", []string{"198.51.100.1,"}, true, "10.0.0.1"},
+		{"missing header", "10.0.0.1:5123", nil, true, "10.0.0.1"},
+	}
+
+	for _, c := range cases {
+		r := &http.Request{RemoteAddr: c.remoteAddr, Header: http.Header{}}
+		for _, value := range c.forwardedFor {
+			r.Header.Add("X-Forwarded-For", value)
+		}
+
+		if ip := ClientIP(r, c.trustProxyHeaders); ip != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.name, c.expected, ip)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type (
+	// Profile contains the profile fields of a user that don't depend on the database
+	Profile struct {
+		Username  string
+		FullName  string
+		Email     string
+		Birthdate string
+		Gender    string
+	}
+)
+
+const (
+	// DateFormat is the format of birthdates
+	DateFormat = "2006-01-02"
+)
+
+// ProfileErrors returns the messages of all invalid fields of a user profile, the slice is empty if the profile is valid
+func ProfileErrors(profile Profile) []string {
+	errors := []string{}
+
+	// Check the required fields
+	if len(strings.TrimSpace(profile.Username)) == 0 {
+		errors = append(errors, "Username is required")
+	}
+	if len(strings.TrimSpace(profile.FullName)) == 0 {
+		errors = append(errors, "Full name is required")
+	}
+	if !IsEmail(profile.Email) {
+		errors = append(errors, fmt.Sprintf("'%s' isn't a valid e-mail address", profile.Email))
+	}
+
+	// Check the optional fields
+	if len(profile.Birthdate) > 0 {
+		if _, err := time.Parse(DateFormat, profile.Birthdate); err != nil {
+			errors = append(errors, fmt.Sprintf("Birthdate '%s' isn't a valid date, expected format YYYY-MM-DD", profile.Birthdate))
+		}
+	}
+
+	if profile.Gender != "" && profile.Gender != "male" && profile.Gender != "female" {
+		errors = append(errors, fmt.Sprintf("Gender '%s' must be 'male', 'female' or empty", profile.Gender))
+	}
+
+	return errors
+}
+
+// IsEmail returns whether a string looks like an e-mail address. Addresses are only truly verified by sending mail to them
+func IsEmail(email string) bool {
+	at := strings.Index(email, "@")
+	return at > 0 && at < len(email)-1 && strings.TrimSpace(email) == email
+}
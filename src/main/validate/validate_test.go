@@ -0,0 +1,60 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProfileErrors(t *testing.T) {
+	valid := Profile{Username: "jdoe", FullName: "John Doe", Email: "john@example.com", Birthdate: "1950-03-01", Gender: "male"}
+
+	cases := []struct {
+		name    string
+		profile func(p Profile) Profile
+		errors  []string
+	}{
+		{"valid", func(p Profile) Profile { return p }, []string{}},
+		{"optional fields empty", func(p Profile) Profile { p.Birthdate, p.Gender = "", ""; return p }, []string{}},
+		{"female", func(p Profile) Profile { p.Gender = "female"; return p }, []string{}},
+		{"blank username", func(p Profile) Profile { p.Username = "  "; return p }, []string{"Username is required"}},
+		{"blank full name", func(p Profile) Profile { p.FullName = ""; return p }, []string{"Full name is required"}},
+		{"e-mail without @", func(p Profile) Profile { p.Email = "john"; return p },
+			[]string{"'john' isn't a valid e-mail address"}},
+		{"invalid birthdate", func(p Profile) Profile { p.Birthdate = "01-03-1950"; return p },
+			[]string{"Birthdate '01-03-1950' isn't a valid date, expected format YYYY-MM-DD"}},
+		{"nonexistent birthdate", func(p Profile) Profile { p.Birthdate = "1950-02-30"; return p },
+			[]string{"Birthdate '1950-02-30' isn't a valid date, expected format YYYY-MM-DD"}},
+		{"invalid gender", func(p Profile) Profile { p.Gender = "M"; return p },
+			[]string{"Gender 'M' must be 'male', 'female' or empty"}},
+		{"multiple errors", func(p Profile) Profile { p.Username, p.Email = "", ""; return p },
+			[]string{"Username is required", "'' isn't a valid e-mail address"}},
+	}
+
+	for _, c := range cases {
+		errors := ProfileErrors(c.profile(valid))
+		if !reflect.DeepEqual(errors, c.errors) {
+			t.Errorf("%s: expected errors %v, got %v", c.name, c.errors, errors)
+		}
+	}
+}
+
+func TestIsEmail(t *testing.T) {
+	cases := []struct {
+		email string
+		valid bool
+	}{
+		{"john@example.com", true},
+		{"j@e", true},
+		{"", false},
+		{"john", false},
+		{"@example.com", false},
+		{"john@", false},
+		{" john@example.com", false},
+	}
+
+	for _, c := range cases {
+		if valid := IsEmail(c.email); valid != c.valid {
+			t.Errorf("%q: expected %t, got %t", c.email, c.valid, valid)
+		}
+	}
+}
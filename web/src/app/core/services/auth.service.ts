@@ -22,12 +22,20 @@ export function emptyCredentials(): Credentials {
 }
 
 /**
- * Response that is returned from an authentication request
+ * Response that is returned from an authentication or refresh request
  */
 interface TokenResponse {
   token: string;
+  refreshToken: string;
+  expiresAt: number;
 }
 
+/**
+ * Number of seconds before the access token expires at which it is refreshed
+ * @type {number}
+ */
+const refreshMargin = 60;
+
 /**
  * Contains session information
  */
@@ -44,6 +52,12 @@ export interface Session {
  */
 @Injectable()
 export class AuthService {
+  /**
+   * Refresh request that is currently in progress, if any
+   * @type {Promise<void>}
+   */
+  private refreshing: Promise<void> = null;
+
   constructor(private http: Http) { }
 
   /**
@@ -54,9 +68,7 @@ export class AuthService {
   async authenticate(credentials: Credentials): Promise<void> {
     try {
       const response = await this.http.post("/api/authenticate", credentials).toPromise();
-      const json: TokenResponse = response.json();
-
-      window.sessionStorage.setItem("jwt", json.token);
+      this.storeTokens(response.json());
     } catch(e) {
       if(e instanceof Response) {
         throw new Error(e.json().message);
@@ -65,6 +77,66 @@ export class AuthService {
     }
   }
 
+  /**
+   * Refreshes the access token when it is about to expire. Concurrent callers share a single refresh request
+   * @returns {Promise<void>} - Promise that resolves once the access token is valid
+   */
+  async ensureFreshToken(): Promise<void> {
+    const refreshToken = sessionStorage.getItem("refreshToken");
+    const expiresAt = parseInt(sessionStorage.getItem("expiresAt"), 10);
+
+    if(!refreshToken || isNaN(expiresAt) || expiresAt - Date.now() / 1000 > refreshMargin) {
+      return;
+    }
+
+    if(!this.refreshing) {
+      this.refreshing = this.refresh(refreshToken).then(() => {
+        this.refreshing = null;
+      }, e => {
+        this.refreshing = null;
+        throw e;
+      });
+    }
+
+    await this.refreshing;
+  }
+
+  /**
+   * Exchanges a refresh token for a new access token. The session is cleared when the refresh token is rejected
+   * @param refreshToken - The refresh token to exchange
+   * @returns {Promise<void>} - Promise that resolves once the new tokens are stored
+   */
+  private async refresh(refreshToken: string): Promise<void> {
+    try {
+      const response = await this.http.post("/api/authenticate/refresh", {refreshToken}).toPromise();
+      this.storeTokens(response.json());
+    } catch(e) {
+      if(e instanceof Response && e.status == 401) {
+        this.clearTokens();
+      }
+      throw e;
+    }
+  }
+
+  /**
+   * Stores the tokens of a new session
+   * @param tokens - The tokens returned from an authentication or refresh request
+   */
+  private storeTokens(tokens: TokenResponse) {
+    window.sessionStorage.setItem("jwt", tokens.token);
+    window.sessionStorage.setItem("refreshToken", tokens.refreshToken);
+    window.sessionStorage.setItem("expiresAt", tokens.expiresAt.toString());
+  }
+
+  /**
+   * Removes the tokens of the current session
+   */
+  private clearTokens() {
+    window.sessionStorage.removeItem("jwt");
+    window.sessionStorage.removeItem("refreshToken");
+    window.sessionStorage.removeItem("expiresAt");
+  }
+
   /**
    * Returns the current session
    */
@@ -1,6 +1,8 @@
 import { Injectable } from '@angular/core';
 import { Http, Headers, Response, RequestOptions, ResponseContentType, URLSearchParams } from '@angular/http';
 
+import { AuthService } from './auth.service';
+
 /**
  * Returns whether a value with possible types URLSearchParams or {[key: string]: any} is of type URLSearchParams
  * @param s - The value to determine the type of
@@ -24,7 +26,7 @@ export interface BaseRequestOptions {
  */
 @Injectable()
 export class AuthHttp {
-  constructor(private http: Http) { }
+  constructor(private http: Http, private authService: AuthService) { }
 
   /**
    * Executes a POST request to a JSON API endpoint
@@ -34,6 +36,7 @@ export class AuthHttp {
    * @returns {Promise<T>} The parsed JSON returned from the API
    */
   async postJSON<T>(url: string, data: any, options?: BaseRequestOptions): Promise<T> {
+    await this.authService.ensureFreshToken();
     const response = await this.http.post(url, data, this.createRequestOptions(options || {})).toPromise();
     return response.json() as T;
   }
@@ -45,6 +48,7 @@ export class AuthHttp {
    * @returns {Promise<T>} The parsed JSON returned from the API
    */
   async getJSON<T>(url: string, options?: BaseRequestOptions): Promise<T> {
+    await this.authService.ensureFreshToken();
     const response = await this.http.get(url, this.createRequestOptions(options || {})).toPromise();
     return response.json() as T;
   }
@@ -57,6 +61,7 @@ export class AuthHttp {
    * @returns {Promise<T>} The parsed JSON returned from the API
    */
   async putJSON<T>(url: string, data: any, options?: BaseRequestOptions): Promise<T> {
+    await this.authService.ensureFreshToken();
     const response = await this.http.put(url, data, this.createRequestOptions(options || {})).toPromise();
     return response.json() as T;
   }
@@ -68,6 +73,7 @@ export class AuthHttp {
    * @returns {Promise<void>} Promise that resolves on completion
    */
   async delete(url: string, options?: BaseRequestOptions): Promise<void> {
+    await this.authService.ensureFreshToken();
     await this.http.delete(url, this.createRequestOptions(options || {})).toPromise();
   }
 
@@ -89,7 +95,11 @@ export class AuthHttp {
       } else {
         const sp = new URLSearchParams();
         for(let param in options.searchParams) {
-          sp.append(param, options.searchParams[param]);
+          // Array values are sent as a repeated parameter
+          const values = options.searchParams[param];
+          for(let value of Array.isArray(values) ? values : [values]) {
+            sp.append(param, value);
+          }
         }
         opts.search = sp;
       }
@@ -1,6 +1,7 @@
 import { Injectable } from '@angular/core';
 import { Subject, Observable } from 'rxjs';
 import * as equal from 'deep-equal';
+import { AuthService } from './auth.service';
 
 /**
  * Contains the data in a message returned by the dispatcher
@@ -21,6 +22,13 @@ interface DispatcherRequest<T> {
   payload: T;
 }
 
+/**
+ * Contains the payload for an authentication request
+ */
+interface AuthenticateRequestPayload {
+  token: string;
+}
+
 /**
  * Contains the payload for a subscription request
  */
@@ -74,11 +82,11 @@ export class DispatcherService {
 
   private subscriptions: SubjectSubscription[] = [];
 
-  constructor() {
-    this.connection = new Promise<void>(resolve => {
+  constructor(private authService: AuthService) {
+    this.connection = new Promise<void>((resolve, reject) => {
       this.socket = new WebSocket(`${window.location.protocol == 'https:' ? 'wss' : 'ws'}://${window.location.host == 'localhost' ? 'localhost:5000' : window.location.host}/api/dispatcher`);
       this.socket.addEventListener("open", (e) => {
-        resolve();
+        this.authenticate().then(resolve, reject);
       });
 
       this.socket.addEventListener("message", (e) => {
@@ -88,6 +96,20 @@ export class DispatcherService {
 
   }
 
+  /**
+   * Authenticates the connection with the access token of the current session, as the dispatcher closes connections
+   * that don't authenticate shortly after connecting
+   * @returns {Promise<void>} - Promise that resolves once the dispatcher accepted the token
+   */
+  private async authenticate(): Promise<void> {
+    await this.authService.ensureFreshToken();
+
+    const response = await this.send<AuthenticateRequestPayload, any>("authenticate", {token: sessionStorage.getItem("jwt")});
+    if(response.action == "error") {
+      throw new Error(response.payload.message);
+    }
+  }
+
   /**
    * Returns a subscription to a given subject with the given parameters
    * @param subject - The subject to subscribe to
@@ -146,6 +168,16 @@ export class DispatcherService {
    */
   private async sendRequest<RqP, RsP>(action: string, payload: RqP): Promise<DispatcherMessage<RsP>> {
     await this.connection;
+    return await this.send<RqP, RsP>(action, payload);
+  }
+
+  /**
+   * Sends a request over the socket and waits for its response, without waiting for the connection to be authenticated
+   * @param action - The action of the request
+   * @param payload - The payload of the request
+   * @returns {Promise<DispatcherMessage<RsP>>} - Promise resolving to the response
+   */
+  private async send<RqP, RsP>(action: string, payload: RqP): Promise<DispatcherMessage<RsP>> {
     this.socket.send(JSON.stringify({
       requestId: this.requestIdCounter,
       action,
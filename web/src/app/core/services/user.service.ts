@@ -65,7 +65,7 @@ export class UserService extends APIInterface<User> {
    * @returns {Promise<User[]>} The found users
    */
   async listUsersWithRole(roles: string[]) {
-    const json = await this.http.getJSON<ModelJson<User>[]>(`/api${this.baseURL}`, {searchParams: {role: roles.join("|")}});
+    const json = await this.http.getJSON<ModelJson<User>[]>(`/api${this.baseURL}`, {searchParams: {role: roles}});
     return json.map(item => new this.model(item));
   }
 }
\ No newline at end of file